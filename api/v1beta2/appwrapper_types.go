@@ -20,6 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // AppWrapperSpec defines the desired state of the AppWrapper
@@ -33,6 +34,33 @@ type AppWrapperSpec struct {
 
 	// ManagedBy is used to indicate the controller or entity that manages the AppWrapper.
 	ManagedBy *string `json:"managedBy,omitempty"`
+
+	// ConcurrencyKey identifies the logical job of the AppWrapper.
+	// An AppWrapper cannot be created while another active AppWrapper in its namespace has the same ConcurrencyKey.
+	// AppWrappers with the same ConcurrencyKey that are created concurrently may all be accepted; they are then deployed one at a time.
+	//+optional
+	ConcurrencyKey string `json:"concurrencyKey,omitempty"`
+
+	// ParentRef optionally identifies a resource in the namespace of the AppWrapper, such as a pipeline run,
+	// that the AppWrapper is a part of. The AppWrapper is reconciled whenever a watched parent changes.
+	//+optional
+	ParentRef *AppWrapperParentReference `json:"parentRef,omitempty"`
+}
+
+// AppWrapperParentReference identifies a resource in the namespace of an AppWrapper
+type AppWrapperParentReference struct {
+	// APIVersion of the parent resource
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the parent resource
+	Kind string `json:"kind"`
+
+	// Name of the parent resource
+	Name string `json:"name"`
+
+	// UID of the parent resource; if set, the reference only matches this incarnation of the named resource
+	//+optional
+	UID types.UID `json:"uid,omitempty"`
 }
 
 // AppWrapperComponent describes a single wrapped Kubernetes resource
@@ -50,6 +78,11 @@ type AppWrapperComponent struct {
 	//+optional
 	PodSetInfos []AppWrapperPodSetInfo `json:"podSetInfos,omitempty"`
 
+	// EndpointPath is the path within the status of the deployed Component (for example "status.dashboardURL")
+	// to a string value, such as an access URL, that is mirrored into the Endpoint of the Component's status
+	//+optional
+	EndpointPath string `json:"endpointPath,omitempty"`
+
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:EmbeddedResource
 	// Template defines the Kubernetes resource for the Component
@@ -91,10 +124,33 @@ type AppWrapperStatus struct {
 	//+optional
 	Phase AppWrapperPhase `json:"phase,omitempty"`
 
+	// Healthy summarizes Phase and Conditions: it is false while the AppWrapper is Resetting or Failed
+	// or has an Unhealthy condition and true otherwise
+	//+optional
+	Healthy bool `json:"healthy"`
+
+	// Summary is a human-readable description of the state of the AppWrapper derived from Phase and Conditions
+	//+optional
+	Summary string `json:"summary,omitempty"`
+
 	// Retries counts the number of times the AppWrapper has entered the Resetting Phase
+	// because of a failure attributed to the application
 	//+optional
 	Retries int32 `json:"resettingCount,omitempty"`
 
+	// InfrastructureRetries counts the number of times the AppWrapper has entered the Resetting Phase
+	// because of a failure attributed to the infrastructure (node evictions, Autopilot, unschedulable Pods)
+	//+optional
+	InfrastructureRetries int32 `json:"infrastructureResettingCount,omitempty"`
+
+	// DeployedComponents counts the number of Components that are currently deployed
+	//+optional
+	DeployedComponents int32 `json:"deployedComponents,omitempty"`
+
+	// PodStatus summarizes the phases of the Pods created by the AppWrapper's Components
+	//+optional
+	PodStatus *AppWrapperPodStatus `json:"podStatus,omitempty"`
+
 	// Conditions hold the latest available observations of the AppWrapper current state.
 	//
 	// The type of the condition could be:
@@ -104,6 +160,9 @@ type AppWrapperStatus struct {
 	// - PodsReady: All pods of the contained resources are in the Ready or Succeeded state
 	// - Unhealthy: One or more of the contained resources is unhealthy
 	// - DeletingResources: The contained resources are in the process of being deleted from the cluster
+	// - DeploymentPending: Creation of the contained resources failed with a transient error and is being retried
+	// - Ready: The AppWrapper is Running, all its components are deployed, none of its pods have failed, and PodsReady is true
+	// - Throttled: The controller is deferring an action on the AppWrapper because of a limit; the Reason names the limit
 	//
 	//+optional
 	//+patchMergeKey=type
@@ -114,6 +173,84 @@ type AppWrapperStatus struct {
 
 	// ComponentStatus parallels the Components array in the Spec and tracks the actually deployed resources
 	ComponentStatus []AppWrapperComponentStatus `json:"componentStatus,omitempty"`
+
+	// PhaseTimings records the cumulative time the AppWrapper has spent in selected phases
+	//+optional
+	PhaseTimings *AppWrapperPhaseTimings `json:"phaseTimings,omitempty"`
+
+	// DeletionReason records why the wrapped resources were most recently deleted by the controller
+	//+optional
+	DeletionReason AppWrapperDeletionReason `json:"deletionReason,omitempty"`
+
+	// FailedPodDeletions tracks the failed Pods of service components that were deleted to let their controllers recreate them
+	//+optional
+	FailedPodDeletions *AppWrapperFailedPodDeletions `json:"failedPodDeletions,omitempty"`
+}
+
+// AppWrapperFailedPodDeletions counts the failed Pods deleted within the current FailureGracePeriod window
+type AppWrapperFailedPodDeletions struct {
+	// Count is the number of failed Pods deleted since WindowStart
+	Count int32 `json:"count"`
+
+	// WindowStart is the time of the first deletion of the current window
+	WindowStart metav1.Time `json:"windowStart"`
+}
+
+// AppWrapperPhaseTimings records the cumulative time an AppWrapper has spent in the Suspended, Resuming, and Running phases.
+// The time spent in the current phase is added when the AppWrapper leaves it.
+type AppWrapperPhaseTimings struct {
+	// LastTransitionTime is the time of the most recent phase transition
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// Suspended is the cumulative time spent in the Suspended phase
+	Suspended metav1.Duration `json:"suspended"`
+
+	// Resuming is the cumulative time spent in the Resuming phase
+	Resuming metav1.Duration `json:"resuming"`
+
+	// Running is the cumulative time spent in the Running phase
+	Running metav1.Duration `json:"running"`
+}
+
+// AppWrapperPodStatus summarizes the Pods created by an AppWrapper
+type AppWrapperPodStatus struct {
+	// Expected is the number of Pods the AppWrapper is expected to create
+	Expected int32 `json:"expected"`
+
+	// Pending is the number of Pods in the Pending phase
+	Pending int32 `json:"pending"`
+
+	// Running is the number of Pods in the Running phase
+	Running int32 `json:"running"`
+
+	// Succeeded is the number of Pods in the Succeeded phase
+	Succeeded int32 `json:"succeeded"`
+
+	// Failed is the number of Pods in the Failed phase
+	Failed int32 `json:"failed"`
+
+	// Lost is the number of Pods that are bound to unreachable Nodes or whose phase is Unknown.
+	// Unreachable Nodes are only detected when the operator is configured to monitor Nodes.
+	// Lost Pods are not included in the Pending and Running counts.
+	//+optional
+	Lost int32 `json:"lost,omitempty"`
+
+	// MaxSchedulingLatencySeconds is the longest time, in seconds, between the creation of a Pod
+	// and its PodScheduled condition becoming true
+	//+optional
+	MaxSchedulingLatencySeconds int32 `json:"maxSchedulingLatencySeconds,omitempty"`
+
+	// AverageSchedulingLatencySeconds is the average time, in seconds, between the creation of a Pod
+	// and its PodScheduled condition becoming true
+	//+optional
+	AverageSchedulingLatencySeconds int32 `json:"averageSchedulingLatencySeconds,omitempty"`
+
+	// Nodes is the sorted list of the names of the Nodes that host the AppWrapper's non-terminated Pods.
+	// At most 64 Nodes are listed.
+	//+optional
+	//+listType=set
+	//+kubebuilder:validation:MaxItems=64
+	Nodes []string `json:"nodes,omitempty"`
 }
 
 // AppWrapperComponentStatus tracks the status of a single managed Component
@@ -130,11 +267,22 @@ type AppWrapperComponentStatus struct {
 	// PodSets is the validated PodSets for the Component (either from AppWrapperComponent.DeclaredPodSets or inferred by the controller)
 	PodSets []AppWrapperPodSet `json:"podSets"`
 
+	// DesiredPods is the number of Pods the Component's controller currently intends to run.
+	// It is only set for Components whose Pod count is determined at runtime (eg DaemonSets)
+	// and when set it overrides the replica counts of PodSets when computing the expected number of Pods.
+	//+optional
+	DesiredPods *int32 `json:"desiredPods,omitempty"`
+
+	// Endpoint is the value found at the Component's EndpointPath in its deployed resource
+	//+optional
+	Endpoint string `json:"endpoint,omitempty"`
+
 	// Conditions hold the latest available observations of the Component's current state.
 	//
 	// The type of the condition could be:
 	//
 	// - ResourcesDeployed: The component is deployed on the cluster
+	// - DeletionComplete: Deletion of the component was initiated and whether it has been removed from the cluster
 	//
 	//+optional
 	//+patchMergeKey=type
@@ -159,6 +307,18 @@ const (
 	AppWrapperTerminating AppWrapperPhase = "Terminating"
 )
 
+// AppWrapperDeletionReason is the reason the wrapped resources of an appwrapper were deleted
+// +kubebuilder:validation:Enum=SuspendedByKueue;Reset;Failed;SucceededTTL;UserDeleted
+type AppWrapperDeletionReason string
+
+const (
+	DeletionReasonSuspendedByKueue AppWrapperDeletionReason = "SuspendedByKueue"
+	DeletionReasonReset            AppWrapperDeletionReason = "Reset"
+	DeletionReasonFailed           AppWrapperDeletionReason = "Failed"
+	DeletionReasonSucceededTTL     AppWrapperDeletionReason = "SucceededTTL"
+	DeletionReasonUserDeleted      AppWrapperDeletionReason = "UserDeleted"
+)
+
 type AppWrapperCondition string
 
 const (
@@ -167,6 +327,12 @@ const (
 	PodsReady         AppWrapperCondition = "PodsReady"
 	Unhealthy         AppWrapperCondition = "Unhealthy"
 	DeletingResources AppWrapperCondition = "DeletingResources"
+	DeploymentPending AppWrapperCondition = "DeploymentPending"
+	Ready             AppWrapperCondition = "Ready"
+	DeletionComplete  AppWrapperCondition = "DeletionComplete"
+	Throttled         AppWrapperCondition = "Throttled"
+	QueueMissing      AppWrapperCondition = "QueueMissing"
+	MigrationDraining AppWrapperCondition = "MigrationDraining"
 )
 
 const (
@@ -175,16 +341,50 @@ const (
 	FailureGracePeriodDurationAnnotation   = "workload.codeflare.dev.appwrapper/failureGracePeriodDuration"
 	RetryPausePeriodDurationAnnotation     = "workload.codeflare.dev.appwrapper/retryPausePeriodDuration"
 	RetryLimitAnnotation                   = "workload.codeflare.dev.appwrapper/retryLimit"
+	FailedPodDeletionLimitAnnotation       = "workload.codeflare.dev.appwrapper/failedPodDeletionLimit"
 	ForcefulDeletionGracePeriodAnnotation  = "workload.codeflare.dev.appwrapper/forcefulDeletionGracePeriodDuration"
+	SuccessDeletionGracePeriodAnnotation   = "workload.codeflare.dev.appwrapper/successDeletionGracePeriodDuration"
 	DeletionOnFailureGracePeriodAnnotation = "workload.codeflare.dev.appwrapper/deletionOnFailureGracePeriodDuration"
 	SuccessTTLAnnotation                   = "workload.codeflare.dev.appwrapper/successTTLDuration"
+	FailedTTLAnnotation                    = "workload.codeflare.dev.appwrapper/failedTTLDuration"
 	TerminalExitCodesAnnotation            = "workload.codeflare.dev.appwrapper/terminalExitCodes"
 	RetryableExitCodesAnnotation           = "workload.codeflare.dev.appwrapper/retryableExitCodes"
+	ActiveDeadlineSecondsAnnotation        = "workload.codeflare.dev/activeDeadlineSeconds"
+	DeletionPolicyAnnotation               = "workload.codeflare.dev/deletionPolicy"
+	PriorityClassNameAnnotation            = "workload.codeflare.dev.appwrapper/priorityClassName"
+	NoForcefulDeletionAnnotation           = "workload.codeflare.dev/noForcefulDeletion"
+	SchedulerNameAnnotation                = "workload.codeflare.dev/schedulerName"
+	MinimumRunningDurationAnnotation       = "workload.codeflare.dev.appwrapper/minimumRunningDuration"
+	MigrationDrainPeriodAnnotation         = "workload.codeflare.dev/migrationDrainPeriod"
+	SuccessPolicyAnnotation                = "workload.codeflare.dev/successPolicy"
+	DeletionPropagationAnnotation          = "workload.codeflare.dev/deletionPropagation"
+	PauseReconcileAnnotation               = "workload.codeflare.dev/pauseReconcile"
+	WorkloadPriorityAnnotation             = "workload.codeflare.dev/priority"
+	// LastReconcileAnnotation is set by the controller (if configured to record it) to a compact JSON summary
+	// of the status of the AppWrapper before it was last changed by a reconcile
+	LastReconcileAnnotation = "workload.codeflare.dev.appwrapper/lastReconcile"
+	// SharedScratchAnnotation is a component annotation of the form [sizeLimit:]mountPath (for example "10Gi:/scratch")
+	// that requests an emptyDir volume, mounted at mountPath into every container of each of the component's PodSets
+	SharedScratchAnnotation = "workload.codeflare.dev/sharedScratch"
+)
+
+const (
+	// DeletionPolicyOrphan causes the wrapped resources of a deleted AppWrapper to be left in the cluster.
+	// It takes precedence over the DeletionPropagationAnnotation, which only applies when the wrapped resources are deleted.
+	DeletionPolicyOrphan = "orphan"
 )
 
 const (
 	AppWrapperControllerName = "workload.codeflare.dev/appwrapper-controller"
 	AppWrapperLabel          = "workload.codeflare.dev/appwrapper"
+	// ServiceComponentLabel is added to the Pods of long-running service components (Deployments, StatefulSets, DaemonSets)
+	ServiceComponentLabel = "workload.codeflare.dev/service-component"
+	// PodSetLabel is added to the Pods of every PodSet to identify the component and PodSet they belong to
+	PodSetLabel = "workload.codeflare.dev/podset"
+	// SharedScratchVolumeName is the name of the volume injected for the SharedScratchAnnotation
+	SharedScratchVolumeName = "shared-scratch"
+	// UsernameLabel is set by the AppWrapper webhook to the sanitized name of the user who created the AppWrapper
+	UsernameLabel = "workload.codeflare.dev/user"
 )
 
 //+kubebuilder:object:root=true
@@ -194,7 +394,12 @@ const (
 //+kubebuilder:printcolumn:name="Quota Reserved",type="string",JSONPath=".status.conditions[?(@.type==\"QuotaReserved\")].status"
 //+kubebuilder:printcolumn:name="Resources Deployed",type="string",JSONPath=".status.conditions[?(@.type==\"ResourcesDeployed\")].status"
 //+kubebuilder:printcolumn:name="Unhealthy",type="string",JSONPath=".status.conditions[?(@.type==\"Unhealthy\")].status"
+//+kubebuilder:printcolumn:name="Running",type="integer",JSONPath=".status.podStatus.running"
+//+kubebuilder:printcolumn:name="Expected",type="integer",JSONPath=".status.podStatus.expected"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:printcolumn:name="Parent",type="string",JSONPath=".spec.parentRef.name",priority=1
+//+kubebuilder:printcolumn:name="Healthy",type="boolean",JSONPath=".status.healthy",priority=1
+//+kubebuilder:printcolumn:name="Summary",type="string",JSONPath=".status.summary",priority=1
 
 // AppWrapper is the Schema for the appwrappers API
 type AppWrapper struct {
@@ -100,6 +100,11 @@ func (in *AppWrapperComponentStatus) DeepCopyInto(out *AppWrapperComponentStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DesiredPods != nil {
+		in, out := &in.DesiredPods, &out.DesiredPods
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -119,6 +124,22 @@ func (in *AppWrapperComponentStatus) DeepCopy() *AppWrapperComponentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppWrapperFailedPodDeletions) DeepCopyInto(out *AppWrapperFailedPodDeletions) {
+	*out = *in
+	in.WindowStart.DeepCopyInto(&out.WindowStart)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWrapperFailedPodDeletions.
+func (in *AppWrapperFailedPodDeletions) DeepCopy() *AppWrapperFailedPodDeletions {
+	if in == nil {
+		return nil
+	}
+	out := new(AppWrapperFailedPodDeletions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWrapperList) DeepCopyInto(out *AppWrapperList) {
 	*out = *in
@@ -151,6 +172,40 @@ func (in *AppWrapperList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppWrapperParentReference) DeepCopyInto(out *AppWrapperParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWrapperParentReference.
+func (in *AppWrapperParentReference) DeepCopy() *AppWrapperParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AppWrapperParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppWrapperPhaseTimings) DeepCopyInto(out *AppWrapperPhaseTimings) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	out.Suspended = in.Suspended
+	out.Resuming = in.Resuming
+	out.Running = in.Running
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWrapperPhaseTimings.
+func (in *AppWrapperPhaseTimings) DeepCopy() *AppWrapperPhaseTimings {
+	if in == nil {
+		return nil
+	}
+	out := new(AppWrapperPhaseTimings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWrapperPodSet) DeepCopyInto(out *AppWrapperPodSet) {
 	*out = *in
@@ -219,6 +274,26 @@ func (in *AppWrapperPodSetInfo) DeepCopy() *AppWrapperPodSetInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppWrapperPodStatus) DeepCopyInto(out *AppWrapperPodStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWrapperPodStatus.
+func (in *AppWrapperPodStatus) DeepCopy() *AppWrapperPodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AppWrapperPodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWrapperSpec) DeepCopyInto(out *AppWrapperSpec) {
 	*out = *in
@@ -234,6 +309,11 @@ func (in *AppWrapperSpec) DeepCopyInto(out *AppWrapperSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ParentRef != nil {
+		in, out := &in.ParentRef, &out.ParentRef
+		*out = new(AppWrapperParentReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWrapperSpec.
@@ -249,6 +329,11 @@ func (in *AppWrapperSpec) DeepCopy() *AppWrapperSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWrapperStatus) DeepCopyInto(out *AppWrapperStatus) {
 	*out = *in
+	if in.PodStatus != nil {
+		in, out := &in.PodStatus, &out.PodStatus
+		*out = new(AppWrapperPodStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -263,6 +348,16 @@ func (in *AppWrapperStatus) DeepCopyInto(out *AppWrapperStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PhaseTimings != nil {
+		in, out := &in.PhaseTimings, &out.PhaseTimings
+		*out = new(AppWrapperPhaseTimings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailedPodDeletions != nil {
+		in, out := &in.FailedPodDeletions, &out.FailedPodDeletions
+		*out = new(AppWrapperFailedPodDeletions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWrapperStatus.
@@ -91,12 +91,7 @@ func main() {
 	namespace, err := getNamespace()
 	exitOnError(err, "unable to get operator namespace")
 
-	cfg := &config.OperatorConfig{
-		AppWrapper:        config.NewAppWrapperConfig(),
-		CertManagement:    config.NewCertManagementConfig(namespace),
-		ControllerManager: config.NewControllerManagerConfig(),
-		WebhooksEnabled:   ptr.To(true),
-	}
+	cfg := config.NewOperatorConfig(namespace)
 
 	k8sConfig, err := ctrl.GetConfig()
 	exitOnError(err, "unable to get client config")
@@ -108,7 +103,20 @@ func main() {
 	exitOnError(loadIntoOrCreate(ctx, k8sClient, cmName, cfg), "unable to initialise configuration")
 
 	setupLog.Info("Configuration", "config", cfg)
-	exitOnError(config.ValidateAppWrapperConfig(cfg.AppWrapper), "invalid appwrapper config")
+	if cfg.AppWrapper.LenientConfigValidation {
+		repaired, err := config.SanitizeAppWrapperConfig(cfg.AppWrapper)
+		for _, problem := range repaired {
+			setupLog.Info("WARNING: invalid appwrapper config field reset to its default", "problem", problem.Error())
+		}
+		exitOnError(err, "invalid appwrapper config")
+	} else {
+		exitOnError(config.ValidateAppWrapperConfig(cfg.AppWrapper), "invalid appwrapper config")
+	}
+	exitOnError(config.ValidateControllerManagerConfig(cfg.ControllerManager), "invalid controller manager config")
+	if err := controller.ValidateLabelPrefix(ctx, k8sClient, cfg.AppWrapper); err != nil {
+		setupLog.Info("WARNING: existing AppWrappers may not be deletable with the configured labelPrefix", "problem", err.Error())
+	}
+	exitOnError(controller.RegisterPodSetTemplates(cfg.AppWrapper), "invalid appwrapper config")
 
 	tlsOpts := []func(*tls.Config){}
 	if !cfg.ControllerManager.EnableHTTP2 {
@@ -129,6 +137,7 @@ func main() {
 
 	mgr, err := ctrl.NewManager(k8sConfig, ctrl.Options{
 		Scheme: scheme,
+		Cache:  config.NewCacheOptions(cfg.ControllerManager),
 		Metrics: metricsserver.Options{
 			BindAddress:    cfg.ControllerManager.Metrics.BindAddress,
 			FilterProvider: filters.WithAuthenticationAndAuthorization,
@@ -153,6 +162,7 @@ func main() {
 		close(certsReady)
 	}
 
+	liveConfig := config.NewLiveAppWrapperConfig(cfg.AppWrapper)
 	go func() {
 		setupLog.Info("Waiting for certificates to be generated")
 		<-certsReady
@@ -160,7 +170,8 @@ func main() {
 		if ptr.Deref(cfg.WebhooksEnabled, false) {
 			exitOnError(controller.SetupWebhooks(mgr, cfg.AppWrapper), "unable to configure webhook")
 		}
-		exitOnError(controller.SetupControllers(mgr, cfg.AppWrapper), "unable to start controllers")
+		exitOnError(controller.SetupControllers(mgr, cfg.AppWrapper, liveConfig), "unable to start controllers")
+		exitOnError(controller.SetupConfigMonitor(mgr, cfg, liveConfig, cmName), "unable to start config monitor")
 	}()
 
 	exitOnError(controller.SetupIndexers(ctx, mgr, cfg.AppWrapper), "unable to setup indexers")
@@ -205,15 +216,7 @@ func loadIntoOrCreate(ctx context.Context, k8sClient client.Client, cmName types
 		return err
 	}
 
-	if len(configMap.Data) != 1 {
-		return fmt.Errorf("cannot resolve config from ConfigMap %s/%s", configMap.Namespace, configMap.Name)
-	}
-
-	for _, data := range configMap.Data {
-		return yaml.Unmarshal([]byte(data), cfg)
-	}
-
-	return nil
+	return config.UnmarshalConfigMap(configMap, cfg)
 }
 
 func exitOnError(err error, msg string) {
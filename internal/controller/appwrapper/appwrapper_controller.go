@@ -18,11 +18,16 @@ package appwrapper
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,75 +35,239 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 
 	workloadv1beta2 "github.com/project-codeflare/appwrapper/api/v1beta2"
+	wlc "github.com/project-codeflare/appwrapper/internal/controller/workload"
 	"github.com/project-codeflare/appwrapper/internal/metrics"
 	"github.com/project-codeflare/appwrapper/pkg/config"
 	"github.com/project-codeflare/appwrapper/pkg/utils"
 )
 
 const (
+	// AppWrapperFinalizer is the finalizer used with the default LabelPrefix
 	AppWrapperFinalizer = "workload.codeflare.dev/finalizer"
+
+	// queueMissingDeactivationAnnotation marks a Workload deactivated because the queue of its AppWrapper is missing
+	queueMissingDeactivationAnnotation = "workload.codeflare.dev/deactivated-for-missing-queue"
 )
 
+// ValidateFinalizerPrefix returns an error if awConfig has a non-default LabelPrefix and an existing AppWrapper has
+// the finalizer derived from the default LabelPrefix but not the finalizer derived from awConfig's LabelPrefix.
+// Unless such an AppWrapper is managed by another operator that uses the default LabelPrefix,
+// no controller will ever remove its finalizer, which will then be impossible to delete.
+func ValidateFinalizerPrefix(ctx context.Context, c client.Reader, awConfig *config.AppWrapperConfig) error {
+	finalizer := config.AppWrapperFinalizer(awConfig)
+	if finalizer == AppWrapperFinalizer {
+		return nil
+	}
+	aws := &workloadv1beta2.AppWrapperList{}
+	if err := c.List(ctx, aws); err != nil {
+		return err
+	}
+	for _, aw := range aws.Items {
+		if controllerutil.ContainsFinalizer(&aw, AppWrapperFinalizer) && !controllerutil.ContainsFinalizer(&aw, finalizer) {
+			return fmt.Errorf("AppWrapper %v/%v has finalizer %v instead of %v; it will not be deleted unless another operator uses the default labelPrefix",
+				aw.Namespace, aw.Name, AppWrapperFinalizer, finalizer)
+		}
+	}
+	return nil
+}
+
 // AppWrapperReconciler reconciles an appwrapper
 type AppWrapperReconciler struct {
 	client.Client
 	Recorder record.EventRecorder
 	Scheme   *runtime.Scheme
 	Config   *config.AppWrapperConfig
+	// Live publishes the configuration changes applied at runtime; if set, it supersedes Config
+	Live *config.LiveAppWrapperConfig
+	// Clock timestamps phase transitions; the real clock is used if nil
+	Clock clock.PassiveClock
 }
 
 type podStatusSummary struct {
-	expected        int32
-	pending         int32
-	running         int32
-	succeeded       int32
-	failed          int32
-	terminalFailure bool
-	noExecuteNodes  sets.Set[string]
+	expected int32
+	// number of Pods of non-service components that must succeed (exceeds expected for Indexed Jobs with completions > parallelism)
+	succeededExpected int32
+	pending           int32
+	running           int32
+	succeeded         int32
+	failed            int32
+	serviceExpected   int32
+	serviceRunning    int32
+	failedPodSets     sets.Set[string]
+	terminalFailure   bool
+	oomKilled         bool
+	noExecuteNodes    sets.Set[string]
+	// number of failed Pods whose failure is attributed to the infrastructure rather than the application
+	infrastructureFailed int32
+	// number of pending Pods that the scheduler was unable to place
+	unschedulable int32
+	// number of non-terminated Pods that are bound to unreachable Nodes or whose phase is Unknown
+	lost int32
+	// number of scheduled Pods and their longest and total time from creation to scheduling
+	scheduled              int32
+	maxSchedulingLatency   time.Duration
+	totalSchedulingLatency time.Duration
+	// description of the first container of a failed Pod that terminated with a non-zero exit code
+	failedContainer string
+	// indices of the components that have pending Pods or running Pods that are not yet ready
+	pendingComponents sets.Set[int]
+	// names of the Nodes that host non-terminated Pods
+	nodes sets.Set[string]
+	// names and UIDs of the failed Pods of service components
+	failedServicePods map[string]types.UID
 }
 
 type componentStatusSummary struct {
 	expected int32
 	deployed int32
 	failed   int32
+	unready  int32
+	// number of Deployments and StatefulSets whose rollout has not completed; this only delays PodsReady
+	rollingOut int32
+	// number of expected Pods of components that are ready even when scaled to zero (eg InferenceServices)
+	readyWithoutPods int32
+	// number of Pods that are no longer required to succeed because their Job completed without them (eg via a successPolicy)
+	waivedSucceeded int32
+}
+
+// workloadCompleted returns true if all Pods of completion-contributing components have succeeded.
+// Pods of service components never complete, so they are instead required to be running and ready.
+// An AppWrapper that only contains service components never completes.
+// If minSucceeded is non-zero (the AppWrapper has a SuccessPolicy), failed Pods are tolerated and
+// only minSucceeded Pods must have succeeded once no Pods are pending or running.
+// Pods of Jobs that have already completed without them are not required to succeed.
+func (ps *podStatusSummary) workloadCompleted(cs *componentStatusSummary, minSucceeded int32) bool {
+	required, failed := ps.succeededExpected-cs.waivedSucceeded, ps.failed
+	if minSucceeded > 0 {
+		required, failed = min(minSucceeded, required), 0
+	}
+	if ps.serviceExpected == 0 {
+		return ps.succeeded >= required && (ps.pending+ps.running+failed == 0)
+	}
+	return ps.expected > ps.serviceExpected &&
+		ps.succeeded >= required &&
+		ps.pending+failed == 0 &&
+		ps.running == ps.serviceRunning &&
+		ps.serviceRunning >= ps.serviceExpected &&
+		cs.unready == 0
+}
+
+// addPendingComponent records that the component of pod has a Pod that is still warming up
+func (ps *podStatusSummary) addPendingComponent(pod *v1.Pod) {
+	if labelValue, ok := pod.Labels[workloadv1beta2.PodSetLabel]; ok {
+		if componentIdx, _, err := utils.ParsePodSetLabelValue(labelValue); err == nil {
+			if ps.pendingComponents == nil {
+				ps.pendingComponents = make(sets.Set[int])
+			}
+			ps.pendingComponents.Insert(componentIdx)
+		}
+	}
+}
+
+// successAchievable returns true if enough Pods have succeeded or may still succeed to satisfy a SuccessPolicy of minSucceeded
+func (ps *podStatusSummary) successAchievable(minSucceeded int32) bool {
+	return minSucceeded > 0 && ps.succeeded+ps.pending+ps.running-ps.serviceRunning >= minSucceeded
+}
+
+// failedPodsCategory returns infrastructureFailure if the failures of all failed Pods are attributed to the infrastructure
+func (ps *podStatusSummary) failedPodsCategory() failureCategory {
+	if ps.failed > 0 && ps.infrastructureFailed == ps.failed {
+		return infrastructureFailure
+	}
+	return applicationFailure
+}
+
+// failureCategory determines which retry budget is consumed when an AppWrapper is reset
+type failureCategory int
+
+const (
+	// applicationFailure is caused by the workload itself (eg a container exiting with a non-zero code)
+	applicationFailure failureCategory = iota
+	// infrastructureFailure is caused by the cluster (eg Pod evictions, Autopilot NoExecute taints, unschedulable Pods)
+	infrastructureFailure
+)
+
+// remediationHints maps the Reason of the Unhealthy condition of a Failed AppWrapper
+// to a short hint that is included in the terminal event emitted for the AppWrapper.
+var remediationHints = map[string]string{
+	"MalformedComponent":    "recreate the AppWrapper from a valid specification",
+	"CreateFailed":          "check that the CRDs of all wrapped resources are installed and that the AppWrapper controller has RBAC permissions to create them",
+	"MissingComponent":      "check for users or controllers that deleted resources owned by the AppWrapper",
+	"FailedComponent":       "inspect the status of the failed wrapped resources",
+	"FoundFailedPods":       "inspect the logs of the failed pods",
+	"OOMKilled":             "increase the memory limits of the wrapped containers",
+	"InsufficientPodsReady": "check for unschedulable pods or image pull errors",
+	"DeadlineExceeded":      "increase the activeDeadlineSeconds annotation or shorten the workload",
 }
 
 // permission to fully control appwrappers
 //+kubebuilder:rbac:groups=workload.codeflare.dev,resources=appwrappers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=workload.codeflare.dev,resources=appwrappers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=workload.codeflare.dev,resources=appwrappers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=localqueues,verbs=get;list;watch
 
-// permission to edit wrapped resources: pods, services, jobs, podgroups, pytorchjobs, rayclusters
+// permission to edit wrapped resources: pods, services, jobs, cronjobs, podgroups, pytorchjobs, tfjobs, xgboostjobs, rayclusters, rayjobs, rayservices, inferenceservices, leaderworkersets
 
 //+kubebuilder:rbac:groups="",resources=pods;services,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=scheduling.sigs.k8s.io,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=scheduling.x-k8s.io,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=kubeflow.org,resources=pytorchjobs,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=ray.io,resources=rayclusters;rayjobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kubeflow.org,resources=pytorchjobs;tfjobs;xgboostjobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ray.io,resources=rayclusters;rayjobs;rayservices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=leaderworkerset.x-k8s.io,resources=leaderworkersets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile reconciles an appwrapper
 // Please see [aw-states] for documentation of this method.
 //
 // [aw-states]: https://project-codeflare.github.io/appwrapper/arch-controller/#framework-controller
-//
-//gocyclo:ignore
 func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.withLiveConfig().reconcile(ctx, req)
+}
+
+// withLiveConfig returns a copy of r whose Config is the configuration currently published by Live,
+// so that a reconcile sees the same configuration from beginning to end
+func (r *AppWrapperReconciler) withLiveConfig() *AppWrapperReconciler {
+	if r.Live == nil {
+		return r
+	}
+	snapshot := *r
+	snapshot.Config = r.Live.Load()
+	snapshot.Live = nil
+	return &snapshot
+}
+
+//gocyclo:ignore
+func (r *AppWrapperReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	aw := &workloadv1beta2.AppWrapper{}
 	if err := r.Get(ctx, req.NamespacedName, aw); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.ForgetComponents(req.NamespacedName)
+			metrics.ForgetQuotaReserved(req.NamespacedName)
+			forgetPaused(req.NamespacedName)
+		}
 		return ctrl.Result{}, nil
 	}
 
@@ -107,20 +276,40 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
+	// aw is updated in place by status patches; report the deployed components it has when reconciliation ends
+	defer recordComponentMetrics(req.NamespacedName, aw)
+	defer r.recordQuotaReservedMetrics(req.NamespacedName, aw)
+	if r.Config.RecordLastReconcile {
+		defer r.recordLastReconcile(ctx, aw, lastReconcileSnapshot(aw))
+	}
+
 	// handle deletion first
 	if !aw.DeletionTimestamp.IsZero() {
-		if controllerutil.ContainsFinalizer(aw, AppWrapperFinalizer) {
+		if controllerutil.ContainsFinalizer(aw, config.AppWrapperFinalizer(r.Config)) {
 			statusUpdated := false
 			orig := copyForStatusPatch(aw)
+			// The orphan DeletionPolicy takes precedence over the DeletionPropagation annotation: the components are not deleted at all
+			if meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)) &&
+				aw.Annotations[workloadv1beta2.DeletionPolicyAnnotation] == workloadv1beta2.DeletionPolicyOrphan {
+				if !r.orphanComponents(ctx, aw) {
+					return ctrl.Result{RequeueAfter: r.Config.DeletionRequeueInterval}, nil // retry after a short while
+				}
+				meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+					Type:    string(workloadv1beta2.ResourcesDeployed),
+					Status:  metav1.ConditionFalse,
+					Reason:  string(workloadv1beta2.AppWrapperTerminating),
+					Message: "Resources orphaned",
+				})
+				statusUpdated = true
+			}
 			if meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)) {
-				if !r.deleteComponents(ctx, aw) {
+				if !r.deleteComponents(ctx, aw, workloadv1beta2.DeletionReasonUserDeleted) {
 					// one or more components are still terminating
-					if aw.Status.Phase != workloadv1beta2.AppWrapperTerminating {
-						// Set Phase for better UX, but ignore errors. We still want to requeue after 5 seconds (not immediately)
-						aw.Status.Phase = workloadv1beta2.AppWrapperTerminating
-						_ = r.Status().Patch(ctx, aw, client.MergeFrom(orig))
-					}
-					return ctrl.Result{RequeueAfter: 5 * time.Second}, nil // check after a short while
+					// Set Phase for better UX and persist the progress of the deletion (eg its escalation to forceful deletion),
+					// but ignore errors. We still want to requeue after a short while (not immediately)
+					aw.Status.Phase = workloadv1beta2.AppWrapperTerminating
+					_ = r.Status().Patch(ctx, aw, client.MergeFrom(orig))
+					return ctrl.Result{RequeueAfter: r.Config.DeletionRequeueInterval}, nil // check after a short while
 				}
 				meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 					Type:    string(workloadv1beta2.ResourcesDeployed),
@@ -146,7 +335,7 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				}
 			}
 
-			if controllerutil.RemoveFinalizer(aw, AppWrapperFinalizer) {
+			if controllerutil.RemoveFinalizer(aw, config.AppWrapperFinalizer(r.Config)) {
 				if err := r.Update(ctx, aw); err != nil {
 					return ctrl.Result{}, err
 				}
@@ -156,6 +345,25 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
+	// a paused AppWrapper is left exactly as it is (except for deletion, handled above) until it is unpaused
+	if r.reconcilePaused(ctx, aw) {
+		if startPaused(req.NamespacedName) {
+			r.Recorder.Event(aw, v1.EventTypeNormal, "ReconcilePaused", "Reconciliation is paused by the "+workloadv1beta2.PauseReconcileAnnotation+" annotation")
+		}
+		return ctrl.Result{}, nil
+	}
+	forgetPaused(req.NamespacedName)
+
+	// restore our finalizer if it was removed while resources are deployed; without it deletion would leak them
+	if meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)) {
+		if controllerutil.AddFinalizer(aw, config.AppWrapperFinalizer(r.Config)) {
+			if err := r.Update(ctx, aw); err != nil {
+				return ctrl.Result{}, err
+			}
+			log.FromContext(ctx).Info("Finalizer Restored")
+		}
+	}
+
 	switch aw.Status.Phase {
 
 	case workloadv1beta2.AppWrapperEmpty: // initial state
@@ -168,11 +376,19 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	case workloadv1beta2.AppWrapperSuspended: // no components deployed
 		if aw.Spec.Suspend {
+			// a Workload deactivated because its queue was missing is reactivated once the queue exists again
+			if r.Config.EnableKueueIntegrations && meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QueueMissing)) {
+				orig := copyForStatusPatch(aw)
+				if err := r.checkQueue(ctx, aw); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{}, r.Status().Patch(ctx, aw, client.MergeFrom(orig))
+			}
 			return ctrl.Result{}, nil // remain suspended
 		}
 
 		// ensure our finalizer is present before we deploy any resources
-		if controllerutil.AddFinalizer(aw, AppWrapperFinalizer) {
+		if controllerutil.AddFinalizer(aw, config.AppWrapperFinalizer(r.Config)) {
 			if err := r.Update(ctx, aw); err != nil {
 				return ctrl.Result{}, err
 			}
@@ -209,20 +425,59 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	case workloadv1beta2.AppWrapperResuming: // deploying components
 		if aw.Spec.Suspend {
-			return ctrl.Result{}, r.transitionToPhase(ctx, copyForStatusPatch(aw), aw, workloadv1beta2.AppWrapperSuspending) // abort deployment
+			orig := copyForStatusPatch(aw)
+			clearCondition(aw, workloadv1beta2.DeploymentPending, "DeploymentAborted", "")
+			clearCondition(aw, workloadv1beta2.Throttled, "DeploymentAborted", "")
+			return ctrl.Result{}, r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperSuspending) // abort deployment
+		}
+		if err := parseComponents(aw); err != nil {
+			orig := copyForStatusPatch(aw)
+			clearCondition(aw, workloadv1beta2.DeploymentPending, "MalformedComponent", "")
+			return ctrl.Result{}, r.failMalformedComponent(ctx, orig, aw, err)
+		}
+		if holder, err := r.concurrencyKeyHolder(ctx, aw); err != nil {
+			return ctrl.Result{}, err
+		} else if holder != "" {
+			// another AppWrapper with the same ConcurrencyKey was deployed first; wait for it to complete
+			orig := copyForStatusPatch(aw)
+			detailMsg := fmt.Sprintf("waiting for AppWrapper %v with concurrencyKey %q to complete", holder, aw.Spec.ConcurrencyKey)
+			if cond := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeploymentPending)); cond == nil ||
+				cond.Status != metav1.ConditionTrue || cond.Message != detailMsg {
+				r.Recorder.Event(aw, v1.EventTypeNormal, string(workloadv1beta2.DeploymentPending), detailMsg)
+			}
+			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+				Type:    string(workloadv1beta2.DeploymentPending),
+				Status:  metav1.ConditionTrue,
+				Reason:  "ConcurrencyKeyHeld",
+				Message: detailMsg,
+			})
+			return requeueAfter(r.Config.DeploymentRetryInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
 		}
 		err, fatal := r.createComponents(ctx, aw) // NOTE: createComponents applies patches to aw.Status incrementally as resources are created
 		orig := copyForStatusPatch(aw)
 		if err != nil {
+			clearCondition(aw, workloadv1beta2.Throttled, "CreateFailed", "")
+			detailMsg := fmt.Sprintf("error creating components: %v", err)
 			if !fatal {
 				startTime := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)).LastTransitionTime
 				graceDuration := r.admissionGraceDuration(ctx, aw)
 				if time.Now().Before(startTime.Add(graceDuration)) {
-					// be patient; non-fatal error; requeue and keep trying
-					return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+					// be patient; non-fatal error; requeue and keep trying.
+					// DeploymentPending is a separate condition so that updating its message does not affect the grace deadline.
+					if cond := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeploymentPending)); cond == nil ||
+						cond.Status != metav1.ConditionTrue || cond.Message != detailMsg {
+						r.Recorder.Event(aw, v1.EventTypeNormal, string(workloadv1beta2.DeploymentPending), detailMsg)
+					}
+					meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+						Type:    string(workloadv1beta2.DeploymentPending),
+						Status:  metav1.ConditionTrue,
+						Reason:  "CreateRetrying",
+						Message: detailMsg,
+					})
+					return requeueAfter(r.Config.DeploymentRetryInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
 				}
 			}
-			detailMsg := fmt.Sprintf("error creating components: %v", err)
+			clearCondition(aw, workloadv1beta2.DeploymentPending, "CreateFailed", "")
 			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 				Type:    string(workloadv1beta2.Unhealthy),
 				Status:  metav1.ConditionTrue,
@@ -233,9 +488,21 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			if fatal {
 				return ctrl.Result{}, r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperFailed) // always move to failed on fatal error
 			} else {
-				return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, false, 1)
+				return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, false, infrastructureFailure, 1)
 			}
 		}
+		if deployed := deployedComponentCount(aw); deployed < int32(len(aw.Spec.Components)) {
+			// createComponents exhausted its MaxReconcileDuration; its progress is already persisted in aw.Status
+			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+				Type:    string(workloadv1beta2.Throttled),
+				Status:  metav1.ConditionTrue,
+				Reason:  "MaxReconcileDuration",
+				Message: fmt.Sprintf("Creation of %v of %v components is deferred to a later reconcile", int32(len(aw.Spec.Components))-deployed, len(aw.Spec.Components)),
+			})
+			return requeueAfter(r.Config.DeploymentRetryInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+		}
+		clearCondition(aw, workloadv1beta2.DeploymentPending, "ComponentsCreated", "")
+		clearCondition(aw, workloadv1beta2.Throttled, "ComponentsCreated", "")
 		return ctrl.Result{}, r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperRunning)
 
 	case workloadv1beta2.AppWrapperRunning: // components deployed
@@ -243,6 +510,39 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if aw.Spec.Suspend {
 			return ctrl.Result{}, r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperSuspending) // begin undeployment
 		}
+		if err := parseComponents(aw); err != nil {
+			return ctrl.Result{}, r.failMalformedComponent(ctx, orig, aw, err)
+		}
+
+		// The AppWrapper holds quota; allow Pods that were held back by the admission gate to be scheduled
+		if r.Config.AdmissionGateName != "" {
+			if err := r.removeAdmissionGate(ctx, aw); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Detect the deletion of the queue to which the AppWrapper was submitted
+		if r.Config.EnableKueueIntegrations {
+			if err := r.checkQueue(ctx, aw); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Enforce the wall-clock deadline (if any) with no grace period or retry
+		if activeDeadline := r.activeDeadlineDuration(ctx, aw); activeDeadline > 0 {
+			whenAdmitted := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved)).LastTransitionTime
+			if time.Now().After(whenAdmitted.Add(activeDeadline)) {
+				detailMsg := fmt.Sprintf("Active deadline of %v exceeded", activeDeadline)
+				meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+					Type:    string(workloadv1beta2.Unhealthy),
+					Status:  metav1.ConditionTrue,
+					Reason:  "DeadlineExceeded",
+					Message: detailMsg,
+				})
+				r.Recorder.Event(aw, v1.EventTypeNormal, string(workloadv1beta2.Unhealthy), "DeadlineExceeded: "+detailMsg)
+				return ctrl.Result{}, r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperFailed)
+			}
+		}
 
 		// Gather status information at the Component and Pod level.
 		compStatus, err := r.getComponentStatus(ctx, aw)
@@ -253,6 +553,26 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+		if err := r.updateComponentEndpoints(ctx, aw); err != nil {
+			return ctrl.Result{}, err
+		}
+		aw.Status.DeployedComponents = compStatus.deployed
+		aw.Status.PodStatus = &workloadv1beta2.AppWrapperPodStatus{
+			Expected:  podStatus.expected,
+			Pending:   podStatus.pending,
+			Running:   podStatus.running,
+			Succeeded: podStatus.succeeded,
+			Failed:    podStatus.failed,
+			Lost:      podStatus.lost,
+		}
+		if podStatus.scheduled > 0 {
+			aw.Status.PodStatus.MaxSchedulingLatencySeconds = int32(podStatus.maxSchedulingLatency / time.Second)
+			aw.Status.PodStatus.AverageSchedulingLatencySeconds = int32(podStatus.totalSchedulingLatency / time.Duration(podStatus.scheduled) / time.Second)
+		}
+		if len(podStatus.nodes) > 0 {
+			nodes := sets.List(podStatus.nodes)
+			aw.Status.PodStatus.Nodes = nodes[:min(len(nodes), maxReportedNodes)]
+		}
 
 		// Detect externally deleted components and transition to Failed with no GracePeriod or retry
 		detailMsg := fmt.Sprintf("Only found %v deployed components, but was expecting %v", compStatus.deployed, compStatus.expected)
@@ -278,12 +598,27 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				Message: detailMsg,
 			})
 			r.Recorder.Event(aw, v1.EventTypeNormal, string(workloadv1beta2.Unhealthy), "FailedComponent: "+detailMsg)
-			return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, podStatus.terminalFailure, 1)
+			return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, podStatus.terminalFailure, applicationFailure, 1)
 		}
 
 		// Handle Success
-		if podStatus.succeeded >= podStatus.expected && (podStatus.pending+podStatus.running+podStatus.failed == 0) {
+		minSucceeded := r.successPolicy(ctx, aw, podStatus.succeededExpected)
+		if podStatus.workloadCompleted(compStatus, minSucceeded) {
+			// Defer success until the AppWrapper has been Running for its minimum duration
+			if minRunning := r.minimumRunningDuration(ctx, aw); minRunning > 0 {
+				deadline := runningSince(aw).Add(minRunning)
+				now := time.Now()
+				if now.Before(deadline) {
+					return requeueAfter(deadline.Sub(now), r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+				}
+			}
 			msg := fmt.Sprintf("%v pods succeeded and no running, pending, or failed pods", podStatus.succeeded)
+			if podStatus.serviceExpected > 0 {
+				msg = fmt.Sprintf("%v pods succeeded and all %v service pods are running", podStatus.succeeded, podStatus.serviceRunning)
+			}
+			if podStatus.failed > 0 {
+				msg = fmt.Sprintf("%v pods succeeded and %v pods failed; successPolicy requires %v succeeded pods", podStatus.succeeded, podStatus.failed, minSucceeded)
+			}
 			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 				Type:    string(workloadv1beta2.QuotaReserved),
 				Status:  metav1.ConditionFalse,
@@ -299,14 +634,26 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return ctrl.Result{}, r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperSucceeded)
 		}
 
-		// Handle Failed Pods
-		if podStatus.failed > 0 {
+		// Handle Failed Pods; they are tolerated as long as the SuccessPolicy (if any) can still be satisfied
+		toleratedFailed := int32(0)
+		if podStatus.failed > 0 && podStatus.successAchievable(minSucceeded) {
+			toleratedFailed = podStatus.failed
+		}
+		if podStatus.failed > 0 && toleratedFailed == 0 {
+			// Failed Pods of service components may instead be deleted to let their controllers recreate them
+			if deleted, err := r.deleteFailedServicePods(ctx, aw, podStatus); err != nil {
+				return ctrl.Result{}, err
+			} else if deleted {
+				return ctrl.Result{}, r.Status().Patch(ctx, aw, client.MergeFrom(orig))
+			}
+
 			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 				Type:   string(workloadv1beta2.Unhealthy),
 				Status: metav1.ConditionTrue,
 				Reason: "FoundFailedPods",
 				// Intentionally no detailed message with failed pod count, since changing the message resets the transition time
 			})
+			clearCondition(aw, workloadv1beta2.Ready, "FoundFailedPods", "")
 
 			// Grace period to give the resource controller a chance to correct the failure
 			whenDetected := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).LastTransitionTime
@@ -316,8 +663,33 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			if now.Before(deadline) {
 				return requeueAfter(deadline.Sub(now), r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
 			} else {
-				r.Recorder.Eventf(aw, v1.EventTypeNormal, string(workloadv1beta2.Unhealthy), "FoundFailedPods: %v failed pods", podStatus.failed)
-				return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, podStatus.terminalFailure, 1)
+				containerMsg := ""
+				if podStatus.failedContainer != "" {
+					containerMsg = "; " + podStatus.failedContainer
+				}
+				if len(podStatus.failedPodSets) > 0 {
+					r.Recorder.Eventf(aw, v1.EventTypeNormal, string(workloadv1beta2.Unhealthy), "FoundFailedPods: %v failed pods in podSets %v%v",
+						podStatus.failed, strings.Join(sets.List(podStatus.failedPodSets), ", "), containerMsg)
+				} else {
+					r.Recorder.Eventf(aw, v1.EventTypeNormal, string(workloadv1beta2.Unhealthy), "FoundFailedPods: %v failed pods%v", podStatus.failed, containerMsg)
+				}
+				if containerMsg != "" {
+					meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+						Type:    string(workloadv1beta2.Unhealthy),
+						Status:  metav1.ConditionTrue,
+						Reason:  "FoundFailedPods",
+						Message: fmt.Sprintf("Found %v failed pods%v", podStatus.failed, containerMsg),
+					})
+				}
+				if podStatus.oomKilled {
+					meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+						Type:    string(workloadv1beta2.Unhealthy),
+						Status:  metav1.ConditionTrue,
+						Reason:  "OOMKilled",
+						Message: fmt.Sprintf("Found %v failed pods with at least one OOMKilled container%v", podStatus.failed, containerMsg),
+					})
+				}
+				return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, podStatus.terminalFailure, podStatus.failedPodsCategory(), 1)
 			}
 		}
 
@@ -330,34 +702,89 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				Reason:  "AutopilotNoExecute",
 				Message: detailMsg,
 			})
+			clearCondition(aw, workloadv1beta2.Ready, "AutopilotNoExecute", "")
+
+			// Drain period to give stateful components a chance to checkpoint before being migrated.
+			// It is measured from its own condition, since Unhealthy may already have been True for another reason.
+			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+				Type:   string(workloadv1beta2.MigrationDraining),
+				Status: metav1.ConditionTrue,
+				Reason: "AutopilotNoExecute",
+			})
+			whenDrained := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.MigrationDraining)).LastTransitionTime
+			now := time.Now()
+			deadline := whenDrained.Add(r.migrationDrainDuration(ctx, aw))
+			if now.Before(deadline) {
+				return requeueAfter(deadline.Sub(now), r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+			}
+			clearCondition(aw, workloadv1beta2.MigrationDraining, "DrainPeriodExpired", "")
 			r.Recorder.Event(aw, v1.EventTypeNormal, string(workloadv1beta2.Unhealthy), detailMsg)
-			return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, false, 0) // Autopilot triggered evacuation does not increment retry count
+			return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, false, infrastructureFailure, 0) // Autopilot triggered evacuation does not increment retry count
+		}
+		clearCondition(aw, workloadv1beta2.MigrationDraining, "NoExecuteResourcesReleased", "")
+
+		// Reset workloads with Pods on unreachable Nodes; the FailureGracePeriod gives the Nodes a chance to recover
+		if podStatus.lost > 0 {
+			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+				Type:   string(workloadv1beta2.Unhealthy),
+				Status: metav1.ConditionTrue,
+				Reason: "FoundLostPods",
+			})
+			clearCondition(aw, workloadv1beta2.Ready, "FoundLostPods", "")
+			whenDetected := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).LastTransitionTime
+			now := time.Now()
+			deadline := whenDetected.Add(r.failureGraceDuration(ctx, aw))
+			if now.Before(deadline) {
+				return requeueAfter(deadline.Sub(now), r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+			}
+			r.Recorder.Eventf(aw, v1.EventTypeNormal, string(workloadv1beta2.Unhealthy), "FoundLostPods: %v pods on unreachable Nodes or in the Unknown phase", podStatus.lost)
+			return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, false, infrastructureFailure, 1)
 		}
 
 		clearCondition(aw, workloadv1beta2.Unhealthy, "FoundNoFailedPods", "")
 
-		if podStatus.running+podStatus.succeeded >= podStatus.expected {
+		podsReady := podStatus.running+podStatus.succeeded+toleratedFailed+compStatus.readyWithoutPods >= podStatus.expected && compStatus.unready == 0
+		if podsReady && compStatus.rollingOut == 0 {
 			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 				Type:    string(workloadv1beta2.PodsReady),
 				Status:  metav1.ConditionTrue,
 				Reason:  "SufficientPodsReady",
 				Message: fmt.Sprintf("%v pods running; %v pods succeeded", podStatus.running, podStatus.succeeded),
 			})
-			return requeueAfter(time.Minute, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+			if toleratedFailed > 0 {
+				clearCondition(aw, workloadv1beta2.Ready, "FoundFailedPods", "")
+			} else {
+				meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+					Type:    string(workloadv1beta2.Ready),
+					Status:  metav1.ConditionTrue,
+					Reason:  "AllComponentsReady",
+					Message: fmt.Sprintf("All %v components deployed and no failed pods", compStatus.deployed),
+				})
+			}
+			return requeueAfter(r.Config.RunningRequeueInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+		}
+
+		// A rollout in progress (eg kubectl rollout restart) only delays PodsReady; it never counts against the warmup period
+		if podsReady {
+			rolloutMessage := fmt.Sprintf("%v rollouts in progress", compStatus.rollingOut)
+			clearCondition(aw, workloadv1beta2.PodsReady, "RolloutInProgress", rolloutMessage)
+			clearCondition(aw, workloadv1beta2.Ready, "RolloutInProgress", rolloutMessage)
+			return requeueAfter(r.Config.WarmupRequeueInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
 		}
 
 		// Not ready yet; either continue to wait or giveup if the warmup period has expired
 		podDetailsMessage := fmt.Sprintf("%v pods pending; %v pods running; %v pods succeeded", podStatus.pending, podStatus.running, podStatus.succeeded)
 		clearCondition(aw, workloadv1beta2.PodsReady, "InsufficientPodsReady", podDetailsMessage)
+		clearCondition(aw, workloadv1beta2.Ready, "InsufficientPodsReady", podDetailsMessage)
 		whenDeployed := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)).LastTransitionTime
 		var graceDuration time.Duration
 		if podStatus.pending+podStatus.running+podStatus.succeeded >= podStatus.expected {
-			graceDuration = r.warmupGraceDuration(ctx, aw)
+			graceDuration = r.warmupGraceDuration(ctx, aw, podStatus.pendingComponents)
 		} else {
 			graceDuration = r.admissionGraceDuration(ctx, aw)
 		}
 		if time.Now().Before(whenDeployed.Add(graceDuration)) {
-			return requeueAfter(5*time.Second, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+			return requeueAfter(r.Config.WarmupRequeueInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
 		} else {
 			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 				Type:    string(workloadv1beta2.Unhealthy),
@@ -366,15 +793,19 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				Message: podDetailsMessage,
 			})
 			r.Recorder.Event(aw, v1.EventTypeNormal, string(workloadv1beta2.Unhealthy), "InsufficientPodsReady: "+podDetailsMessage)
-			return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, podStatus.terminalFailure, 1)
+			category := applicationFailure
+			if podStatus.unschedulable > 0 {
+				category = infrastructureFailure
+			}
+			return ctrl.Result{}, r.resetOrFail(ctx, orig, aw, podStatus.terminalFailure, category, 1)
 		}
 
 	case workloadv1beta2.AppWrapperSuspending: // undeploying components
 		orig := copyForStatusPatch(aw)
 		// finish undeploying components irrespective of desired state (suspend bit)
 		if meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)) {
-			if !r.deleteComponents(ctx, aw) {
-				return requeueAfter(5*time.Second, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+			if !r.deleteComponents(ctx, aw, workloadv1beta2.DeletionReasonSuspendedByKueue) {
+				return requeueAfter(r.Config.DeletionRequeueInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
 			}
 			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 				Type:    string(workloadv1beta2.ResourcesDeployed),
@@ -401,8 +832,8 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 		clearCondition(aw, workloadv1beta2.PodsReady, string(workloadv1beta2.AppWrapperResetting), "")
 		if meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)) {
-			if !r.deleteComponents(ctx, aw) {
-				return requeueAfter(5*time.Second, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+			if !r.deleteComponents(ctx, aw, workloadv1beta2.DeletionReasonReset) {
+				return requeueAfter(r.Config.DeletionRequeueInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
 			}
 			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 				Type:    string(workloadv1beta2.ResourcesDeployed),
@@ -413,8 +844,10 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 
 		// Pause before transitioning to Resuming to heuristically allow transient system problems to subside
+		// The pause grows geometrically with the number of retries to avoid repeatedly failing workloads hammering the cluster
 		whenReset := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).LastTransitionTime
-		pauseDuration := r.retryPauseDuration(ctx, aw)
+		pauseDuration := backoffDuration(r.retryPauseDuration(ctx, aw), r.Config.FaultTolerance.RetryPauseBackoffFactor,
+			aw.Status.Retries, r.Config.FaultTolerance.GracePeriodMaximum)
 		now := time.Now()
 		deadline := whenReset.Add(pauseDuration)
 		if now.Before(deadline) {
@@ -454,8 +887,8 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 
 		if meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)) {
-			if !r.deleteComponents(ctx, aw) {
-				return requeueAfter(5*time.Second, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+			if !r.deleteComponents(ctx, aw, workloadv1beta2.DeletionReasonFailed) {
+				return requeueAfter(r.Config.DeletionRequeueInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
 			}
 			msg := "Resources deleted for failed AppWrapper"
 			if deletionDelay > 0 && aw.Spec.Suspend {
@@ -474,7 +907,29 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			Reason:  string(workloadv1beta2.AppWrapperFailed),
 			Message: "No resources deployed",
 		})
-		return ctrl.Result{}, r.Status().Patch(ctx, aw, client.MergeFrom(orig))
+		if err := r.Status().Patch(ctx, aw, client.MergeFrom(orig)); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// Garbage collect the failed appwrapper itself once its FailedTTL expires
+		if ttl := r.timeToLiveAfterFailedDuration(ctx, aw); ttl > 0 {
+			whenReleased := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved)).LastTransitionTime
+			now := time.Now()
+			deadline := whenReleased.Add(ttl)
+			if now.Before(deadline) {
+				return requeueAfter(deadline.Sub(now), nil)
+			}
+			if controllerutil.RemoveFinalizer(aw, config.AppWrapperFinalizer(r.Config)) {
+				if err := r.Update(ctx, aw); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			if err := r.Delete(ctx, aw); err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			log.FromContext(ctx).Info("Deleted failed AppWrapper", "failedTTL", ttl)
+		}
+		return ctrl.Result{}, nil
 
 	case workloadv1beta2.AppWrapperSucceeded:
 		if meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)) {
@@ -487,8 +942,8 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			}
 
 			orig := copyForStatusPatch(aw)
-			if !r.deleteComponents(ctx, aw) {
-				return requeueAfter(5*time.Second, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
+			if !r.deleteComponents(ctx, aw, workloadv1beta2.DeletionReasonSucceededTTL) {
+				return requeueAfter(r.Config.DeletionRequeueInterval, r.Status().Patch(ctx, aw, client.MergeFrom(orig)))
 			}
 			meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 				Type:    string(workloadv1beta2.ResourcesDeployed),
@@ -504,24 +959,362 @@ func (r *AppWrapperReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
+// recordComponentMetrics updates the appwrapper_components_total gauge with the deployed components of aw
+func recordComponentMetrics(key types.NamespacedName, aw *workloadv1beta2.AppWrapper) {
+	gvks := []string{}
+	for _, cs := range aw.Status.ComponentStatus {
+		if meta.IsStatusConditionTrue(cs.Conditions, string(workloadv1beta2.ResourcesDeployed)) {
+			gvks = append(gvks, cs.APIVersion+"/"+cs.Kind)
+		}
+	}
+	metrics.RecordComponents(key, string(aw.Status.Phase), gvks)
+}
+
+// recordQuotaReservedMetrics updates the appwrapper_quota_reserved gauge with the user and queue of aw while it holds quota
+func (r *AppWrapperReconciler) recordQuotaReservedMetrics(key types.NamespacedName, aw *workloadv1beta2.AppWrapper) {
+	if !meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved)) {
+		metrics.ForgetQuotaReserved(key)
+		return
+	}
+	user := ""
+	if r.Config.UserMetricsLabel {
+		user = aw.Labels[workloadv1beta2.UsernameLabel]
+	}
+	metrics.RecordQuotaReserved(key, user, jobframework.QueueNameForObject(aw))
+}
+
 func (r *AppWrapperReconciler) transitionToPhase(ctx context.Context, orig *workloadv1beta2.AppWrapper, modified *workloadv1beta2.AppWrapper, phase workloadv1beta2.AppWrapperPhase) error {
+	if orig.Status.Phase != phase {
+		recordPhaseTiming(modified, orig.Status.Phase, r.now())
+	}
 	modified.Status.Phase = phase
+	if phase != workloadv1beta2.AppWrapperRunning {
+		clearCondition(modified, workloadv1beta2.Ready, string(phase), "")
+	}
 	if err := r.Status().Patch(ctx, modified, client.MergeFrom(orig)); err != nil {
 		return err
 	}
 	log.FromContext(ctx).Info(string(phase), "phase", phase)
 	metrics.AppWrapperPhaseCounter.WithLabelValues(orig.Namespace, string(phase)).Inc()
+	if phase == workloadv1beta2.AppWrapperFailed {
+		r.recordFailure(modified)
+	} else if orig.Status.Phase != phase {
+		from := string(orig.Status.Phase)
+		if from == "" {
+			from = "Empty"
+		}
+		r.Recorder.Eventf(modified, v1.EventTypeNormal, string(phase), "Transitioned from %v to %v", from, phase)
+	}
 	return nil
 }
 
-func (r *AppWrapperReconciler) resetOrFail(ctx context.Context, orig *workloadv1beta2.AppWrapper, aw *workloadv1beta2.AppWrapper, terminalFailure bool, retryIncrement int32) error {
-	maxRetries := r.retryLimit(ctx, aw)
-	if !terminalFailure && aw.Status.Retries < maxRetries {
-		aw.Status.Retries += retryIncrement
-		return r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperResetting)
+// now returns the current time of the reconciler's Clock
+func (r *AppWrapperReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// runningSince returns the time at which aw entered the Running phase.
+// AppWrappers without PhaseTimings fall back to the time their resources were deployed.
+func runningSince(aw *workloadv1beta2.AppWrapper) time.Time {
+	if aw.Status.Phase == workloadv1beta2.AppWrapperRunning && aw.Status.PhaseTimings != nil && !aw.Status.PhaseTimings.LastTransitionTime.IsZero() {
+		return aw.Status.PhaseTimings.LastTransitionTime.Time
+	}
+	return meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)).LastTransitionTime.Time
+}
+
+// recordPhaseTiming adds the time aw spent in the phase it is leaving to its PhaseTimings
+func recordPhaseTiming(aw *workloadv1beta2.AppWrapper, leaving workloadv1beta2.AppWrapperPhase, now time.Time) {
+	timings := aw.Status.PhaseTimings
+	if timings == nil {
+		timings = &workloadv1beta2.AppWrapperPhaseTimings{}
+		aw.Status.PhaseTimings = timings
+	} else if !timings.LastTransitionTime.IsZero() {
+		elapsed := max(now.Sub(timings.LastTransitionTime.Time), 0)
+		switch leaving {
+		case workloadv1beta2.AppWrapperSuspended:
+			timings.Suspended.Duration += elapsed
+		case workloadv1beta2.AppWrapperResuming:
+			timings.Resuming.Duration += elapsed
+		case workloadv1beta2.AppWrapperRunning:
+			timings.Running.Duration += elapsed
+		}
+	}
+	timings.LastTransitionTime = metav1.NewTime(now)
+}
+
+// recordFailure emits a terminal event for a Failed AppWrapper that includes a remediation hint derived from its Unhealthy condition
+func (r *AppWrapperReconciler) recordFailure(aw *workloadv1beta2.AppWrapper) {
+	cond := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		r.Recorder.Event(aw, v1.EventTypeWarning, string(workloadv1beta2.AppWrapperFailed), "AppWrapper failed")
+		return
+	}
+	if hint, ok := remediationHints[cond.Reason]; ok {
+		r.Recorder.Eventf(aw, v1.EventTypeWarning, string(workloadv1beta2.AppWrapperFailed), "%s: %s (hint: %s)", cond.Reason, cond.Message, hint)
 	} else {
-		return r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperFailed)
+		r.Recorder.Eventf(aw, v1.EventTypeWarning, string(workloadv1beta2.AppWrapperFailed), "%s: %s", cond.Reason, cond.Message)
+	}
+}
+
+// concurrencyKeyHolder returns the name of another active AppWrapper with the ConcurrencyKey of aw that aw must wait for
+// before creating its components, or "" if there is none. The webhook rejects such AppWrappers when they are created,
+// but its check races with concurrent creations; this check ensures at most one of them deploys its components.
+// An AppWrapper holds the key while its resources are deployed if it is older than aw (by creationTimestamp, then UID)
+// or has already created some components; aw is never held back once it has created some components itself.
+func (r *AppWrapperReconciler) concurrencyKeyHolder(ctx context.Context, aw *workloadv1beta2.AppWrapper) (string, error) {
+	if aw.Spec.ConcurrencyKey == "" || deployedComponentCount(aw) > 0 {
+		return "", nil
+	}
+	awList := &workloadv1beta2.AppWrapperList{}
+	if err := r.List(ctx, awList, client.InNamespace(aw.Namespace)); err != nil {
+		return "", err
+	}
+	for _, other := range awList.Items {
+		if other.Spec.ConcurrencyKey != aw.Spec.ConcurrencyKey || other.UID == aw.UID || !other.DeletionTimestamp.IsZero() ||
+			other.Status.Phase == workloadv1beta2.AppWrapperSucceeded || other.Status.Phase == workloadv1beta2.AppWrapperFailed ||
+			!meta.IsStatusConditionTrue(other.Status.Conditions, string(workloadv1beta2.ResourcesDeployed)) {
+			continue
+		}
+		older := other.CreationTimestamp.Before(&aw.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&aw.CreationTimestamp) && other.UID < aw.UID)
+		if older || deployedComponentCount(&other) > 0 {
+			return other.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// failMalformedComponent fails aw with no grace period or retry because the template of one of its components
+// cannot be parsed (eg it was corrupted by a direct edit that bypassed the webhook). The situation will not self-correct.
+func (r *AppWrapperReconciler) failMalformedComponent(ctx context.Context, orig *workloadv1beta2.AppWrapper, aw *workloadv1beta2.AppWrapper, err error) error {
+	detailMsg := fmt.Sprintf("error parsing components: %v", err)
+	meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+		Type:    string(workloadv1beta2.Unhealthy),
+		Status:  metav1.ConditionTrue,
+		Reason:  "MalformedComponent",
+		Message: detailMsg,
+	})
+	r.Recorder.Event(aw, v1.EventTypeNormal, string(workloadv1beta2.Unhealthy), "MalformedComponent: "+detailMsg)
+	return r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperFailed)
+}
+
+// resetOrFail resets the AppWrapper if the retry budget of the failure's category is not exhausted; otherwise it fails the AppWrapper
+func (r *AppWrapperReconciler) resetOrFail(ctx context.Context, orig *workloadv1beta2.AppWrapper, aw *workloadv1beta2.AppWrapper, terminalFailure bool,
+	category failureCategory, retryIncrement int32) error {
+	if !terminalFailure {
+		switch category {
+		case infrastructureFailure:
+			if aw.Status.InfrastructureRetries < r.Config.FaultTolerance.InfrastructureRetryLimit {
+				aw.Status.InfrastructureRetries += retryIncrement
+				return r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperResetting)
+			}
+		default:
+			if aw.Status.Retries < r.retryLimit(ctx, aw) {
+				aw.Status.Retries += retryIncrement
+				return r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperResetting)
+			}
+		}
 	}
+	return r.transitionToPhase(ctx, orig, aw, workloadv1beta2.AppWrapperFailed)
+}
+
+// deleteFailedServicePods deletes the failed Pods of aw if they all belong to service components, whose controllers
+// recreate them, and the FailedPodDeletionLimit of the current FailureGracePeriod window is not exceeded by doing so.
+// It returns true if the Pods were deleted.
+func (r *AppWrapperReconciler) deleteFailedServicePods(ctx context.Context, aw *workloadv1beta2.AppWrapper, podStatus *podStatusSummary) (bool, error) {
+	limit := r.failedPodDeletionLimit(ctx, aw)
+	if limit <= 0 || int32(len(podStatus.failedServicePods)) != podStatus.failed {
+		return false, nil
+	}
+	now := time.Now()
+	deletions := aw.Status.FailedPodDeletions
+	if deletions == nil || !now.Before(deletions.WindowStart.Add(r.failureGraceDuration(ctx, aw))) {
+		deletions = &workloadv1beta2.AppWrapperFailedPodDeletions{WindowStart: metav1.NewTime(now)}
+	}
+	if deletions.Count+podStatus.failed > limit {
+		return false, nil // failures persist; escalate
+	}
+	names := sets.List(sets.KeySet(podStatus.failedServicePods))
+	for _, name := range names {
+		uid := podStatus.failedServicePods[name]
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: aw.Namespace}}
+		if err := r.Delete(ctx, pod, client.Preconditions{UID: &uid}); err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			return false, err
+		}
+	}
+	deletions.Count += podStatus.failed
+	aw.Status.FailedPodDeletions = deletions
+	r.Recorder.Eventf(aw, v1.EventTypeNormal, "DeletedFailedPods", "Deleted failed pods %v of service components to be recreated (%v of %v deletions allowed)",
+		strings.Join(names, ", "), deletions.Count, limit)
+	return true, nil
+}
+
+// isInfrastructureFailure is the classification hook that attributes the failure of a Pod to the infrastructure.
+// A Pod failed because of the infrastructure if it was disrupted (eg evicted or preempted) or if its
+// failure reason is one of the configured InfrastructureFailureReasons.
+func (r *AppWrapperReconciler) isInfrastructureFailure(pod *v1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.DisruptionTarget && cond.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return pod.Status.Reason != "" && slices.Contains(r.Config.FaultTolerance.InfrastructureFailureReasons, pod.Status.Reason)
+}
+
+// schedulingLatency returns the time between the creation of pod and its PodScheduled condition becoming true
+func schedulingLatency(pod *v1.Pod) (time.Duration, bool) {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodScheduled && cond.Status == v1.ConditionTrue && !cond.LastTransitionTime.IsZero() {
+			return max(cond.LastTransitionTime.Sub(pod.CreationTimestamp.Time), 0), true
+		}
+	}
+	return 0, false
+}
+
+// maxReportedNodes bounds the number of Nodes listed in the PodStatus of an AppWrapper
+const maxReportedNodes = 64
+
+// maxLastReconcileSize bounds the size of the LastReconcileAnnotation of an AppWrapper
+const maxLastReconcileSize = 1024
+
+// reconcileSnapshot is the compact summary of the status of an AppWrapper recorded in its LastReconcileAnnotation
+type reconcileSnapshot struct {
+	Phase                 workloadv1beta2.AppWrapperPhase `json:"phase"`
+	Retries               int32                           `json:"retries,omitempty"`
+	InfrastructureRetries int32                           `json:"infrastructureRetries,omitempty"`
+	DeployedComponents    int32                           `json:"deployedComponents"`
+	ExpectedComponents    int32                           `json:"expectedComponents"`
+	Pods                  *podCountsSnapshot              `json:"pods,omitempty"`
+	// the Type=Reason of each true condition
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+type podCountsSnapshot struct {
+	Expected  int32 `json:"expected,omitempty"`
+	Pending   int32 `json:"pending,omitempty"`
+	Running   int32 `json:"running,omitempty"`
+	Succeeded int32 `json:"succeeded,omitempty"`
+	Failed    int32 `json:"failed,omitempty"`
+	Lost      int32 `json:"lost,omitempty"`
+}
+
+// lastReconcileSnapshot returns the JSON of the reconcileSnapshot of aw's current status, bounded by maxLastReconcileSize
+func lastReconcileSnapshot(aw *workloadv1beta2.AppWrapper) string {
+	snapshot := reconcileSnapshot{
+		Phase:                 aw.Status.Phase,
+		Retries:               aw.Status.Retries,
+		InfrastructureRetries: aw.Status.InfrastructureRetries,
+		DeployedComponents:    aw.Status.DeployedComponents,
+		ExpectedComponents:    int32(len(aw.Spec.Components)),
+	}
+	if ps := aw.Status.PodStatus; ps != nil {
+		snapshot.Pods = &podCountsSnapshot{Expected: ps.Expected, Pending: ps.Pending, Running: ps.Running, Succeeded: ps.Succeeded, Failed: ps.Failed, Lost: ps.Lost}
+	}
+	for _, cond := range aw.Status.Conditions {
+		if cond.Status == metav1.ConditionTrue {
+			snapshot.Conditions = append(snapshot.Conditions, cond.Type+"="+cond.Reason)
+		}
+	}
+	bytes, err := json.Marshal(snapshot)
+	if err == nil && len(bytes) > maxLastReconcileSize {
+		snapshot.Conditions = nil // the reasons of the conditions are the only unbounded part of a snapshot
+		bytes, err = json.Marshal(snapshot)
+	}
+	if err != nil {
+		return ""
+	}
+	return string(bytes)
+}
+
+// recordLastReconcile records before, the snapshot of aw's status taken at the start of a reconcile, in the
+// LastReconcileAnnotation of aw if the reconcile changed the status. Comparing the annotation to the status
+// then shows what the most recent change was. Failures are only logged since the annotation is a debugging aid.
+func (r *AppWrapperReconciler) recordLastReconcile(ctx context.Context, aw *workloadv1beta2.AppWrapper, before string) {
+	if before == "" || before == lastReconcileSnapshot(aw) || before == aw.Annotations[workloadv1beta2.LastReconcileAnnotation] {
+		return
+	}
+	patch := client.MergeFrom(aw.DeepCopy())
+	metav1.SetMetaDataAnnotation(&aw.ObjectMeta, workloadv1beta2.LastReconcileAnnotation, before)
+	if err := r.Patch(ctx, aw, patch); err != nil && !apierrors.IsNotFound(err) {
+		log.FromContext(ctx).Error(err, "Failed to record the last reconcile", "annotation", workloadv1beta2.LastReconcileAnnotation)
+	}
+}
+
+// maxTerminationMessageLength bounds the portion of a container's termination message that is copied into
+// the conditions and events of its AppWrapper
+const maxTerminationMessageLength = 256
+
+// describeFailedContainer describes the termination of a container of pod that exited with a non-zero code
+func describeFailedContainer(pod *v1.Pod, containerStatus *v1.ContainerStatus) string {
+	terminated := containerStatus.State.Terminated
+	desc := fmt.Sprintf("container %v of pod %v exited with code %v", containerStatus.Name, pod.Name, terminated.ExitCode)
+	if terminated.Reason != "" {
+		desc += fmt.Sprintf(" (%v)", terminated.Reason)
+	}
+	if message := strings.TrimSpace(terminated.Message); message != "" {
+		if runes := []rune(message); len(runes) > maxTerminationMessageLength {
+			message = string(runes[:maxTerminationMessageLength]) + "..."
+		}
+		desc += ": " + message
+	}
+	return desc
+}
+
+// podIsLost returns true if pod has not terminated but its phase is Unknown or it is bound to an unreachable Node
+func podIsLost(pod *v1.Pod) bool {
+	switch pod.Status.Phase {
+	case v1.PodUnknown:
+		return true
+	case v1.PodPending, v1.PodRunning:
+		if pod.Status.Reason == "NodeLost" {
+			return true
+		}
+		if pod.Spec.NodeName != "" {
+			unreachableNodesMutex.RLock() // BEGIN CRITICAL SECTION
+			defer unreachableNodesMutex.RUnlock()
+			return unreachableNodes.Has(pod.Spec.NodeName)
+		}
+	}
+	return false
+}
+
+// isServicePod returns true if pod belongs to a service component of aw. The component is identified by the
+// PodSetLabel of pod or, for Pods created before that label was injected, by the controller that owns pod:
+// the StatefulSet or DaemonSet of a component, or a ReplicaSet of one of its Deployments (whose names
+// are prefixed by the name of the component).
+func isServicePod(aw *workloadv1beta2.AppWrapper, pod *v1.Pod) bool {
+	if labelValue, ok := pod.Labels[workloadv1beta2.PodSetLabel]; ok {
+		if componentIdx, _, err := utils.ParsePodSetLabelValue(labelValue); err == nil && componentIdx >= 0 && componentIdx < len(aw.Status.ComponentStatus) {
+			cs := aw.Status.ComponentStatus[componentIdx]
+			return utils.IsServiceComponent(cs.APIVersion, cs.Kind)
+		}
+	}
+	if pod.Labels[workloadv1beta2.ServiceComponentLabel] == "true" {
+		return true
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.APIVersion != "apps/v1" || (owner.Kind != "ReplicaSet" && owner.Kind != "StatefulSet" && owner.Kind != "DaemonSet") {
+		return false
+	}
+	for _, cs := range aw.Status.ComponentStatus {
+		if utils.IsServiceComponent(cs.APIVersion, cs.Kind) && (owner.Name == cs.Name || strings.HasPrefix(owner.Name, cs.Name+"-")) {
+			return true
+		}
+	}
+	return false
+}
+
+// podIsReady returns true if the Ready condition of pod is true
+func podIsReady(pod *v1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
 }
 
 //gocyclo:ignore
@@ -529,23 +1322,58 @@ func (r *AppWrapperReconciler) getPodStatus(ctx context.Context, aw *workloadv1b
 	pods := &v1.PodList{}
 	if err := r.List(ctx, pods,
 		client.InNamespace(aw.Namespace),
-		client.MatchingLabels{workloadv1beta2.AppWrapperLabel: aw.Name}); err != nil {
+		client.MatchingLabels{config.AppWrapperLabel(r.Config): aw.Name}); err != nil {
 		return nil, err
 	}
 	pc, err := utils.ExpectedPodCount(aw)
 	if err != nil {
 		return nil, err
 	}
-	summary := &podStatusSummary{expected: pc}
+	spc, err := utils.ExpectedServicePodCount(aw)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := utils.ExpectedSucceededPodCount(aw)
+	if err != nil {
+		return nil, err
+	}
+	summary := &podStatusSummary{expected: pc, serviceExpected: spc, succeededExpected: sc}
 	checkNoExecuteNodes := r.Config.Autopilot != nil && r.Config.Autopilot.MonitorNodes
 
 	for _, pod := range pods.Items {
-		switch pod.Status.Phase {
-		case v1.PodPending:
-			summary.pending += 1
+		if latency, ok := schedulingLatency(&pod); ok {
+			summary.scheduled += 1
+			summary.maxSchedulingLatency = max(summary.maxSchedulingLatency, latency)
+			summary.totalSchedulingLatency += latency
+		}
+		if pod.Spec.NodeName != "" && pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+			if summary.nodes == nil {
+				summary.nodes = make(sets.Set[string])
+			}
+			summary.nodes.Insert(pod.Spec.NodeName)
+		}
+		if podIsLost(&pod) {
+			summary.lost += 1
+			continue
+		}
+		switch pod.Status.Phase {
+		case v1.PodPending:
+			summary.pending += 1
+			summary.addPendingComponent(&pod)
+			for _, cond := range pod.Status.Conditions {
+				if cond.Type == v1.PodScheduled && cond.Status == v1.ConditionFalse && cond.Reason == v1.PodReasonUnschedulable {
+					summary.unschedulable += 1
+				}
+			}
 		case v1.PodRunning:
 			if pod.DeletionTimestamp.IsZero() {
 				summary.running += 1
+				if isServicePod(aw, &pod) {
+					summary.serviceRunning += 1
+				}
+				if !podIsReady(&pod) {
+					summary.addPendingComponent(&pod) // still warming up
+				}
 				if checkNoExecuteNodes {
 					noExecuteNodesMutex.RLock() // BEGIN CRITICAL SECTION
 					if len(noExecuteNodes) > 0 {
@@ -578,7 +1406,34 @@ func (r *AppWrapperReconciler) getPodStatus(ctx context.Context, aw *workloadv1b
 		case v1.PodSucceeded:
 			summary.succeeded += 1
 		case v1.PodFailed:
+			// PodFailed is terminal. The kubelet restarts the failed containers of Pods whose restartPolicy is
+			// OnFailure (or Always) in place, and such Pods stay Running until the kubelet gives up on them.
 			summary.failed += 1
+			if r.isInfrastructureFailure(&pod) {
+				summary.infrastructureFailed += 1
+			}
+			if isServicePod(aw, &pod) {
+				if summary.failedServicePods == nil {
+					summary.failedServicePods = make(map[string]types.UID)
+				}
+				summary.failedServicePods[pod.Name] = pod.UID
+			}
+			if labelValue, ok := pod.Labels[workloadv1beta2.PodSetLabel]; ok {
+				if path, err := utils.PodSetPath(aw, labelValue); err == nil {
+					if summary.failedPodSets == nil {
+						summary.failedPodSets = make(sets.Set[string])
+					}
+					summary.failedPodSets.Insert(path)
+				}
+			}
+			for _, containerStatus := range pod.Status.ContainerStatuses {
+				if containerStatus.State.Terminated != nil && containerStatus.State.Terminated.Reason == "OOMKilled" {
+					summary.oomKilled = true
+				}
+				if summary.failedContainer == "" && containerStatus.State.Terminated != nil && containerStatus.State.Terminated.ExitCode != 0 {
+					summary.failedContainer = describeFailedContainer(&pod, &containerStatus)
+				}
+			}
 			if terminalCodes := r.terminalExitCodes(ctx, aw); len(terminalCodes) > 0 {
 				for _, containerStatus := range pod.Status.ContainerStatuses {
 					if containerStatus.State.Terminated != nil {
@@ -619,6 +1474,26 @@ func (r *AppWrapperReconciler) getPodStatus(ctx context.Context, aw *workloadv1b
 	return summary, nil
 }
 
+// hasTrueCondition returns true if the status.conditions of obj contains an entry of the given type with status "True"
+func hasTrueCondition(obj *unstructured.Unstructured, conditionType string) bool {
+	conditions, err := utils.GetFieldValue(obj.UnstructuredContent(), "status.conditions")
+	if err != nil {
+		return false
+	}
+	condArray, ok := conditions.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, aCond := range condArray {
+		if condMap, ok := aCond.(map[string]interface{}); ok {
+			if condMap["type"] == conditionType && condMap["status"] == string(metav1.ConditionTrue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 //gocyclo:ignore
 func (r *AppWrapperReconciler) getComponentStatus(ctx context.Context, aw *workloadv1beta2.AppWrapper) (*componentStatusSummary, error) {
 	summary := &componentStatusSummary{expected: int32(len(aw.Status.ComponentStatus))}
@@ -634,49 +1509,97 @@ func (r *AppWrapperReconciler) getComponentStatus(ctx context.Context, aw *workl
 					summary.deployed += 1
 
 					// batch/v1 Jobs are failed when status.Conditions contains an entry with type "Failed" and status "True"
+					// A Job whose successPolicy is met succeeds before all its indexes have succeeded; the rest need not succeed
+					successPolicyMet := false
 					for _, jc := range obj.Status.Conditions {
 						if jc.Type == batchv1.JobFailed && jc.Status == v1.ConditionTrue {
 							summary.failed += 1
 						}
+						if jc.Status == v1.ConditionTrue && (jc.Type == batchv1.JobSuccessCriteriaMet || jc.Type == batchv1.JobComplete) &&
+							jc.Reason == batchv1.JobReasonSuccessPolicy {
+							successPolicyMet = true
+						}
+					}
+					if successPolicyMet {
+						// the number of Pods the Job contributes to utils.ExpectedSucceededPodCount
+						expected := min(ptr.Deref(obj.Spec.Parallelism, 1), ptr.Deref(obj.Spec.Completions, math.MaxInt32))
+						if ptr.Deref(obj.Spec.CompletionMode, batchv1.NonIndexedCompletion) == batchv1.IndexedCompletion && obj.Spec.Completions != nil {
+							expected = *obj.Spec.Completions
+						}
+						summary.waivedSucceeded += max(0, expected-obj.Status.Succeeded)
 					}
 				}
 
-			} else if !apierrors.IsNotFound(err) {
+			} else if !isComponentGone(err) {
 				return nil, err
 			}
 
-		case "kubeflow.org/v1:PyTorchJob":
-			obj := &unstructured.Unstructured{}
-			obj.SetAPIVersion(cs.APIVersion)
-			obj.SetKind(cs.Kind)
+		case "apps/v1:DaemonSet":
+			obj := &appsv1.DaemonSet{}
 			if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err == nil {
 				if obj.GetDeletionTimestamp().IsZero() {
 					summary.deployed += 1
 
-					// PyTorchJob is failed if status.Conditions contains an entry with type "Failed" and status "True"
-					status, ok := obj.UnstructuredContent()["status"]
-					if !ok {
-						continue
+					// The number of Pods of a DaemonSet is determined by the number of Nodes it is scheduled on,
+					// which is only known once the DaemonSet controller has observed it
+					if obj.Status.ObservedGeneration < obj.Generation {
+						summary.unready += 1
+					} else {
+						desired := obj.Status.DesiredNumberScheduled
+						cs.DesiredPods = &desired
+						if obj.Status.NumberReady < desired {
+							summary.unready += 1
+						}
 					}
-					cond, ok := status.(map[string]interface{})["conditions"]
-					if !ok {
-						continue
+				}
+			} else if !isComponentGone(err) {
+				return nil, err
+			}
+
+		case "apps/v1:Deployment":
+			obj := &appsv1.Deployment{}
+			if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err == nil {
+				if obj.GetDeletionTimestamp().IsZero() {
+					summary.deployed += 1
+
+					// A Deployment is only ready once its rollout has completed
+					if !rolloutComplete(obj.Generation, obj.Status.ObservedGeneration, ptr.Deref(obj.Spec.Replicas, 1), obj.Status.UpdatedReplicas, obj.Status.Replicas) {
+						summary.rollingOut += 1
 					}
-					condArray, ok := cond.([]interface{})
-					if !ok {
-						continue
+				}
+			} else if !isComponentGone(err) {
+				return nil, err
+			}
+
+		case "apps/v1:StatefulSet":
+			obj := &appsv1.StatefulSet{}
+			if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err == nil {
+				if obj.GetDeletionTimestamp().IsZero() {
+					summary.deployed += 1
+
+					// A StatefulSet is only ready once its rollout has completed
+					if !rolloutComplete(obj.Generation, obj.Status.ObservedGeneration, ptr.Deref(obj.Spec.Replicas, 1), obj.Status.UpdatedReplicas, obj.Status.Replicas) {
+						summary.rollingOut += 1
 					}
-					for _, aCond := range condArray {
-						if condMap, ok := aCond.(map[string]interface{}); ok {
-							if condType, ok := condMap["type"]; ok && condType.(string) == "Failed" {
-								if status, ok := condMap["status"]; ok && status.(string) == "True" {
-									summary.failed += 1
-								}
-							}
-						}
+				}
+			} else if !isComponentGone(err) {
+				return nil, err
+			}
+
+		case "kubeflow.org/v1:PyTorchJob", "kubeflow.org/v1:TFJob", "kubeflow.org/v1:XGBoostJob":
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(cs.APIVersion)
+			obj.SetKind(cs.Kind)
+			if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err == nil {
+				if obj.GetDeletionTimestamp().IsZero() {
+					summary.deployed += 1
+
+					// Kubeflow Training Operator jobs are failed if status.Conditions contains an entry with type "Failed" and status "True"
+					if hasTrueCondition(obj, "Failed") {
+						summary.failed += 1
 					}
 				}
-			} else if !apierrors.IsNotFound(err) {
+			} else if !isComponentGone(err) {
 				return nil, err
 			}
 
@@ -706,7 +1629,7 @@ func (r *AppWrapperReconciler) getComponentStatus(ctx context.Context, aw *workl
 					}
 					*/
 				}
-			} else if !apierrors.IsNotFound(err) {
+			} else if !isComponentGone(err) {
 				return nil, err
 			}
 
@@ -735,17 +1658,74 @@ func (r *AppWrapperReconciler) getComponentStatus(ctx context.Context, aw *workl
 					}
 					*/
 				}
-			} else if !apierrors.IsNotFound(err) {
+			} else if !isComponentGone(err) {
+				return nil, err
+			}
+
+		case "ray.io/v1:RayService":
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(cs.APIVersion)
+			obj.SetKind(cs.Kind)
+			if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err == nil {
+				if obj.GetDeletionTimestamp().IsZero() {
+					summary.deployed += 1
+					// Like RayCluster, a RayService is deployed-by-existence: none of its serviceStatus values is known to be a terminal failure
+				}
+			} else if !isComponentGone(err) {
+				return nil, err
+			}
+
+		case "serving.kserve.io/v1beta1:InferenceService":
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(cs.APIVersion)
+			obj.SetKind(cs.Kind)
+			if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err == nil {
+				if obj.GetDeletionTimestamp().IsZero() {
+					summary.deployed += 1
+
+					// An InferenceService is ready when status.conditions contains an entry with type "Ready" and status "True".
+					// Since KServe may scale a ready InferenceService to zero, its Pods are then not required to be running.
+					if hasTrueCondition(obj, "Ready") {
+						for _, ps := range cs.PodSets {
+							summary.readyWithoutPods += utils.Replicas(ps)
+						}
+					} else {
+						summary.unready += 1
+					}
+				}
+			} else if !isComponentGone(err) {
 				return nil, err
 			}
 
 		default:
+			if rules := r.componentFailureRules(cs.APIVersion, cs.Kind); len(rules) > 0 {
+				obj := &unstructured.Unstructured{}
+				obj.SetAPIVersion(cs.APIVersion)
+				obj.SetKind(cs.Kind)
+				if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err == nil {
+					if obj.GetDeletionTimestamp().IsZero() {
+						summary.deployed += 1
+
+						// The resource is failed if the value at the path of any configured rule matches its failed value
+						for _, rule := range rules {
+							if value, err := utils.GetFieldValue(obj.UnstructuredContent(), rule.FailedJSONPath); err == nil && fmt.Sprint(value) == rule.FailedValue {
+								summary.failed += 1
+								break
+							}
+						}
+					}
+				} else if !isComponentGone(err) {
+					return nil, err
+				}
+				continue
+			}
+
 			obj := &metav1.PartialObjectMetadata{TypeMeta: metav1.TypeMeta{Kind: cs.Kind, APIVersion: cs.APIVersion}}
 			if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err == nil {
 				if obj.GetDeletionTimestamp().IsZero() {
 					summary.deployed += 1
 				}
-			} else if !apierrors.IsNotFound(err) {
+			} else if !isComponentGone(err) {
 				return nil, err
 			}
 		}
@@ -754,6 +1734,51 @@ func (r *AppWrapperReconciler) getComponentStatus(ctx context.Context, aw *workl
 	return summary, nil
 }
 
+// updateComponentEndpoints mirrors the string found at the EndpointPath of each Component into its ComponentStatus
+func (r *AppWrapperReconciler) updateComponentEndpoints(ctx context.Context, aw *workloadv1beta2.AppWrapper) error {
+	for idx, component := range aw.Spec.Components {
+		if component.EndpointPath == "" || idx >= len(aw.Status.ComponentStatus) {
+			continue
+		}
+		cs := &aw.Status.ComponentStatus[idx]
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(cs.APIVersion)
+		obj.SetKind(cs.Kind)
+		if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err != nil {
+			if !isComponentGone(err) {
+				return err
+			}
+			cs.Endpoint = ""
+			continue
+		}
+		// The endpoint is empty until the Component's controller reports it
+		value, err := utils.GetFieldValue(obj.UnstructuredContent(), component.EndpointPath)
+		if endpoint, ok := value.(string); err == nil && ok {
+			cs.Endpoint = endpoint
+		} else {
+			cs.Endpoint = ""
+		}
+	}
+	return nil
+}
+
+// rolloutComplete returns true if the controller of a Deployment or StatefulSet has observed its latest generation
+// and all of its replicas have been updated to the latest template
+func rolloutComplete(generation int64, observedGeneration int64, desired int32, updated int32, replicas int32) bool {
+	return observedGeneration >= generation && updated == desired && replicas == updated
+}
+
+// componentFailureRules returns the configured failure rules that apply to the given resource type
+func (r *AppWrapperReconciler) componentFailureRules(apiVersion string, kind string) []config.ComponentFailureRule {
+	rules := []config.ComponentFailureRule{}
+	for _, rule := range r.Config.ComponentFailureRules {
+		if rule.APIVersion == apiVersion && rule.Kind == kind {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
 func (r *AppWrapperReconciler) limitDuration(desired time.Duration) time.Duration {
 	if desired < 0 {
 		return 0 * time.Second
@@ -775,15 +1800,36 @@ func (r *AppWrapperReconciler) admissionGraceDuration(ctx context.Context, aw *w
 	return r.limitDuration(r.Config.FaultTolerance.AdmissionGracePeriod)
 }
 
-func (r *AppWrapperReconciler) warmupGraceDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper) time.Duration {
+// warmupGraceDuration returns the warmup grace period of aw. A component may override it with its own
+// warmup grace period annotation; if pendingComponents (the components with pending or not yet ready Pods)
+// is not empty, the longest period of those components is used.
+func (r *AppWrapperReconciler) warmupGraceDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper, pendingComponents sets.Set[int]) time.Duration {
+	awDuration := r.limitDuration(r.Config.FaultTolerance.WarmupGracePeriod)
 	if userPeriod, ok := aw.Annotations[workloadv1beta2.WarmupGracePeriodDurationAnnotation]; ok {
 		if duration, err := time.ParseDuration(userPeriod); err == nil {
-			return r.limitDuration(duration)
+			awDuration = r.limitDuration(duration)
 		} else {
 			log.FromContext(ctx).Error(err, "Malformed warmup grace period annotation; using default", "annotation", userPeriod)
 		}
 	}
-	return r.limitDuration(r.Config.FaultTolerance.WarmupGracePeriod)
+	if len(pendingComponents) == 0 {
+		return awDuration
+	}
+	grace := time.Duration(0)
+	for componentIdx := range pendingComponents {
+		componentDuration := awDuration
+		if componentIdx < len(aw.Spec.Components) {
+			if userPeriod, ok := aw.Spec.Components[componentIdx].Annotations[workloadv1beta2.WarmupGracePeriodDurationAnnotation]; ok {
+				if duration, err := time.ParseDuration(userPeriod); err == nil {
+					componentDuration = r.limitDuration(duration)
+				} else {
+					log.FromContext(ctx).Error(err, "Malformed component warmup grace period annotation; using default", "component", componentIdx, "annotation", userPeriod)
+				}
+			}
+		}
+		grace = max(grace, componentDuration)
+	}
+	return grace
 }
 
 func (r *AppWrapperReconciler) failureGraceDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper) time.Duration {
@@ -797,6 +1843,28 @@ func (r *AppWrapperReconciler) failureGraceDuration(ctx context.Context, aw *wor
 	return r.limitDuration(r.Config.FaultTolerance.FailureGracePeriod)
 }
 
+func (r *AppWrapperReconciler) migrationDrainDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper) time.Duration {
+	if userPeriod, ok := aw.Annotations[workloadv1beta2.MigrationDrainPeriodAnnotation]; ok {
+		if duration, err := time.ParseDuration(userPeriod); err == nil {
+			return r.limitDuration(duration)
+		} else {
+			log.FromContext(ctx).Error(err, "Malformed migration drain period annotation; using default", "annotation", userPeriod)
+		}
+	}
+	return r.limitDuration(r.Config.FaultTolerance.MigrationDrainPeriod)
+}
+
+func (r *AppWrapperReconciler) minimumRunningDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper) time.Duration {
+	if userPeriod, ok := aw.Annotations[workloadv1beta2.MinimumRunningDurationAnnotation]; ok {
+		if duration, err := time.ParseDuration(userPeriod); err == nil {
+			return r.limitDuration(duration)
+		} else {
+			log.FromContext(ctx).Error(err, "Malformed minimum running duration annotation; using default", "annotation", userPeriod)
+		}
+	}
+	return r.limitDuration(r.Config.FaultTolerance.MinimumRunningDuration)
+}
+
 func (r *AppWrapperReconciler) retryLimit(ctx context.Context, aw *workloadv1beta2.AppWrapper) int32 {
 	if userLimit, ok := aw.Annotations[workloadv1beta2.RetryLimitAnnotation]; ok {
 		if limit, err := strconv.Atoi(userLimit); err == nil {
@@ -808,6 +1876,17 @@ func (r *AppWrapperReconciler) retryLimit(ctx context.Context, aw *workloadv1bet
 	return r.Config.FaultTolerance.RetryLimit
 }
 
+func (r *AppWrapperReconciler) failedPodDeletionLimit(ctx context.Context, aw *workloadv1beta2.AppWrapper) int32 {
+	if userLimit, ok := aw.Annotations[workloadv1beta2.FailedPodDeletionLimitAnnotation]; ok {
+		if limit, err := strconv.Atoi(userLimit); err == nil {
+			return int32(limit)
+		} else {
+			log.FromContext(ctx).Error(err, "Malformed failed pod deletion limit annotation; using default", "annotation", userLimit)
+		}
+	}
+	return r.Config.FaultTolerance.FailedPodDeletionLimit
+}
+
 func (r *AppWrapperReconciler) retryPauseDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper) time.Duration {
 	if userPeriod, ok := aw.Annotations[workloadv1beta2.RetryPausePeriodDurationAnnotation]; ok {
 		if duration, err := time.ParseDuration(userPeriod); err == nil {
@@ -830,6 +1909,88 @@ func (r *AppWrapperReconciler) forcefulDeletionGraceDuration(ctx context.Context
 	return r.limitDuration(r.Config.FaultTolerance.ForcefulDeletionGracePeriod)
 }
 
+func (r *AppWrapperReconciler) successDeletionGraceDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper) time.Duration {
+	if userPeriod, ok := aw.Annotations[workloadv1beta2.SuccessDeletionGracePeriodAnnotation]; ok {
+		if duration, err := time.ParseDuration(userPeriod); err == nil {
+			return r.limitDuration(duration)
+		} else {
+			log.FromContext(ctx).Error(err, "Malformed success deletion period annotation; using default", "annotation", userPeriod)
+		}
+	}
+	return r.limitDuration(r.Config.FaultTolerance.SuccessDeletionGracePeriod)
+}
+
+// hasSucceeded returns true if aw is Succeeded or was Succeeded before it began Terminating.
+// The QuotaReserved condition records the success, since it is released with reason Succeeded.
+func hasSucceeded(aw *workloadv1beta2.AppWrapper) bool {
+	if aw.Status.Phase == workloadv1beta2.AppWrapperSucceeded {
+		return true
+	}
+	quota := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))
+	return quota != nil && quota.Status == metav1.ConditionFalse && quota.Reason == string(workloadv1beta2.AppWrapperSucceeded)
+}
+
+func (r *AppWrapperReconciler) forcefulDeletionDisabled(ctx context.Context, aw *workloadv1beta2.AppWrapper) bool {
+	if userValue, ok := aw.Annotations[workloadv1beta2.NoForcefulDeletionAnnotation]; ok {
+		if disabled, err := strconv.ParseBool(userValue); err == nil {
+			return disabled
+		} else {
+			log.FromContext(ctx).Error(err, "Malformed no forceful deletion annotation; using default", "annotation", userValue)
+		}
+	}
+	return false
+}
+
+func (r *AppWrapperReconciler) reconcilePaused(ctx context.Context, aw *workloadv1beta2.AppWrapper) bool {
+	if userValue, ok := aw.Annotations[workloadv1beta2.PauseReconcileAnnotation]; ok {
+		if paused, err := strconv.ParseBool(userValue); err == nil {
+			return paused
+		} else {
+			log.FromContext(ctx).Error(err, "Malformed pause reconcile annotation; using default", "annotation", userValue)
+		}
+	}
+	return false
+}
+
+var (
+	// pausedAppWrappers are the AppWrappers whose paused state has been reported by a ReconcilePaused event
+	pausedAppWrappers      = sets.New[types.NamespacedName]()
+	pausedAppWrappersMutex sync.Mutex
+)
+
+// startPaused records that the AppWrapper named nn is paused and returns true if it was not already
+func startPaused(nn types.NamespacedName) bool {
+	pausedAppWrappersMutex.Lock() // BEGIN CRITICAL SECTION
+	defer pausedAppWrappersMutex.Unlock()
+	if pausedAppWrappers.Has(nn) {
+		return false
+	}
+	pausedAppWrappers.Insert(nn)
+	return true
+}
+
+// forgetPaused records that the AppWrapper named nn is not paused
+func forgetPaused(nn types.NamespacedName) {
+	pausedAppWrappersMutex.Lock() // BEGIN CRITICAL SECTION
+	defer pausedAppWrappersMutex.Unlock()
+	pausedAppWrappers.Delete(nn)
+}
+
+func (r *AppWrapperReconciler) deletionPropagation(ctx context.Context, aw *workloadv1beta2.AppWrapper) metav1.DeletionPropagation {
+	if userPolicy, ok := aw.Annotations[workloadv1beta2.DeletionPropagationAnnotation]; ok {
+		if policy := metav1.DeletionPropagation(userPolicy); config.ValidDeletionPropagation(policy) {
+			return policy
+		} else {
+			log.FromContext(ctx).Error(fmt.Errorf("%q is not one of Background, Foreground, or Orphan", userPolicy),
+				"Malformed deletion propagation annotation; using default", "annotation", userPolicy)
+		}
+	}
+	if r.Config.FaultTolerance.DeletionPropagationPolicy == "" {
+		return metav1.DeletePropagationBackground
+	}
+	return r.Config.FaultTolerance.DeletionPropagationPolicy
+}
+
 func (r *AppWrapperReconciler) deletionOnFailureGraceDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper) time.Duration {
 	if userPeriod, ok := aw.Annotations[workloadv1beta2.DeletionOnFailureGracePeriodAnnotation]; ok {
 		if duration, err := time.ParseDuration(userPeriod); err == nil {
@@ -854,6 +2015,57 @@ func (r *AppWrapperReconciler) timeToLiveAfterSucceededDuration(ctx context.Cont
 	return r.Config.FaultTolerance.SuccessTTL
 }
 
+func (r *AppWrapperReconciler) timeToLiveAfterFailedDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper) time.Duration {
+	if userPeriod, ok := aw.Annotations[workloadv1beta2.FailedTTLAnnotation]; ok {
+		if duration, err := time.ParseDuration(userPeriod); err == nil {
+			if duration > 0 && (r.Config.FaultTolerance.FailedTTL == 0 || duration < r.Config.FaultTolerance.FailedTTL) {
+				return duration
+			}
+		} else {
+			log.FromContext(ctx).Error(err, "Malformed failedTTL annotation; using default", "annotation", userPeriod)
+		}
+	}
+	return r.Config.FaultTolerance.FailedTTL
+}
+
+func (r *AppWrapperReconciler) activeDeadlineDuration(ctx context.Context, aw *workloadv1beta2.AppWrapper) time.Duration {
+	if userSeconds, ok := aw.Annotations[workloadv1beta2.ActiveDeadlineSecondsAnnotation]; ok {
+		if seconds, err := strconv.Atoi(userSeconds); err == nil {
+			return r.limitDuration(time.Duration(seconds) * time.Second)
+		} else {
+			log.FromContext(ctx).Error(err, "Malformed active deadline annotation; using default of no deadline", "annotation", userSeconds)
+		}
+	}
+	return 0 * time.Second
+}
+
+// successPolicy returns the minimum number of succeeded Pods required by the SuccessPolicy annotation of aw, or 0 if it has none.
+// The annotation is either a count ("8") or a percentage ("80%") of the succeededExpected Pods and is clamped to [1, succeededExpected].
+func (r *AppWrapperReconciler) successPolicy(ctx context.Context, aw *workloadv1beta2.AppWrapper, succeededExpected int32) int32 {
+	userPolicy, ok := aw.Annotations[workloadv1beta2.SuccessPolicyAnnotation]
+	if !ok || succeededExpected == 0 {
+		return 0
+	}
+	var minSucceeded int32
+	if pctStr, isPct := strings.CutSuffix(userPolicy, "%"); isPct {
+		pct, err := strconv.ParseFloat(pctStr, 64)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Malformed success policy annotation; requiring all pods to succeed", "annotation", userPolicy)
+			return 0
+		}
+		pct = math.Max(0, math.Min(100, pct))
+		minSucceeded = int32(math.Ceil(pct * float64(succeededExpected) / 100))
+	} else {
+		count, err := strconv.Atoi(userPolicy)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Malformed success policy annotation; requiring all pods to succeed", "annotation", userPolicy)
+			return 0
+		}
+		minSucceeded = int32(min(count, int(succeededExpected)))
+	}
+	return max(minSucceeded, 1)
+}
+
 func (r *AppWrapperReconciler) terminalExitCodes(_ context.Context, aw *workloadv1beta2.AppWrapper) []int {
 	ans := []int{}
 	if exitCodeAnn, ok := aw.Annotations[workloadv1beta2.TerminalExitCodesAnnotation]; ok {
@@ -868,6 +2080,28 @@ func (r *AppWrapperReconciler) terminalExitCodes(_ context.Context, aw *workload
 	return ans
 }
 
+func (r *AppWrapperReconciler) priorityClassName(_ context.Context, aw *workloadv1beta2.AppWrapper) string {
+	return priorityClassNameFor(aw, r.Config)
+}
+
+func priorityClassNameFor(aw *workloadv1beta2.AppWrapper, awConfig *config.AppWrapperConfig) string {
+	if pcn, ok := aw.Annotations[workloadv1beta2.PriorityClassNameAnnotation]; ok && pcn != "" {
+		return pcn
+	}
+	return awConfig.PriorityClassName
+}
+
+func (r *AppWrapperReconciler) schedulerName(_ context.Context, aw *workloadv1beta2.AppWrapper) string {
+	return schedulerNameFor(aw, r.Config)
+}
+
+func schedulerNameFor(aw *workloadv1beta2.AppWrapper, awConfig *config.AppWrapperConfig) string {
+	if sn, ok := aw.Annotations[workloadv1beta2.SchedulerNameAnnotation]; ok && sn != "" {
+		return sn
+	}
+	return awConfig.SchedulerName
+}
+
 func (r *AppWrapperReconciler) retryableExitCodes(_ context.Context, aw *workloadv1beta2.AppWrapper) []int {
 	ans := []int{}
 	if exitCodeAnn, ok := aw.Annotations[workloadv1beta2.RetryableExitCodesAnnotation]; ok {
@@ -882,6 +2116,18 @@ func (r *AppWrapperReconciler) retryableExitCodes(_ context.Context, aw *workloa
 	return ans
 }
 
+// backoffDuration computes base * factor^(retries-1), clipped to maximum
+func backoffDuration(base time.Duration, factor float64, retries int32, maximum time.Duration) time.Duration {
+	backoff := float64(base)
+	for i := int32(1); i < retries && backoff < float64(maximum); i++ {
+		backoff *= factor
+	}
+	if backoff > float64(maximum) {
+		return maximum
+	}
+	return time.Duration(backoff)
+}
+
 func clearCondition(aw *workloadv1beta2.AppWrapper, condition workloadv1beta2.AppWrapperCondition, reason string, message string) {
 	if meta.IsStatusConditionTrue(aw.Status.Conditions, string(condition)) {
 		meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
@@ -893,10 +2139,150 @@ func clearCondition(aw *workloadv1beta2.AppWrapper, condition workloadv1beta2.Ap
 	}
 }
 
+// checkQueue sets the QueueMissing condition of aw if the LocalQueue named by its queue name label, or the
+// ClusterQueue of that LocalQueue, has been deleted and applies the configured MissingQueuePolicy.
+// The condition is cleared if the queues exist again.
+func (r *AppWrapperReconciler) checkQueue(ctx context.Context, aw *workloadv1beta2.AppWrapper) error {
+	queueName := jobframework.QueueNameForObject(aw)
+	if queueName == "" {
+		return nil // not submitted to a queue
+	}
+	reason, msg := "", ""
+	lq := &kueue.LocalQueue{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: aw.Namespace, Name: queueName}, lq); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ignoreNoMatch(err)
+		}
+		reason, msg = "LocalQueueNotFound", fmt.Sprintf("LocalQueue %v not found", queueName)
+	} else if err := r.Get(ctx, types.NamespacedName{Name: string(lq.Spec.ClusterQueue)}, &kueue.ClusterQueue{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ignoreNoMatch(err)
+		}
+		reason, msg = "ClusterQueueNotFound", fmt.Sprintf("ClusterQueue %v of LocalQueue %v not found", lq.Spec.ClusterQueue, queueName)
+	}
+	if reason == "" {
+		if meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QueueMissing)) {
+			if err := r.reactivateWorkload(ctx, aw); err != nil {
+				return err
+			}
+		}
+		clearCondition(aw, workloadv1beta2.QueueMissing, "QueueFound", "")
+		return nil
+	}
+
+	if !meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QueueMissing)) {
+		r.Recorder.Event(aw, v1.EventTypeWarning, string(workloadv1beta2.QueueMissing), msg)
+	}
+	meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+		Type:    string(workloadv1beta2.QueueMissing),
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: msg,
+	})
+	if r.Config.MissingQueuePolicy == config.MissingQueueSuspend {
+		return r.deactivateWorkload(ctx, aw)
+	}
+	return nil
+}
+
+// deactivateWorkload sets spec.active of the Workload of aw to false, which causes Kueue to evict the Workload
+// and suspend aw. Suspending aw directly would not do, since Kueue resumes suspended AppWrappers that are admitted.
+// The Workload is annotated so that reactivateWorkload only undoes deactivations made by the controller.
+func (r *AppWrapperReconciler) deactivateWorkload(ctx context.Context, aw *workloadv1beta2.AppWrapper) error {
+	wl := &kueue.Workload{}
+	wlName := jobframework.GetWorkloadNameForOwnerWithGVK(aw.Name, aw.UID, wlc.GVK)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: aw.Namespace, Name: wlName}, wl); err != nil {
+		return client.IgnoreNotFound(err) // aw is not managed by Kueue
+	}
+	if !ptr.Deref(wl.Spec.Active, true) {
+		return nil
+	}
+	wl.Spec.Active = ptr.To(false)
+	metav1.SetMetaDataAnnotation(&wl.ObjectMeta, queueMissingDeactivationAnnotation, "true")
+	if err := r.Update(ctx, wl); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("Deactivated Workload because its queue is missing", "workload", wlName)
+	return nil
+}
+
+// reactivateWorkload sets spec.active of the Workload of aw back to true if deactivateWorkload deactivated it,
+// which lets Kueue admit the Workload again and resume aw.
+func (r *AppWrapperReconciler) reactivateWorkload(ctx context.Context, aw *workloadv1beta2.AppWrapper) error {
+	wl := &kueue.Workload{}
+	wlName := jobframework.GetWorkloadNameForOwnerWithGVK(aw.Name, aw.UID, wlc.GVK)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: aw.Namespace, Name: wlName}, wl); err != nil {
+		return client.IgnoreNotFound(err) // aw is not managed by Kueue
+	}
+	if _, ok := wl.Annotations[queueMissingDeactivationAnnotation]; !ok {
+		return nil // not deactivated by the controller
+	}
+	wl.Spec.Active = ptr.To(true)
+	delete(wl.Annotations, queueMissingDeactivationAnnotation)
+	if err := r.Update(ctx, wl); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("Reactivated Workload because its queue exists again", "workload", wlName)
+	return nil
+}
+
+// ignoreNoMatch returns nil if err shows that the Kueue queue resources are not installed
+func ignoreNoMatch(err error) error {
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+	return err
+}
+
+// queueMapFunc maps LocalQueues to the Running appwrappers submitted to them and to the Suspended
+// appwrappers whose QueueMissing condition is True
+func (r *AppWrapperReconciler) queueMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.appWrappersInQueues(ctx, obj.GetNamespace(), sets.New(obj.GetName()))
+}
+
+// clusterQueueMapFunc maps ClusterQueues to the appwrappers submitted to their LocalQueues that queueMapFunc selects
+func (r *AppWrapperReconciler) clusterQueueMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	lqs := &kueue.LocalQueueList{}
+	if err := r.List(ctx, lqs); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list LocalQueues", "clusterQueue", obj.GetName())
+		return nil
+	}
+	byNamespace := map[string]sets.Set[string]{}
+	for _, lq := range lqs.Items {
+		if string(lq.Spec.ClusterQueue) == obj.GetName() {
+			if byNamespace[lq.Namespace] == nil {
+				byNamespace[lq.Namespace] = sets.New[string]()
+			}
+			byNamespace[lq.Namespace].Insert(lq.Name)
+		}
+	}
+	requests := []reconcile.Request{}
+	for ns, names := range byNamespace {
+		requests = append(requests, r.appWrappersInQueues(ctx, ns, names)...)
+	}
+	return requests
+}
+
+func (r *AppWrapperReconciler) appWrappersInQueues(ctx context.Context, namespace string, queueNames sets.Set[string]) []reconcile.Request {
+	awList := &workloadv1beta2.AppWrapperList{}
+	if err := r.List(ctx, awList, client.InNamespace(namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list AppWrappers", "namespace", namespace)
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, aw := range awList.Items {
+		awaitingQueue := aw.Status.Phase == workloadv1beta2.AppWrapperSuspended && meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QueueMissing))
+		if (aw.Status.Phase == workloadv1beta2.AppWrapperRunning || awaitingQueue) && queueNames.Has(jobframework.QueueNameForObject(&aw)) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: aw.Namespace, Name: aw.Name}})
+		}
+	}
+	return requests
+}
+
 // podMapFunc maps pods to appwrappers and generates reconcile.Requests for those whose Status.Phase is PodSucceeded
 func (r *AppWrapperReconciler) podMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
 	pod := obj.(*v1.Pod)
-	if name, ok := pod.Labels[workloadv1beta2.AppWrapperLabel]; ok {
+	if name, ok := pod.Labels[config.AppWrapperLabel(r.Config)]; ok {
 		if pod.Status.Phase == v1.PodSucceeded {
 			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: name}}}
 		}
@@ -904,13 +2290,165 @@ func (r *AppWrapperReconciler) podMapFunc(ctx context.Context, obj client.Object
 	return nil
 }
 
+// parentMapFunc returns a function that maps a resource of the given GVK to the appwrappers in its namespace
+// whose ParentRef refers to it and generates reconcile.Requests for them
+func (r *AppWrapperReconciler) parentMapFunc(gvk schema.GroupVersionKind) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		awList := &workloadv1beta2.AppWrapperList{}
+		if err := r.List(ctx, awList, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to list AppWrappers", "parent", obj.GetName())
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, aw := range awList.Items {
+			ref := aw.Spec.ParentRef
+			if ref == nil || ref.Name != obj.GetName() || ref.Kind != gvk.Kind || ref.APIVersion != gvk.GroupVersion().String() {
+				continue
+			}
+			if ref.UID != "" && ref.UID != obj.GetUID() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: aw.Namespace, Name: aw.Name}})
+		}
+		return requests
+	}
+}
+
+// resync enqueues every AppWrapper that has not yet Succeeded or Failed by sending it to events
+func (r *AppWrapperReconciler) resync(ctx context.Context, events chan<- event.GenericEvent) error {
+	aws := &workloadv1beta2.AppWrapperList{}
+	if err := r.List(ctx, aws); err != nil {
+		return err
+	}
+	for i := range aws.Items {
+		aw := &aws.Items[i]
+		if aw.Status.Phase == workloadv1beta2.AppWrapperSucceeded || aw.Status.Phase == workloadv1beta2.AppWrapperFailed {
+			continue
+		}
+		select {
+		case events <- event.GenericEvent{Object: aw}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
+// If a ResyncPeriod is configured, a periodic resync of all non-terminal AppWrappers is also added to the Manager.
 func (r *AppWrapperReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&workloadv1beta2.AppWrapper{}).
-		Watches(&v1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.podMapFunc)).
-		Named("AppWrapper").
-		Complete(r)
+		Watches(&v1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.podMapFunc))
+	if period := r.Config.ResyncPeriod; period > 0 {
+		events := make(chan event.GenericEvent)
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			wait.UntilWithContext(ctx, func(ctx context.Context) {
+				if err := r.resync(ctx, events); err != nil && ctx.Err() == nil {
+					log.FromContext(ctx).Error(err, "Failed to resync AppWrappers")
+				}
+			}, period)
+			return nil
+		})); err != nil {
+			return err
+		}
+		b = b.WatchesRawSource(source.Channel(events, &handler.EnqueueRequestForObject{}))
+	}
+	if r.Config.EnableKueueIntegrations {
+		b = b.Watches(&kueue.LocalQueue{}, handler.EnqueueRequestsFromMapFunc(r.queueMapFunc)).
+			Watches(&kueue.ClusterQueue{}, handler.EnqueueRequestsFromMapFunc(r.clusterQueueMapFunc))
+	}
+	for _, kind := range r.Config.ParentRefKinds {
+		gv, err := schema.ParseGroupVersion(kind.APIVersion)
+		if err != nil {
+			return err
+		}
+		parent := &metav1.PartialObjectMetadata{}
+		parent.SetGroupVersionKind(gv.WithKind(kind.Kind))
+		b = b.Watches(parent, handler.EnqueueRequestsFromMapFunc(r.parentMapFunc(parent.GroupVersionKind())))
+	}
+	return b.Named("AppWrapper").Complete(r)
+}
+
+// Status returns a client for the status subresource that refreshes the Healthy and Summary
+// of an AppWrapper's status before each write, so that they are never stale
+func (r *AppWrapperReconciler) Status() client.SubResourceWriter {
+	return &summarizingStatusWriter{r.Client.Status()}
+}
+
+type summarizingStatusWriter struct {
+	client.SubResourceWriter
+}
+
+func (w *summarizingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if aw, ok := obj.(*workloadv1beta2.AppWrapper); ok {
+		summarizeStatus(&aw.Status)
+	}
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w *summarizingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if aw, ok := obj.(*workloadv1beta2.AppWrapper); ok {
+		summarizeStatus(&aw.Status)
+	}
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// summarizeStatus derives the Healthy and Summary fields of status from its Phase and Conditions
+func summarizeStatus(status *workloadv1beta2.AppWrapperStatus) {
+	unhealthy := meta.FindStatusCondition(status.Conditions, string(workloadv1beta2.Unhealthy))
+	problem := ""
+	if unhealthy != nil && unhealthy.Status == metav1.ConditionTrue {
+		problem = unhealthy.Reason
+		if unhealthy.Message != "" {
+			problem += ": " + unhealthy.Message
+		}
+	}
+	status.Healthy = problem == "" && status.Phase != workloadv1beta2.AppWrapperResetting && status.Phase != workloadv1beta2.AppWrapperFailed
+
+	deployed := meta.IsStatusConditionTrue(status.Conditions, string(workloadv1beta2.ResourcesDeployed))
+	switch status.Phase {
+	case workloadv1beta2.AppWrapperEmpty, workloadv1beta2.AppWrapperSuspended:
+		status.Summary = "Waiting for quota to be reserved"
+	case workloadv1beta2.AppWrapperResuming:
+		status.Summary = "Deploying resources"
+		if pending := meta.FindStatusCondition(status.Conditions, string(workloadv1beta2.DeploymentPending)); pending != nil && pending.Status == metav1.ConditionTrue {
+			status.Summary += "; retrying " + pending.Reason
+		}
+	case workloadv1beta2.AppWrapperRunning:
+		switch {
+		case problem != "":
+			status.Summary = "Running but unhealthy (" + problem + ")"
+		case meta.IsStatusConditionTrue(status.Conditions, string(workloadv1beta2.PodsReady)):
+			status.Summary = "Running with all pods ready"
+		default:
+			status.Summary = "Running; waiting for pods to be ready"
+		}
+	case workloadv1beta2.AppWrapperResetting:
+		status.Summary = "Resetting after a failure"
+		if problem != "" {
+			status.Summary += " (" + problem + ")"
+		}
+	case workloadv1beta2.AppWrapperSuspending:
+		status.Summary = "Suspending; deleting resources"
+	case workloadv1beta2.AppWrapperSucceeded:
+		status.Summary = "Succeeded"
+		if !deployed {
+			status.Summary += "; resources deleted"
+		}
+	case workloadv1beta2.AppWrapperFailed:
+		status.Summary = "Failed"
+		if problem != "" {
+			status.Summary += " (" + problem + ")"
+		}
+		if deployed {
+			status.Summary += "; resources not yet deleted"
+		}
+	case workloadv1beta2.AppWrapperTerminating:
+		status.Summary = "Terminating; deleting resources"
+	default:
+		status.Summary = string(status.Phase)
+	}
 }
 
 // copyForStatusPatch returns an AppWrapper with an empty Spec and a DeepCopy of orig's Status for use in a subsequent Status().Patch(...) call
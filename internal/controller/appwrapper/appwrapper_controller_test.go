@@ -17,24 +17,44 @@ limitations under the License.
 package appwrapper
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/podset"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 
 	workloadv1beta2 "github.com/project-codeflare/appwrapper/api/v1beta2"
 	"github.com/project-codeflare/appwrapper/internal/controller/workload"
+	"github.com/project-codeflare/appwrapper/internal/metrics"
 	"github.com/project-codeflare/appwrapper/pkg/config"
 	"github.com/project-codeflare/appwrapper/pkg/utils"
 )
@@ -64,6 +84,7 @@ var _ = Describe("AppWrapper Controller", func() {
 		awConfig.FaultTolerance.FailureGracePeriod = 0 * time.Second
 		awConfig.FaultTolerance.RetryPausePeriod = 0 * time.Second
 		awConfig.FaultTolerance.RetryLimit = 0
+		awConfig.FaultTolerance.InfrastructureRetryLimit = 0
 		awConfig.FaultTolerance.SuccessTTL = 0 * time.Second
 		awConfig.Autopilot.ResourceTaints["nvidia.com/gpu"] = append(awConfig.Autopilot.ResourceTaints["nvidia.com/gpu"], v1.Taint{Key: "extra", Value: "test", Effect: v1.TaintEffectNoExecute})
 
@@ -93,6 +114,7 @@ var _ = Describe("AppWrapper Controller", func() {
 
 		aw = getAppWrapper(awName)
 		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResuming))
+		Expect(aw.Status.DeployedComponents).Should(Equal(int32(0)))
 		Expect(controllerutil.ContainsFinalizer(aw, AppWrapperFinalizer)).Should(BeTrue())
 		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
 		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeTrue())
@@ -107,6 +129,7 @@ var _ = Describe("AppWrapper Controller", func() {
 
 		aw := getAppWrapper(awName)
 		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(aw.Status.DeployedComponents).Should(Equal(int32(len(aw.Spec.Components))))
 		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
 		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeTrue())
 		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeFalse())
@@ -156,6 +179,7 @@ var _ = Describe("AppWrapper Controller", func() {
 		podStatus, err := awReconciler.getPodStatus(ctx, aw)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(podStatus.running).Should(Equal(pc))
+		Expect(aw.Status.PodStatus).Should(Equal(&workloadv1beta2.AppWrapperPodStatus{Expected: pc, Running: pc}))
 		_, _, finished := (*workload.AppWrapper)(aw).Finished()
 		Expect(finished).Should(BeFalse())
 	}
@@ -264,6 +288,7 @@ var _ = Describe("AppWrapper Controller", func() {
 		Expect(err).NotTo(HaveOccurred())
 		aw = getAppWrapper(awName)
 		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+		Expect(aw.Status.DeletionReason).Should(Equal(workloadv1beta2.DeletionReasonSucceededTTL))
 	})
 
 	It("Running Workloads can be Suspended", func() {
@@ -298,6 +323,7 @@ var _ = Describe("AppWrapper Controller", func() {
 		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspended))
 		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
 		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeFalse())
+		Expect(aw.Status.DeletionReason).Should(Equal(workloadv1beta2.DeletionReasonSuspendedByKueue))
 		Expect((*workload.AppWrapper)(aw).IsActive()).Should(BeFalse())
 		Expect((*workload.AppWrapper)(aw).IsSuspended()).Should(BeTrue())
 		podStatus, err := awReconciler.getPodStatus(ctx, aw)
@@ -305,75 +331,2659 @@ var _ = Describe("AppWrapper Controller", func() {
 		Expect(podStatus.failed + podStatus.succeeded + podStatus.running + podStatus.pending).Should(Equal(int32(0)))
 	})
 
+	It("Lingering terminated pods do not block deletion completion when IgnoreTerminatedPods is set", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		awReconciler.Config.FaultTolerance.IgnoreTerminatedPods = true
+		beginRunning()
+		fullyRunning()
+
+		By("Simulating a lingering Succeeded pod created by a wrapped resource")
+		aw := getAppWrapper(awName)
+		lingering := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       randName("lingering"),
+				Namespace:  aw.Namespace,
+				Labels:     map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name},
+				Finalizers: []string{"workload.codeflare.dev/test"},
+			},
+			Spec: v1.PodSpec{RestartPolicy: v1.RestartPolicyNever, Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		Expect(k8sClient.Create(ctx, lingering)).To(Succeed())
+		lingering.Status.Phase = v1.PodSucceeded
+		Expect(k8sClient.Status().Update(ctx, lingering)).To(Succeed())
+
+		By("Suspending the AppWrapper")
+		aw = getAppWrapper(awName)
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		for i := 0; i < 4 && aw.Status.Phase != workloadv1beta2.AppWrapperSuspended; i++ {
+			_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			aw = getAppWrapper(awName)
+		}
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspended))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+
+		By("The lingering pod was deleted")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(lingering), lingering)).To(Succeed())
+		Expect(lingering.DeletionTimestamp.IsZero()).Should(BeFalse())
+		controllerutil.RemoveFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+	})
+
+	It("The status before the last change is recorded in the LastReconcile annotation", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		awReconciler.Config.RecordLastReconcile = true
+		lastReconcile := func() reconcileSnapshot {
+			aw := getAppWrapper(awName)
+			Expect(aw.Annotations).Should(HaveKey(workloadv1beta2.LastReconcileAnnotation))
+			snapshot := reconcileSnapshot{}
+			Expect(json.Unmarshal([]byte(aw.Annotations[workloadv1beta2.LastReconcileAnnotation]), &snapshot)).To(Succeed())
+			return snapshot
+		}
+
+		By("Reconciling: Resuming -> Running records the Resuming status")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getAppWrapper(awName).Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		snapshot := lastReconcile()
+		Expect(snapshot.Phase).Should(Equal(workloadv1beta2.AppWrapperResuming))
+		Expect(snapshot.DeployedComponents).Should(Equal(int32(0)))
+		Expect(snapshot.ExpectedComponents).Should(Equal(int32(2)))
+		Expect(snapshot.Pods).Should(BeNil())
+		Expect(snapshot.Conditions).Should(ContainElement("QuotaReserved=" + string(workloadv1beta2.AppWrapperResuming)))
+
+		By("Reconciling: Running -> Running records the Running status before the pods were running")
+		fullyRunning()
+		snapshot = lastReconcile()
+		Expect(snapshot.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(snapshot.DeployedComponents).Should(Equal(int32(2)))
+		Expect(snapshot.Conditions).Should(ContainElement("ResourcesDeployed=" + string(workloadv1beta2.AppWrapperResuming)))
+		Expect(snapshot.Conditions).ShouldNot(ContainElement(HavePrefix("PodsReady=")))
+
+		By("Reconciling without a change to the status leaves the annotation alone")
+		before := getAppWrapper(awName).Annotations[workloadv1beta2.LastReconcileAnnotation]
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getAppWrapper(awName).Annotations[workloadv1beta2.LastReconcileAnnotation]).Should(Equal(before))
+		Expect(len(before)).Should(BeNumerically("<=", maxLastReconcileSize))
+	})
+
+	It("Deleting a Running AppWrapper records the UserDeleted deletion reason", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		beginRunning()
+		fullyRunning()
+
+		By("Deleting the AppWrapper")
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.DeletionReason).Should(BeEmpty())
+		Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+		By("Reconciling: Running -> Terminating")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // initiate deletion
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperTerminating))
+		Expect(aw.Status.DeletionReason).Should(Equal(workloadv1beta2.DeletionReasonUserDeleted))
+	})
+
+	It("Aggregate pod counts are persisted in the AppWrapper status", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true), pod(100, 0, false))
+		awReconciler.Config.FaultTolerance.FailureGracePeriod = 1 * time.Minute
+		beginRunning()
+
+		By("Simulating one Pod Succeeding and one Pod Failing")
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(3))
+		pods[0].Status.Phase = v1.PodSucceeded
+		Expect(k8sClient.Status().Update(ctx, &pods[0])).To(Succeed())
+		pods[1].Status.Phase = v1.PodFailed
+		Expect(k8sClient.Status().Update(ctx, &pods[1])).To(Succeed())
+
+		By("Reconciling: Running -> Running (within the FailureGracePeriod)")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		podStatus, err := awReconciler.getPodStatus(ctx, aw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(aw.Status.PodStatus).Should(Equal(&workloadv1beta2.AppWrapperPodStatus{
+			Expected:  podStatus.expected,
+			Pending:   podStatus.pending,
+			Running:   podStatus.running,
+			Succeeded: podStatus.succeeded,
+			Failed:    podStatus.failed,
+		}))
+		Expect(aw.Status.PodStatus.Expected).Should(Equal(int32(3)))
+		Expect(aw.Status.PodStatus.Succeeded).Should(Equal(int32(1)))
+		Expect(aw.Status.PodStatus.Failed).Should(Equal(int32(1)))
+	})
+
 	It("A Pod Failure leads to a failed AppWrapper", func() {
 		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
 		beginRunning()
-		fullyRunning()
+		fullyRunning()
+
+		By("Simulating one Pod Failing")
+		aw := getAppWrapper(awName)
+		Expect(setPodStatus(aw, v1.PodFailed, 1)).To(Succeed())
+
+		By("Reconciling: Running -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) //  detect failure
+		Expect(err).NotTo(HaveOccurred())
+
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeTrue())
+		Expect((*workload.AppWrapper)(aw).IsActive()).Should(BeTrue())
+		Expect((*workload.AppWrapper)(aw).IsSuspended()).Should(BeFalse())
+		_, _, finished := (*workload.AppWrapper)(aw).Finished()
+		Expect(finished).Should(BeFalse())
+
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // initiate deletion
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // see deletion has completed
+		Expect(err).NotTo(HaveOccurred())
+
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(aw.Status.DeletionReason).Should(Equal(workloadv1beta2.DeletionReasonFailed))
+		Expect(aw.Status.Healthy).Should(BeFalse())
+		Expect(aw.Status.Summary).Should(HavePrefix("Failed"))
+
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeFalse())
+		Expect((*workload.AppWrapper)(aw).IsActive()).Should(BeFalse())
+		Expect((*workload.AppWrapper)(aw).IsSuspended()).Should(BeFalse())
+		_, _, finished = (*workload.AppWrapper)(aw).Finished()
+		Expect(finished).Should(BeTrue())
+	})
+
+	It("The Ready condition is true only while the AppWrapper is Running and healthy", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		beginRunning()
+		aw := getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Ready))).Should(BeFalse())
+
+		fullyRunning()
+		aw = getAppWrapper(awName)
+		readyCond := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Ready))
+		Expect(readyCond).ShouldNot(BeNil())
+		Expect(readyCond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(readyCond.Reason).Should(Equal("AllComponentsReady"))
+
+		By("A failed Pod clears Ready during the FailureGracePeriod")
+		awReconciler.Config.FaultTolerance.FailureGracePeriod = time.Minute
+		Expect(setPodStatus(aw, v1.PodFailed, 1)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+		readyCond = meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Ready))
+		Expect(readyCond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(readyCond.Reason).Should(Equal("FoundFailedPods"))
+
+		By("Leaving the Running phase keeps Ready false")
+		awReconciler.Config.FaultTolerance.FailureGracePeriod = 0
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Ready))).Should(BeFalse())
+	})
+
+	It("A count SuccessPolicy tolerates failed pods once enough pods have succeeded", func() {
+		advanceToResuming(pod(100, 0, true), pod(100, 0, true), pod(100, 0, true), pod(100, 0, true), pod(100, 0, true))
+		aw := getAppWrapper(awName)
+		aw.Annotations = map[string]string{workloadv1beta2.SuccessPolicyAnnotation: "4"}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		beginRunning()
+		fullyRunning()
+
+		By("A failed pod is tolerated while the policy can still be satisfied")
+		aw = getAppWrapper(awName)
+		Expect(setPodStatus(aw, v1.PodFailed, 1)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))).Should(BeFalse())
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Ready))).Should(BeFalse())
+
+		By("Four succeeded pods and one failed pod satisfy the policy")
+		Expect(setPodStatus(aw, v1.PodSucceeded, 5)).To(Succeed())
+		Expect(setPodStatus(aw, v1.PodFailed, 1)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSucceeded))
+		Expect(aw.Status.PodStatus.Failed).Should(Equal(int32(1)))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
+		quotaCond := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))
+		Expect(quotaCond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(quotaCond.Reason).Should(Equal(string(workloadv1beta2.AppWrapperSucceeded)))
+		_, _, finished := (*workload.AppWrapper)(aw).Finished()
+		Expect(finished).Should(BeTrue())
+	})
+
+	It("A percentage SuccessPolicy that is not satisfied leads to a failed AppWrapper", func() {
+		advanceToResuming(pod(100, 0, true), pod(100, 0, true), pod(100, 0, true), pod(100, 0, true), pod(100, 0, true))
+		aw := getAppWrapper(awName)
+		aw.Annotations = map[string]string{workloadv1beta2.SuccessPolicyAnnotation: "70%"} // rounds up to 4 of 5 pods
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		beginRunning()
+		fullyRunning()
+
+		By("Three succeeded pods and two failed pods do not satisfy the policy")
+		aw = getAppWrapper(awName)
+		Expect(setPodStatus(aw, v1.PodSucceeded, 5)).To(Succeed())
+		Expect(setPodStatus(aw, v1.PodFailed, 2)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("FoundFailedPods"))
+	})
+
+	It("Quota is held during the DeletionOnFailureGracePeriod of a failed AppWrapper", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		beginRunning()
+		fullyRunning()
+
+		By("Annotating the AppWrapper with a deletion delay")
+		aw := getAppWrapper(awName)
+		if aw.Annotations == nil {
+			aw.Annotations = map[string]string{}
+		}
+		aw.Annotations[workloadv1beta2.DeletionOnFailureGracePeriodAnnotation] = "1m"
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+
+		By("Simulating one Pod Failing")
+		aw = getAppWrapper(awName)
+		Expect(setPodStatus(aw, v1.PodFailed, 1)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) //  detect failure
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+
+		By("Reconciling: Failed AppWrapper keeps its resources and quota during the delay")
+		result, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).Should(BeNumerically(">", 0))
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
+		quota := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))
+		Expect(quota).ShouldNot(BeNil())
+		Expect(quota.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeletingResources)).Reason).Should(Equal("DeletionPaused"))
+		_, _, finished := (*workload.AppWrapper)(aw).Finished()
+		Expect(finished).Should(BeFalse())
+
+		By("Suspending the AppWrapper forces deletion and releases the quota")
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // initiate deletion
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // see deletion has completed
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeFalse())
+	})
+
+	It("Migration away from NoExecute resources is delayed by the migrationDrainPeriod", func() {
+		advanceToResuming(pod(100, 1, true))
+		beginRunning()
+		fullyRunning()
+
+		awReconciler.Config.FaultTolerance.InfrastructureRetryLimit = 1
+
+		By("Annotating the AppWrapper with a drain period")
+		aw := getAppWrapper(awName)
+		if aw.Annotations == nil {
+			aw.Annotations = map[string]string{}
+		}
+		aw.Annotations[workloadv1beta2.MigrationDrainPeriodAnnotation] = "2s"
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+
+		By("Simulating an Unhealthy condition set long before the drain for another reason")
+		aw = getAppWrapper(awName)
+		meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+			Type:               string(workloadv1beta2.Unhealthy),
+			Status:             metav1.ConditionTrue,
+			Reason:             "FoundLostPods",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+		})
+		Expect(k8sClient.Status().Update(ctx, aw)).To(Succeed())
+
+		By("Simulating a GPU pod running on a Node that Autopilot has flagged as NoExecute")
+		nodeName := randName("noexecute-node")
+		gpu := resource.MustParse("1")
+		drainPod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("drain-pod"), Namespace: aw.Namespace, Labels: map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}},
+			Spec: v1.PodSpec{
+				NodeName: nodeName,
+				Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36",
+					Resources: v1.ResourceRequirements{Requests: v1.ResourceList{"nvidia.com/gpu": gpu}, Limits: v1.ResourceList{"nvidia.com/gpu": gpu}}}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, drainPod)).To(Succeed())
+		drainPod.Status.Phase = v1.PodRunning
+		Expect(k8sClient.Status().Update(ctx, drainPod)).To(Succeed())
+		noExecuteNodesMutex.Lock()
+		noExecuteNodes[nodeName] = sets.New("nvidia.com/gpu")
+		noExecuteNodesMutex.Unlock()
+		defer func() {
+			noExecuteNodesMutex.Lock()
+			delete(noExecuteNodes, nodeName)
+			noExecuteNodesMutex.Unlock()
+		}()
+
+		By("Reconciling: Running while the drain period has not expired")
+		result, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).Should(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).Should(BeNumerically("<=", 2*time.Second))
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		unhealthy := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))
+		Expect(unhealthy).ShouldNot(BeNil())
+		Expect(unhealthy.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(unhealthy.Reason).Should(Equal("AutopilotNoExecute"))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.MigrationDraining))).Should(BeTrue())
+
+		By("Reconciling: Running -> Resetting once the drain period expires")
+		Eventually(func() workloadv1beta2.AppWrapperPhase {
+			_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			return getAppWrapper(awName).Status.Phase
+		}, 10*time.Second, 500*time.Millisecond).Should(Equal(workloadv1beta2.AppWrapperResetting))
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Retries).Should(Equal(int32(0)))
+		Expect(aw.Status.InfrastructureRetries).Should(Equal(int32(0)))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.MigrationDraining))).Should(BeFalse())
+		Expect(k8sClient.Delete(ctx, drainPod, client.GracePeriodSeconds(0))).To(Succeed())
+	})
+
+	It("The Nodes hosting non-terminated Pods are listed in the AppWrapper status", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, false))
+		beginRunning()
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.PodStatus.Nodes).Should(BeEmpty())
+
+		By("Simulating scheduled pods")
+		nodeNames := []string{randName("node-b"), randName("node-a")}
+		scheduled := []*v1.Pod{}
+		for _, nodeName := range nodeNames {
+			p := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: randName("scheduled-pod"), Namespace: aw.Namespace, Labels: map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}},
+				Spec: v1.PodSpec{
+					NodeName:   nodeName,
+					Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, p)).To(Succeed())
+			p.Status.Phase = v1.PodRunning
+			Expect(k8sClient.Status().Update(ctx, p)).To(Succeed())
+			scheduled = append(scheduled, p)
+		}
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.PodStatus.Nodes).Should(Equal([]string{nodeNames[1], nodeNames[0]}))
+
+		By("Omitting the Nodes of terminated pods")
+		scheduled[1].Status.Phase = v1.PodSucceeded
+		Expect(k8sClient.Status().Update(ctx, scheduled[1])).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.PodStatus.Nodes).Should(Equal([]string{nodeNames[0]}))
+
+		for _, p := range scheduled {
+			Expect(k8sClient.Delete(ctx, p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+
+	It("An evicted Pod consumes the infrastructure retry budget", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		awReconciler.Config.FaultTolerance.InfrastructureRetryLimit = 1
+		beginRunning()
+		fullyRunning()
+
+		By("Simulating one Pod being evicted")
+		aw := getAppWrapper(awName)
+		Expect(setPodEvicted(aw, 1)).To(Succeed())
+
+		By("Reconciling: Running -> Resetting")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResetting))
+		Expect(aw.Status.InfrastructureRetries).Should(Equal(int32(1)))
+		Expect(aw.Status.Retries).Should(Equal(int32(0)))
+	})
+
+	It("A Pod Failure consumes the application retry budget", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		awReconciler.Config.FaultTolerance.RetryLimit = 1
+		awReconciler.Config.FaultTolerance.InfrastructureRetryLimit = 5
+		beginRunning()
+		fullyRunning()
+
+		By("Simulating one Pod being evicted and another Pod failing")
+		aw := getAppWrapper(awName)
+		Expect(setPodEvicted(aw, 1)).To(Succeed())
+		Expect(setPodStatus(aw, v1.PodFailed, 2)).To(Succeed())
+
+		By("Reconciling: Running -> Resetting")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResetting))
+		Expect(aw.Status.Retries).Should(Equal(int32(1)))
+		Expect(aw.Status.InfrastructureRetries).Should(Equal(int32(0)))
+	})
+
+	It("Failed Pods of a Deployment may be deleted instead of resetting the AppWrapper", func() {
+		advanceToResuming(deployment(100))
+		awReconciler.Config.FaultTolerance.FailedPodDeletionLimit = 1
+		awReconciler.Config.FaultTolerance.FailureGracePeriod = 1 * time.Minute
+		beginRunning()
+		aw := getAppWrapper(awName)
+		dep := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[0].Name, Namespace: aw.Namespace}, dep)).To(Succeed())
+
+		failDeploymentPod := func() *v1.Pod {
+			depPod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: randName("dep-pod"), Namespace: aw.Namespace, Labels: dep.Spec.Template.Labels},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+			}
+			Expect(k8sClient.Create(ctx, depPod)).To(Succeed())
+			depPod.Status.Phase = v1.PodFailed
+			Expect(k8sClient.Status().Update(ctx, depPod)).To(Succeed())
+			return depPod
+		}
+
+		By("Simulating a failed pod of the Deployment")
+		first := failDeploymentPod()
+
+		By("Reconciling: the failed pod is deleted and the AppWrapper keeps Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))).Should(BeFalse())
+		Expect(aw.Status.Retries).Should(Equal(int32(0)))
+		Expect(aw.Status.FailedPodDeletions).ShouldNot(BeNil())
+		Expect(aw.Status.FailedPodDeletions.Count).Should(Equal(int32(1)))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(first), &v1.Pod{})).Should(MatchError(ContainSubstring("not found")))
+
+		By("Simulating a second failed pod of the Deployment within the grace window")
+		failDeploymentPod()
+
+		By("Reconciling: the deletion limit is exhausted so the failure escalates")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))).Should(BeTrue())
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("FoundFailedPods"))
+		Expect(aw.Status.FailedPodDeletions.Count).Should(Equal(int32(1)))
+
+		By("Cleanup the simulated pods")
+		for _, p := range getPods(aw) {
+			Expect(k8sClient.Delete(ctx, &p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+
+	It("Pods on unreachable Nodes are counted as lost and reset the AppWrapper", func() {
+		advanceToResuming(pod(100, 0, true))
+		beginRunning()
+		fullyRunning()
+
+		awReconciler.Config.FaultTolerance.InfrastructureRetryLimit = 1
+
+		By("Simulating a running pod on a Node that is tainted as unreachable")
+		aw := getAppWrapper(awName)
+		nodeName := randName("unreachable-node")
+		lostPod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("lost-pod"), Namespace: aw.Namespace, Labels: map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}},
+			Spec: v1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, lostPod)).To(Succeed())
+		lostPod.Status.Phase = v1.PodRunning
+		Expect(k8sClient.Status().Update(ctx, lostPod)).To(Succeed())
+		unreachableNodesMutex.Lock()
+		unreachableNodes.Insert(nodeName)
+		unreachableNodesMutex.Unlock()
+		defer func() {
+			unreachableNodesMutex.Lock()
+			unreachableNodes.Delete(nodeName)
+			unreachableNodesMutex.Unlock()
+		}()
+
+		podStatus, err := awReconciler.getPodStatus(ctx, aw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podStatus.running).Should(Equal(int32(1)))
+		Expect(podStatus.lost).Should(Equal(int32(1)))
+
+		By("Reconciling: Running -> Resetting")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResetting))
+		Expect(aw.Status.InfrastructureRetries).Should(Equal(int32(1)))
+		Expect(aw.Status.PodStatus.Lost).Should(Equal(int32(1)))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("FoundLostPods"))
+		Expect(k8sClient.Delete(ctx, lostPod, client.GracePeriodSeconds(0))).To(Succeed())
+	})
+
+	It("Exceeding the active deadline leads to a failed AppWrapper", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		beginRunning()
+		fullyRunning()
+
+		By("Setting a 1 second active deadline")
+		aw := getAppWrapper(awName)
+		aw.Annotations = map[string]string{workloadv1beta2.ActiveDeadlineSecondsAnnotation: "1"}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		time.Sleep(1 * time.Second)
+
+		By("Reconciling: Running -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(aw.Status.Retries).Should(Equal(int32(0)))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("DeadlineExceeded"))
+	})
+
+	It("A Failed bare Pod is recreated when the AppWrapper is reset", func() {
+		advanceToResuming(pod(100, 0, true))
+		awReconciler.Config.FaultTolerance.RetryLimit = 1
+		beginRunning()
+		fullyRunning()
+
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		Expect(pods[0].Spec.RestartPolicy).Should(Equal(v1.RestartPolicyNever))
+		failedUID := pods[0].UID
+
+		By("Simulating the Pod Failing")
+		Expect(setPodStatus(aw, v1.PodFailed, 1)).To(Succeed())
+
+		By("Reconciling: Running -> Resetting")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResetting))
+		Expect(aw.Status.Retries).Should(Equal(int32(1)))
+
+		By("Reconciling: Resetting -> Resuming")
+		for i := 0; i < 3 && aw.Status.Phase == workloadv1beta2.AppWrapperResetting; i++ {
+			_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			aw = getAppWrapper(awName)
+		}
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResuming))
+		Expect(aw.Status.DeletionReason).Should(Equal(workloadv1beta2.DeletionReasonReset))
+		Expect(getPods(aw)).Should(BeEmpty())
+
+		By("Reconciling: Resuming -> Running")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		pods = getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		Expect(pods[0].UID).ShouldNot(Equal(failedUID))
+		Expect(pods[0].Status.Phase).Should(Equal(v1.PodPending))
+	})
+
+	It("A change to a parent enqueues the AppWrappers that reference it", func() {
+		parent := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: randName("pipeline"), Namespace: "default", UID: "parent-uid"}}
+		parentGVK := v1.SchemeGroupVersion.WithKind("ConfigMap")
+
+		By("Creating a child AppWrapper and an AppWrapper with a different parent")
+		advanceToResuming(pod(100, 0, false))
+		aw := getAppWrapper(awName)
+		aw.Spec.ParentRef = &workloadv1beta2.AppWrapperParentReference{APIVersion: "v1", Kind: "ConfigMap", Name: parent.Name}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		other := toAppWrapper(pod(100, 0, false))
+		other.Spec.ParentRef = &workloadv1beta2.AppWrapperParentReference{APIVersion: "v1", Kind: "ConfigMap", Name: randName("pipeline")}
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+
+		By("Mapping the parent to its children")
+		Expect(awReconciler.parentMapFunc(parentGVK)(ctx, parent)).Should(ConsistOf(reconcile.Request{NamespacedName: awName}))
+		Expect(awReconciler.parentMapFunc(v1.SchemeGroupVersion.WithKind("Secret"))(ctx, parent)).Should(BeEmpty())
+
+		By("A reference with a UID only matches that incarnation of the parent")
+		aw = getAppWrapper(awName)
+		aw.Spec.ParentRef.UID = "previous-uid"
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		Expect(awReconciler.parentMapFunc(parentGVK)(ctx, parent)).Should(BeEmpty())
+
+		Expect(k8sClient.Delete(ctx, other)).To(Succeed())
+	})
+
+	It("A resync enqueues the AppWrappers that have not yet Succeeded or Failed", func() {
+		advanceToResuming(pod(100, 0, false))
+		terminal := []*workloadv1beta2.AppWrapper{}
+		for _, phase := range []workloadv1beta2.AppWrapperPhase{workloadv1beta2.AppWrapperSucceeded, workloadv1beta2.AppWrapperFailed} {
+			aw := toAppWrapper(pod(100, 0, false))
+			Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+			aw.Status.Phase = phase
+			Expect(k8sClient.Status().Update(ctx, aw)).To(Succeed())
+			terminal = append(terminal, aw)
+		}
+
+		events := make(chan event.GenericEvent, 100)
+		Expect(awReconciler.resync(ctx, events)).To(Succeed())
+		close(events)
+		enqueued := []string{}
+		for e := range events {
+			enqueued = append(enqueued, e.Object.GetName())
+		}
+		Expect(enqueued).Should(ContainElement(awName.Name))
+		for _, aw := range terminal {
+			Expect(enqueued).ShouldNot(ContainElement(aw.Name))
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		}
+	})
+
+	It("Each component records when its deletion is complete", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, false))
+		beginRunning()
+
+		By("Making the second component slow to delete")
+		aw := getAppWrapper(awName)
+		slow := &v1.Pod{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[1].Name, Namespace: aw.Namespace}, slow)).To(Succeed())
+		controllerutil.AddFinalizer(slow, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, slow)).To(Succeed())
+
+		By("Suspending the AppWrapper")
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		for i := 0; i < 3; i++ { // Running -> Suspending, initiate deletion, observe deletion
+			_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspending))
+		Expect(meta.IsStatusConditionTrue(aw.Status.ComponentStatus[0].Conditions, string(workloadv1beta2.DeletionComplete))).Should(BeTrue())
+		held := meta.FindStatusCondition(aw.Status.ComponentStatus[1].Conditions, string(workloadv1beta2.DeletionComplete))
+		Expect(held).ShouldNot(BeNil())
+		Expect(held.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(held.Reason).Should(Equal("DeletionInProgress"))
+
+		By("Allowing the slow component to be deleted")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(slow), slow)).To(Succeed())
+		controllerutil.RemoveFinalizer(slow, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, slow)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.ComponentStatus[1].Conditions, string(workloadv1beta2.DeletionComplete))).Should(BeTrue())
+	})
+
+	It("A shared scratch volume is injected into the pods of an annotated component", func() {
+		annotated := pod(100, 0, false)
+		annotated.Annotations = map[string]string{workloadv1beta2.SharedScratchAnnotation: "1Gi:/scratch"}
+		preexisting := pod(100, 0, true, func(p *v1.Pod) {
+			// a pre-existing volume named shared-scratch mounted into the first container and a second container without it
+			p.Spec.Volumes = []v1.Volume{{Name: workloadv1beta2.SharedScratchVolumeName, VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+			p.Spec.Containers[0].VolumeMounts = []v1.VolumeMount{{Name: workloadv1beta2.SharedScratchVolumeName, MountPath: "/data"}}
+			p.Spec.Containers = append(p.Spec.Containers, v1.Container{Name: "sidecar", Image: p.Spec.Containers[0].Image, Command: p.Spec.Containers[0].Command})
+		})
+		preexisting.Annotations = map[string]string{workloadv1beta2.SharedScratchAnnotation: "/scratch"}
+		advanceToResuming(annotated, preexisting, pod(100, 0, false))
+		beginRunning()
+
+		aw := getAppWrapper(awName)
+		scratchVolumes := func(p *v1.Pod) []v1.Volume {
+			volumes := []v1.Volume{}
+			for _, vol := range p.Spec.Volumes {
+				if vol.Name == workloadv1beta2.SharedScratchVolumeName {
+					volumes = append(volumes, vol)
+				}
+			}
+			return volumes
+		}
+		for _, p := range getPods(aw) {
+			switch p.Name {
+			case aw.Status.ComponentStatus[0].Name:
+				By("Injecting the volume and its mount into the annotated component")
+				volumes := scratchVolumes(&p)
+				Expect(volumes).Should(HaveLen(1))
+				Expect(volumes[0].EmptyDir).ShouldNot(BeNil())
+				Expect(volumes[0].EmptyDir.SizeLimit.String()).Should(Equal("1Gi"))
+				Expect(p.Spec.Containers[0].VolumeMounts).Should(ContainElement(v1.VolumeMount{Name: workloadv1beta2.SharedScratchVolumeName, MountPath: "/scratch"}))
+			case aw.Status.ComponentStatus[1].Name:
+				By("Reusing a pre-existing volume with the same name")
+				Expect(scratchVolumes(&p)).Should(HaveLen(1))
+				Expect(p.Spec.Containers[0].VolumeMounts).Should(ContainElement(v1.VolumeMount{Name: workloadv1beta2.SharedScratchVolumeName, MountPath: "/data"}))
+				Expect(p.Spec.Containers[0].VolumeMounts).ShouldNot(ContainElement(HaveField("MountPath", "/scratch")))
+				Expect(p.Spec.Containers[1].VolumeMounts).Should(ContainElement(v1.VolumeMount{Name: workloadv1beta2.SharedScratchVolumeName, MountPath: "/scratch"}))
+			default:
+				By("Leaving components without the annotation unchanged")
+				Expect(scratchVolumes(&p)).Should(BeEmpty())
+			}
+		}
+	})
+
+	It("Success is deferred until the AppWrapper has been Running for its minimum duration", func() {
+		advanceToResuming(pod(100, 0, false))
+		awReconciler.Config.FaultTolerance.MinimumRunningDuration = time.Minute
+		beginRunning()
+		aw := getAppWrapper(awName)
+		Expect(setPodStatus(aw, v1.PodSucceeded, 1)).To(Succeed())
+
+		By("Reconciling before the minimum running duration has elapsed")
+		result, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).Should(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).Should(BeNumerically("<=", time.Minute))
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(aw.Status.PodStatus.Succeeded).Should(Equal(int32(1)))
+
+		By("Reconciling once the minimum running duration has elapsed")
+		awReconciler.Config.FaultTolerance.MinimumRunningDuration = 0
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSucceeded))
+	})
+
+	It("Components may override the warmup grace period of their pending Pods", func() {
+		sidecar := pod(100, 0, false)
+		sidecar.Annotations = map[string]string{workloadv1beta2.WarmupGracePeriodDurationAnnotation: "0s"}
+		head := pod(100, 0, false)
+		head.Annotations = map[string]string{workloadv1beta2.WarmupGracePeriodDurationAnnotation: "1h"}
+		advanceToResuming(sidecar, head)
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // Resuming -> Running
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		setComponentPodPhase := func(componentIdx int, phase v1.PodPhase) {
+			p := &v1.Pod{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[componentIdx].Name, Namespace: aw.Namespace}, p)).To(Succeed())
+			p.Status.Phase = phase
+			ready := v1.ConditionFalse
+			if phase == v1.PodRunning {
+				ready = v1.ConditionTrue
+			}
+			p.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: ready}}
+			Expect(k8sClient.Status().Update(ctx, p)).To(Succeed())
+		}
+
+		By("Waiting for the pending Pod of the component with the longer warmup grace period")
+		setComponentPodPhase(0, v1.PodRunning)
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))).Should(BeFalse())
+
+		By("Giving up on the pending Pod of the component with the shorter warmup grace period")
+		setComponentPodPhase(0, v1.PodPending)
+		setComponentPodPhase(1, v1.PodRunning)
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("InsufficientPodsReady"))
+	})
+
+	It("Running Pods that are not yet ready are subject to the warmup grace period of their component", func() {
+		sidecar := pod(100, 0, false)
+		sidecar.Annotations = map[string]string{workloadv1beta2.WarmupGracePeriodDurationAnnotation: "0s"}
+		head := pod(100, 0, false)
+		head.Annotations = map[string]string{workloadv1beta2.WarmupGracePeriodDurationAnnotation: "1h"}
+		advanceToResuming(sidecar, head)
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // Resuming -> Running
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		By("Simulating a pending sidecar Pod and a running head Pod that is not ready")
+		headPod := &v1.Pod{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[1].Name, Namespace: aw.Namespace}, headPod)).To(Succeed())
+		headPod.Status.Phase = v1.PodRunning
+		headPod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}}
+		Expect(k8sClient.Status().Update(ctx, headPod)).To(Succeed())
+		podStatus, err := awReconciler.getPodStatus(ctx, aw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podStatus.pendingComponents).Should(Equal(sets.New(0, 1)))
+		Expect(awReconciler.warmupGraceDuration(ctx, aw, podStatus.pendingComponents)).Should(Equal(time.Hour))
+
+		By("The warmup grace period of the head keeps the AppWrapper waiting")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))).Should(BeFalse())
+	})
+
+	It("A paused AppWrapper is not reconciled until it is unpaused", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, false))
+		beginRunning()
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+
+		By("Pausing the Running AppWrapper")
+		aw := getAppWrapper(awName)
+		aw.Annotations = map[string]string{workloadv1beta2.PauseReconcileAnnotation: "true"}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		before := getAppWrapper(awName).Status
+		Expect(setPodStatus(aw, v1.PodFailed, 1)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status).Should(Equal(before))
+		Expect(drainEvents(recorder)).Should(ConsistOf(ContainSubstring("ReconcilePaused")))
+
+		By("Reconciling again while paused does not repeat the event")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drainEvents(recorder)).Should(BeEmpty())
+
+		By("Unpausing the AppWrapper")
+		delete(aw.Annotations, workloadv1beta2.PauseReconcileAnnotation)
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(aw.Status.PodStatus.Failed).Should(Equal(int32(1)))
+	})
+
+	It("An AppWrapper managed by another controller is only observed", func() {
+		aw := toAppWrapper(pod(100, 0, false), pod(100, 1, true))
+		aw.Spec.ManagedBy = ptr.To("kueue.x-k8s.io/multikueue")
+		Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+		awName = types.NamespacedName{Name: aw.Name, Namespace: aw.Namespace}
+		awReconciler = &AppWrapperReconciler{
+			Client:   k8sClient,
+			Recorder: &record.FakeRecorder{},
+			Scheme:   k8sClient.Scheme(),
+			Config:   config.NewAppWrapperConfig(),
+		}
+
+		By("Mirroring the status of the remote AppWrapper")
+		aw = getAppWrapper(awName)
+		aw.Status.Phase = workloadv1beta2.AppWrapperRunning
+		meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+			Type:   string(workloadv1beta2.ResourcesDeployed),
+			Status: metav1.ConditionTrue,
+			Reason: "Remote",
+		})
+		Expect(k8sClient.Status().Update(ctx, aw)).To(Succeed())
+		before := getAppWrapper(awName)
+
+		By("Reconciling does not create components or change the status")
+		for i := 0; i < 3; i++ {
+			_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		aw = getAppWrapper(awName)
+		Expect(aw.Status).Should(Equal(before.Status))
+		Expect(aw.Finalizers).Should(BeEmpty())
+		Expect(getPods(aw)).Should(BeEmpty())
+	})
+
+	It("The value at a Component's EndpointPath is mirrored into its status", func() {
+		component := pod(100, 0, false)
+		component.EndpointPath = "status.podIP"
+		advanceToResuming(component)
+		beginRunning()
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.ComponentStatus[0].Endpoint).Should(BeEmpty())
+
+		By("Reporting the endpoint in the status of the Pod")
+		wrappedPod := getPods(aw)[0]
+		wrappedPod.Status.PodIP = "10.0.0.1"
+		Expect(k8sClient.Status().Update(ctx, &wrappedPod)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.ComponentStatus[0].Endpoint).Should(Equal("10.0.0.1"))
+	})
+
+	It("The admission gate is removed from Pods once the AppWrapper is admitted", func() {
+		advanceToResuming(pod(100, 0, true, withSchedulingGates("example.com/admission", "example.com/user-gate")),
+			pod(100, 0, true, withSchedulingGates("example.com/admission")))
+		awReconciler.Config.AdmissionGateName = "example.com/admission"
+		beginRunning()
+
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		for _, pod := range pods {
+			Expect(pod.Spec.SchedulingGates).Should(ContainElement(v1.PodSchedulingGate{Name: "example.com/admission"}))
+		}
+
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		gates := []v1.PodSchedulingGate{}
+		for _, pod := range getPods(aw) {
+			Expect(pod.Spec.SchedulingGates).ShouldNot(ContainElement(v1.PodSchedulingGate{Name: "example.com/admission"}))
+			gates = append(gates, pod.Spec.SchedulingGates...)
+		}
+		// the user-supplied gate and the gate injected by Kueue (markerPodSet) remain
+		Expect(gates).Should(ConsistOf(v1.PodSchedulingGate{Name: "example.com/user-gate"}, markerPodSet.SchedulingGates[0], markerPodSet.SchedulingGates[0]))
+	})
+
+	It("The scheduling latency of Pods is summarized in the PodStatus", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, false), pod(100, 0, false))
+		beginRunning()
+		aw := getAppWrapper(awName)
+		Expect(setPodScheduled(aw, 10*time.Second, 20*time.Second, time.Minute)).To(Succeed())
+		Expect(setPodStatus(aw, v1.PodRunning, 3)).To(Succeed())
+
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.PodStatus.Running).Should(Equal(int32(3)))
+		Expect(aw.Status.PodStatus.MaxSchedulingLatencySeconds).Should(Equal(int32(60)))
+		Expect(aw.Status.PodStatus.AverageSchedulingLatencySeconds).Should(Equal(int32(30)))
+	})
+
+	It("The time spent in each phase is accumulated in the PhaseTimings", func() {
+		By("Create an AppWrapper")
+		aw := toAppWrapper(pod(100, 0, false))
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+		awName = types.NamespacedName{Name: aw.Name, Namespace: aw.Namespace}
+		// status timestamps are persisted with a resolution of one second
+		fakeClock := testingclock.NewFakePassiveClock(time.Now().Truncate(time.Second))
+		awReconciler = &AppWrapperReconciler{
+			Client:   k8sClient,
+			Recorder: &record.FakeRecorder{},
+			Scheme:   k8sClient.Scheme(),
+			Config:   config.NewAppWrapperConfig(),
+			Clock:    fakeClock,
+		}
+		reconcileAndGet := func(phase workloadv1beta2.AppWrapperPhase) *workloadv1beta2.AppWrapperPhaseTimings {
+			_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			aw := getAppWrapper(awName)
+			Expect(aw.Status.Phase).Should(Equal(phase))
+			Expect(aw.Status.PhaseTimings).ShouldNot(BeNil())
+			Expect(aw.Status.PhaseTimings.LastTransitionTime.Time).Should(BeTemporally("==", fakeClock.Now()))
+			return aw.Status.PhaseTimings
+		}
+
+		timings := reconcileAndGet(workloadv1beta2.AppWrapperSuspended) // Empty -> Suspended
+		Expect(timings.Suspended.Duration).Should(BeZero())
+
+		By("Resuming after 30 seconds")
+		fakeClock.SetTime(fakeClock.Now().Add(30 * time.Second))
+		aw = getAppWrapper(awName)
+		aw.Spec.Suspend = false
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		timings = reconcileAndGet(workloadv1beta2.AppWrapperResuming)
+		Expect(timings.Suspended.Duration).Should(Equal(30 * time.Second))
+
+		By("Running after 5 seconds")
+		fakeClock.SetTime(fakeClock.Now().Add(5 * time.Second))
+		timings = reconcileAndGet(workloadv1beta2.AppWrapperRunning)
+		Expect(timings.Resuming.Duration).Should(Equal(5 * time.Second))
+		Expect(timings.Running.Duration).Should(BeZero())
+
+		By("Suspending after 2 minutes")
+		fakeClock.SetTime(fakeClock.Now().Add(2 * time.Minute))
+		aw = getAppWrapper(awName)
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		timings = reconcileAndGet(workloadv1beta2.AppWrapperSuspending)
+		Expect(timings.Suspended.Duration).Should(Equal(30 * time.Second))
+		Expect(timings.Resuming.Duration).Should(Equal(5 * time.Second))
+		Expect(timings.Running.Duration).Should(Equal(2 * time.Minute))
+	})
+
+	It("A finalizer removed while resources are deployed is restored", func() {
+		advanceToResuming(pod(100, 0, false))
+		beginRunning()
+
+		By("Removing the finalizer of the Running AppWrapper")
+		aw := getAppWrapper(awName)
+		Expect(controllerutil.RemoveFinalizer(aw, AppWrapperFinalizer)).Should(BeTrue())
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(controllerutil.ContainsFinalizer(aw, AppWrapperFinalizer)).Should(BeTrue())
+	})
+
+	It("The deletion propagation annotation selects the propagation policy of component deletion", func() {
+		advanceToResuming(pod(100, 0, false))
+		aw := getAppWrapper(awName)
+		aw.Annotations = map[string]string{workloadv1beta2.DeletionPropagationAnnotation: string(metav1.DeletePropagationForeground)}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		policies := []metav1.DeletionPropagation{}
+		watchingClient, err := client.NewWithWatch(cfg, client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).NotTo(HaveOccurred())
+		awReconciler.Client = interceptor.NewClient(watchingClient, interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				deleteOpts := &client.DeleteOptions{}
+				deleteOpts.ApplyOptions(opts)
+				if _, isComponent := obj.(*metav1.PartialObjectMetadata); isComponent && deleteOpts.PropagationPolicy != nil {
+					policies = append(policies, *deleteOpts.PropagationPolicy)
+				}
+				// envtest has no garbage collector to complete a Foreground deletion
+				return c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			},
+		})
+		beginRunning()
+
+		By("Suspending the AppWrapper")
+		aw = getAppWrapper(awName)
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // Running -> Suspending
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // initiate deletion
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).ShouldNot(BeEmpty())
+		Expect(policies).Should(HaveEach(metav1.DeletePropagationForeground))
+	})
+
+	It("Orphan deletion propagation neither waits for nor forcefully deletes the orphaned Pods", func() {
+		advanceToResuming(pod(100, 0, false))
+		aw := getAppWrapper(awName)
+		aw.Annotations = map[string]string{workloadv1beta2.DeletionPropagationAnnotation: string(metav1.DeletePropagationOrphan)}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		awReconciler.Config.FaultTolerance.ForcefulDeletionGracePeriod = 0 * time.Second
+		forcefulDeletes := 0
+		// envtest has no garbage collector to complete an Orphan deletion
+		awReconciler.Client = forcefulDeletionCountingClient(&forcefulDeletes, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		beginRunning()
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+
+		By("Simulating an orphaned Pod that is not removed by the deletion of its component")
+		orphan := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("orphan"), Namespace: awName.Namespace,
+				Labels: map[string]string{workloadv1beta2.AppWrapperLabel: awName.Name}, Finalizers: []string{"workload.codeflare.dev/test"}},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		Expect(k8sClient.Create(ctx, orphan)).To(Succeed())
+
+		By("Suspending the AppWrapper")
+		aw = getAppWrapper(awName)
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		for i := 0; i < 3 && aw.Status.Phase != workloadv1beta2.AppWrapperSuspended; i++ {
+			_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			aw = getAppWrapper(awName)
+		}
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspended))
+		Expect(forcefulDeletes).Should(BeZero())
+		Expect(drainEvents(recorder)).ShouldNot(ContainElement(ContainSubstring("ForcefulDeletion")))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(orphan), orphan)).To(Succeed())
+		Expect(orphan.DeletionTimestamp.IsZero()).Should(BeTrue())
+
+		By("Cleanup the orphaned Pod")
+		controllerutil.RemoveFinalizer(orphan, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, orphan)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, orphan, client.GracePeriodSeconds(0))).To(Succeed())
+	})
+
+	It("A restartPolicy OnFailure Pod whose container was restarted in place is not counted as failed", func() {
+		advanceToResuming(pod(100, 0, true, func(p *v1.Pod) { p.Spec.RestartPolicy = v1.RestartPolicyOnFailure }), pod(100, 0, false))
+		beginRunning()
+		fullyRunning()
+
+		By("Simulating the kubelet restarting a failed container of the OnFailure Pod")
+		aw := getAppWrapper(awName)
+		restarted := false
+		for _, p := range getPods(aw) {
+			if p.Spec.RestartPolicy != v1.RestartPolicyOnFailure {
+				continue
+			}
+			p.Status.ContainerStatuses = []v1.ContainerStatus{{
+				Name:         p.Spec.Containers[0].Name,
+				Image:        p.Spec.Containers[0].Image,
+				Ready:        true,
+				RestartCount: 1,
+				State:        v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.Now()}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+					ExitCode: 1,
+					Reason:   "Error",
+				}},
+			}}
+			Expect(k8sClient.Status().Update(ctx, &p)).To(Succeed())
+			restarted = true
+		}
+		Expect(restarted).Should(BeTrue())
+
+		By("Reconciling: Running -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		podStatus, err := awReconciler.getPodStatus(ctx, aw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podStatus.failed).Should(Equal(int32(0)))
+		Expect(podStatus.running).Should(Equal(int32(2)))
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(aw.Status.Retries).Should(Equal(int32(0)))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))).Should(BeFalse())
+	})
+
+	It("The deletion of the queue of a Running AppWrapper sets QueueMissing and applies the MissingQueuePolicy", func() {
+		cq := slackQueue(randName("cq"), resource.MustParse("4"))
+		Expect(k8sClient.Create(ctx, cq)).To(Succeed())
+		lq := &kueue.LocalQueue{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("lq"), Namespace: "default"},
+			Spec:       kueue.LocalQueueSpec{ClusterQueue: kueue.ClusterQueueReference(cq.Name)},
+		}
+		Expect(k8sClient.Create(ctx, lq)).To(Succeed())
+
+		advanceToResuming(pod(100, 0, false))
+		aw := getAppWrapper(awName)
+		aw.Labels = map[string]string{"kueue.x-k8s.io/queue-name": lq.Name}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		beginRunning()
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+		aw = getAppWrapper(awName)
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.QueueMissing))).Should(BeNil())
+
+		By("Simulating the Workload that Kueue created for the AppWrapper")
+		wl := &kueue.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      jobframework.GetWorkloadNameForOwnerWithGVK(aw.Name, aw.UID, workload.GVK),
+				Namespace: aw.Namespace,
+			},
+			Spec: kueue.WorkloadSpec{PodSets: kueuePodSets, QueueName: lq.Name},
+		}
+		Expect(k8sClient.Create(ctx, wl)).To(Succeed())
+
+		By("Deleting the LocalQueue leaves the AppWrapper running with the Warn policy")
+		Expect(k8sClient.Delete(ctx, lq)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QueueMissing))).Should(BeTrue())
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.QueueMissing)).Reason).Should(Equal("LocalQueueNotFound"))
+		Expect(drainEvents(recorder)).Should(ContainElement(
+			Equal(v1.EventTypeWarning + " QueueMissing LocalQueue " + lq.Name + " not found")))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(wl), wl)).To(Succeed())
+		Expect(ptr.Deref(wl.Spec.Active, true)).Should(BeTrue())
+
+		By("Recreating the LocalQueue clears the condition")
+		lq = &kueue.LocalQueue{
+			ObjectMeta: metav1.ObjectMeta{Name: lq.Name, Namespace: lq.Namespace},
+			Spec:       kueue.LocalQueueSpec{ClusterQueue: kueue.ClusterQueueReference(cq.Name)},
+		}
+		Expect(k8sClient.Create(ctx, lq)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QueueMissing))).Should(BeFalse())
+
+		By("Deleting the ClusterQueue deactivates the Workload with the Suspend policy")
+		awReconciler.Config.MissingQueuePolicy = config.MissingQueueSuspend
+		Expect(k8sClient.Delete(ctx, cq)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QueueMissing))).Should(BeTrue())
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.QueueMissing)).Reason).Should(Equal("ClusterQueueNotFound"))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(wl), wl)).To(Succeed())
+		Expect(wl.Spec.Active).Should(Equal(ptr.To(false)))
+
+		By("Simulating Kueue suspending the AppWrapper of the deactivated Workload")
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		for i := 0; i < 4 && aw.Status.Phase != workloadv1beta2.AppWrapperSuspended; i++ {
+			_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			aw = getAppWrapper(awName)
+		}
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspended))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QueueMissing))).Should(BeTrue())
+		Expect(awReconciler.clusterQueueMapFunc(ctx, cq)).Should(ContainElement(reconcile.Request{NamespacedName: awName}))
+
+		By("Recreating the ClusterQueue clears the condition and reactivates the Workload")
+		cq = slackQueue(cq.Name, resource.MustParse("4"))
+		Expect(k8sClient.Create(ctx, cq)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspended))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QueueMissing))).Should(BeFalse())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(wl), wl)).To(Succeed())
+		Expect(wl.Spec.Active).Should(Equal(ptr.To(true)))
+		Expect(wl.Annotations).ShouldNot(HaveKey(queueMissingDeactivationAnnotation))
+
+		Expect(k8sClient.Delete(ctx, wl)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, lq)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, cq)).To(Succeed())
+	})
+
+	It("Escalation to forceful deletion is reported by an event and a metric", func() {
+		advanceToResuming(pod(100, 0, false))
+		awReconciler.Config.FaultTolerance.ForcefulDeletionGracePeriod = 0 * time.Second
+		forcefulDeletes := 0
+		awReconciler.Client = forcefulDeletionCountingClient(&forcefulDeletes)
+		beginRunning()
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+		forcefulBefore := counterValue(metrics.AppWrapperForcefulDeletionCounter, awName.Namespace)
+
+		By("Adding a finalizer so the Pod lingers after deletion")
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		lingering := &pods[0]
+		controllerutil.AddFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+
+		By("Suspending the AppWrapper")
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // Running -> Suspending
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Reconciling past the forceful deletion deadline")
+		for i := 0; i < 3; i++ {
+			_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspending))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeletingResources)).Reason).Should(Equal("ForcefulDeletion"))
+		Expect(forcefulDeletes).Should(BeNumerically(">", 0))
+		Expect(counterValue(metrics.AppWrapperForcefulDeletionCounter, awName.Namespace)).Should(Equal(forcefulBefore+1), "each deletion is counted once")
+		Expect(drainEvents(recorder)).Should(ConsistOf(
+			Equal(v1.EventTypeWarning + " ForcefulDeletion Deletion grace period of 0s expired; forcefully deleting 1 remaining pods")))
+
+		By("Deletion completes once the finalizer is removed")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(lingering), lingering)).To(Succeed())
+		controllerutil.RemoveFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+		for i := 0; i < 3 && aw.Status.Phase == workloadv1beta2.AppWrapperSuspending; i++ {
+			_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			aw = getAppWrapper(awName)
+		}
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspended))
+	})
+
+	It("Escalation to forceful deletion of a deleted AppWrapper is reported once", func() {
+		advanceToResuming(pod(100, 0, false))
+		awReconciler.Config.FaultTolerance.ForcefulDeletionGracePeriod = 0 * time.Second
+		beginRunning()
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+		forcefulBefore := counterValue(metrics.AppWrapperForcefulDeletionCounter, awName.Namespace)
+
+		By("Adding a finalizer so the Pod lingers after deletion")
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		lingering := &pods[0]
+		controllerutil.AddFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+
+		By("Deleting the AppWrapper and reconciling past the forceful deletion deadline")
+		Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		for i := 0; i < 4; i++ {
+			_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperTerminating))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeletingResources)).Reason).Should(Equal("ForcefulDeletion"))
+		Expect(counterValue(metrics.AppWrapperForcefulDeletionCounter, awName.Namespace)).Should(Equal(forcefulBefore+1), "each deletion is counted once")
+		Expect(drainEvents(recorder)).Should(ConsistOf(
+			Equal(v1.EventTypeWarning + " ForcefulDeletion Deletion grace period of 0s expired; forcefully deleting 1 remaining pods")))
+
+		By("Removing the finalizer lets the deletion complete")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(lingering), lingering)).To(Succeed())
+		controllerutil.RemoveFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+	})
+
+	It("Forceful deletion is never issued when disabled by annotation", func() {
+		advanceToResuming(pod(100, 0, false))
+		aw := getAppWrapper(awName)
+		aw.Annotations = map[string]string{workloadv1beta2.NoForcefulDeletionAnnotation: "true"}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		awReconciler.Config.FaultTolerance.ForcefulDeletionGracePeriod = 0 * time.Second
+		forcefulDeletes := 0
+		awReconciler.Client = forcefulDeletionCountingClient(&forcefulDeletes)
+		beginRunning()
+
+		By("Adding a finalizer so the Pod lingers after graceful deletion")
+		aw = getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		lingering := &pods[0]
+		controllerutil.AddFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+
+		By("Suspending the AppWrapper")
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // Running -> Suspending
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Reconciling past the forceful deletion deadline")
+		for i := 0; i < 3; i++ {
+			_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspending))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeletingResources)).Reason).Should(Equal("ForcefulDeletionDisabled"))
+		Expect(forcefulDeletes).Should(Equal(0))
+
+		By("Graceful deletion completes once the finalizer is removed")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(lingering), lingering)).To(Succeed())
+		controllerutil.RemoveFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+		for i := 0; i < 3 && aw.Status.Phase == workloadv1beta2.AppWrapperSuspending; i++ {
+			_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			aw = getAppWrapper(awName)
+		}
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSuspended))
+		Expect(forcefulDeletes).Should(Equal(0))
+	})
+
+	It("Cleanup after success uses the SuccessDeletionGracePeriod", func() {
+		advanceToResuming(pod(100, 0, false))
+		awReconciler.Config.FaultTolerance.SuccessDeletionGracePeriod = 0 * time.Second
+		forcefulDeletes := 0
+		awReconciler.Client = forcefulDeletionCountingClient(&forcefulDeletes)
+		beginRunning()
+
+		By("Adding a finalizer so the Pod lingers after graceful deletion")
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		lingering := &pods[0]
+		controllerutil.AddFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+
+		By("Reconciling: Running -> Succeeded")
+		Expect(setPodStatus(aw, v1.PodSucceeded, 1)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSucceeded))
+
+		By("Reconciling past the success deletion deadline")
+		for i := 0; i < 3; i++ {
+			_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(awReconciler.Config.FaultTolerance.ForcefulDeletionGracePeriod).Should(Equal(10 * time.Minute))
+		Expect(forcefulDeletes).Should(BeNumerically(">", 0))
+
+		By("Removing the finalizer so deletion can complete")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(lingering), lingering)).To(Succeed())
+		controllerutil.RemoveFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+	})
+
+	It("Deleting a Succeeded AppWrapper uses the SuccessDeletionGracePeriod", func() {
+		advanceToResuming(pod(100, 0, false))
+		awReconciler.Config.FaultTolerance.SuccessTTL = 1 * time.Hour
+		awReconciler.Config.FaultTolerance.SuccessDeletionGracePeriod = 0 * time.Second
+		forcefulDeletes := 0
+		awReconciler.Client = forcefulDeletionCountingClient(&forcefulDeletes)
+		beginRunning()
+
+		By("Adding a finalizer so the Pod lingers after graceful deletion")
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		lingering := &pods[0]
+		controllerutil.AddFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+
+		By("Reconciling: Running -> Succeeded")
+		Expect(setPodStatus(aw, v1.PodSucceeded, 1)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSucceeded))
+
+		By("Deleting the Succeeded AppWrapper before its SuccessTTL expires")
+		Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		for i := 0; i < 3; i++ {
+			_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperTerminating))
+		Expect(hasSucceeded(aw)).Should(BeTrue())
+		Expect(forcefulDeletes).Should(BeNumerically(">", 0))
+
+		By("Removing the finalizer so deletion can complete")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(lingering), lingering)).To(Succeed())
+		controllerutil.RemoveFinalizer(lingering, "workload.codeflare.dev/test")
+		Expect(k8sClient.Update(ctx, lingering)).To(Succeed())
+	})
+
+	It("An AppWrapper is held back while another AppWrapper with its concurrencyKey is deployed", func() {
+		By("Creating a deployed AppWrapper with the same concurrencyKey, as if admitted concurrently by the webhook")
+		holder := toAppWrapper(pod(100, 0, true))
+		holder.Spec.ConcurrencyKey = "nightly-training"
+		Expect(k8sClient.Create(ctx, holder)).To(Succeed())
+		holder.Status.Phase = workloadv1beta2.AppWrapperRunning
+		meta.SetStatusCondition(&holder.Status.Conditions, metav1.Condition{Type: string(workloadv1beta2.ResourcesDeployed), Status: metav1.ConditionTrue, Reason: "Test"})
+		holder.Status.ComponentStatus = []workloadv1beta2.AppWrapperComponentStatus{{
+			APIVersion: "v1", Kind: "Pod", Name: "holder-pod",
+			Conditions: []metav1.Condition{{Type: string(workloadv1beta2.ResourcesDeployed), Status: metav1.ConditionTrue, Reason: "Test", LastTransitionTime: metav1.Now()}},
+		}}
+		Expect(k8sClient.Status().Update(ctx, holder)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, holder)).To(Succeed()) })
+
+		advanceToResuming(pod(100, 0, true))
+		aw := getAppWrapper(awName)
+		aw.Spec.ConcurrencyKey = "nightly-training"
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+
+		By("Reconciling: Resuming -> Resuming while the other AppWrapper is deployed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResuming))
+		Expect(deployedComponentCount(aw)).Should(Equal(int32(0)))
+		pending := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeploymentPending))
+		Expect(pending).ShouldNot(BeNil())
+		Expect(pending.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(pending.Reason).Should(Equal("ConcurrencyKeyHeld"))
+		Expect(pending.Message).Should(ContainSubstring(holder.Name))
+
+		By("Reconciling: Resuming -> Running once the other AppWrapper completes")
+		holder = getAppWrapper(types.NamespacedName{Name: holder.Name, Namespace: holder.Namespace})
+		holder.Status.Phase = workloadv1beta2.AppWrapperSucceeded
+		Expect(k8sClient.Status().Update(ctx, holder)).To(Succeed())
+		beginRunning()
+	})
+
+	It("Configured failure rules detect failed custom resources", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, false), syntheticJob())
+		awReconciler.Config.ComponentFailureRules = []config.ComponentFailureRule{{
+			APIVersion:     "test.codeflare.dev/v1",
+			Kind:           "SyntheticJob",
+			FailedJSONPath: "status.applicationState.state",
+			FailedValue:    "FAILED",
+		}}
+		beginRunning()
+		fullyRunning()
+
+		By("Simulating the custom resource reporting a non-failed state")
+		aw := getAppWrapper(awName)
+		cs := aw.Status.ComponentStatus[2]
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(cs.APIVersion)
+		obj.SetKind(cs.Kind)
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj)).To(Succeed())
+		Expect(unstructured.SetNestedField(obj.Object, "RUNNING", "status", "applicationState", "state")).To(Succeed())
+		Expect(k8sClient.Update(ctx, obj)).To(Succeed())
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		By("Simulating the custom resource reporting a failed state")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj)).To(Succeed())
+		Expect(unstructured.SetNestedField(obj.Object, "FAILED", "status", "applicationState", "state")).To(Succeed())
+		Expect(k8sClient.Update(ctx, obj)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("FailedComponent"))
+	})
+
+	It("DaemonSet readiness is based on the number of scheduled and ready pods", func() {
+		advanceToResuming(daemonSet(2, 100))
+
+		By("Reconciling: Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(2)), "the declared replicas are expected until the DaemonSet reports its scheduled pods")
+
+		By("Simulating the DaemonSet controller scheduling three pods")
+		ds := &appsv1.DaemonSet{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[0].Name, Namespace: aw.Namespace}, ds)).To(Succeed())
+		ds.Status.ObservedGeneration = ds.Generation
+		ds.Status.DesiredNumberScheduled = 3
+		ds.Status.NumberReady = 0
+		Expect(k8sClient.Status().Update(ctx, ds)).To(Succeed())
+		for i := 0; i < 3; i++ {
+			p := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: randName("ds-pod"), Namespace: aw.Namespace, Labels: map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+			}
+			Expect(k8sClient.Create(ctx, p)).To(Succeed())
+		}
+		Expect(setPodStatus(aw, v1.PodRunning, 3)).To(Succeed())
+
+		By("Reconciling: Running pods are not sufficient while the DaemonSet is not ready")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.ComponentStatus[0].DesiredPods).Should(Equal(ptr.To(int32(3))))
+		Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(3)))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeFalse())
+
+		By("Reconciling: PodsReady once the DaemonSet reports all pods ready")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ds), ds)).To(Succeed())
+		ds.Status.NumberReady = 3
+		Expect(k8sClient.Status().Update(ctx, ds)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+
+		By("Reconciling: a DaemonSet that is scheduled on no Nodes expects no pods")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ds), ds)).To(Succeed())
+		ds.Status.DesiredNumberScheduled = 0
+		ds.Status.NumberReady = 0
+		Expect(k8sClient.Status().Update(ctx, ds)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.ComponentStatus[0].DesiredPods).Should(Equal(ptr.To(int32(0))))
+		Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(0)))
+
+		By("Cleanup the simulated DaemonSet pods")
+		for _, p := range getPods(aw) {
+			Expect(k8sClient.Delete(ctx, &p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+
+	It("A DeploymentPending condition explains why component creation is being retried", func() {
+		advanceToResuming(pod(100, 0, false))
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+
+		By("Exhausting the pod quota of the namespace")
+		quota := &v1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("no-pods"), Namespace: awName.Namespace},
+			Spec:       v1.ResourceQuotaSpec{Hard: v1.ResourceList{v1.ResourcePods: resource.MustParse("0")}},
+		}
+		Expect(k8sClient.Create(ctx, quota)).To(Succeed())
+		quota.Status = v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{v1.ResourcePods: resource.MustParse("0")},
+			Used: v1.ResourceList{v1.ResourcePods: resource.MustParse("0")},
+		}
+		Expect(k8sClient.Status().Update(ctx, quota)).To(Succeed())
+
+		By("Reconciling: Resuming while the transient create error persists")
+		result, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).Should(Equal(awReconciler.Config.DeploymentRetryInterval))
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResuming))
+		pending := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeploymentPending))
+		Expect(pending).ShouldNot(BeNil())
+		Expect(pending.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(pending.Reason).Should(Equal("CreateRetrying"))
+		Expect(pending.Message).Should(ContainSubstring("error creating components"))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))).Should(BeNil())
+		Expect(drainEvents(recorder)).Should(ContainElement(ContainSubstring(string(workloadv1beta2.DeploymentPending))))
+
+		By("Reconciling: Resuming -> Running once the quota is removed")
+		Expect(k8sClient.Delete(ctx, quota)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionFalse(aw.Status.Conditions, string(workloadv1beta2.DeploymentPending))).Should(BeTrue())
+	})
+
+	It("Components are created over several reconciles when MaxReconcileDuration is exceeded", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, false), pod(100, 0, false), pod(100, 0, false))
+		awReconciler.Config.MaxReconcileDuration = time.Nanosecond
+
+		for expected := int32(1); expected < 4; expected++ {
+			By("Reconciling: Resuming -> Resuming with partial progress")
+			result, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).Should(Equal(awReconciler.Config.DeploymentRetryInterval))
+			aw := getAppWrapper(awName)
+			Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResuming))
+			Expect(deployedComponentCount(aw)).Should(Equal(expected))
+			Expect(getPods(aw)).Should(HaveLen(int(expected)))
+			throttled := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Throttled))
+			Expect(throttled).ShouldNot(BeNil())
+			Expect(throttled.Status).Should(Equal(metav1.ConditionTrue))
+			Expect(throttled.Reason).Should(Equal("MaxReconcileDuration"))
+			Expect(throttled.Message).Should(Equal(fmt.Sprintf("Creation of %v of 4 components is deferred to a later reconcile", 4-expected)))
+		}
+
+		By("Reconciling: Resuming -> Running once the last component is created")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(aw.Status.DeployedComponents).Should(Equal(int32(4)))
+		Expect(getPods(aw)).Should(HaveLen(4))
+		Expect(meta.IsStatusConditionFalse(aw.Status.Conditions, string(workloadv1beta2.Throttled))).Should(BeTrue())
+	})
+
+	It("Components without PodSets are created before pod-bearing components", func() {
+		advanceToResuming(deployment(100), service())
+		awReconciler.Config.MaxReconcileDuration = time.Nanosecond
+
+		By("Reconciling: the Service is created before the Deployment that precedes it")
+		result, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).Should(Equal(awReconciler.Config.DeploymentRetryInterval))
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResuming))
+		Expect(meta.IsStatusConditionTrue(aw.Status.ComponentStatus[0].Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+		Expect(meta.IsStatusConditionTrue(aw.Status.ComponentStatus[1].Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
+
+		By("Reconciling: the Deployment is created next")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.ComponentStatus[0].Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
+	})
+
+	It("Components are created in declaration order when CreatePodlessComponentsFirst is disabled", func() {
+		advanceToResuming(deployment(100), service())
+		awReconciler.Config.MaxReconcileDuration = time.Nanosecond
+		awReconciler.Config.CreatePodlessComponentsFirst = false
+
+		By("Reconciling: the Deployment is created first")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperResuming))
+		Expect(meta.IsStatusConditionTrue(aw.Status.ComponentStatus[0].Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
+		Expect(meta.IsStatusConditionTrue(aw.Status.ComponentStatus[1].Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+	})
+
+	It("Labels with invalid values are not injected", func() {
+		longValue := strings.Repeat("x", 64)
+		advanceToResuming(pod(100, 0, false), pod(100, 0, false))
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+		awReconciler.Config.RequiredPodLabels = map[string]string{"team": "ml", "cost-center": longValue}
+		aw := getAppWrapper(awName)
+		aw.Spec.Components[0].PodSetInfos[0].Labels = map[string]string{"propagated": longValue, "kept": "value"}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+
+		By("Reconciling: Resuming -> Running despite the invalid label values")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		for _, p := range pods {
+			Expect(p.Labels).Should(HaveKeyWithValue("team", "ml"))
+			Expect(p.Labels).ShouldNot(HaveKey("cost-center"))
+			Expect(p.Labels).ShouldNot(HaveKey("propagated"))
+		}
+		Expect(pods).Should(ContainElement(HaveField("ObjectMeta.Labels", HaveKeyWithValue("kept", "value"))))
+
+		By("Recording a warning for each component")
+		warnings := []string{}
+		for _, e := range drainEvents(recorder) {
+			if strings.HasPrefix(e, "Warning InvalidLabelValue") {
+				warnings = append(warnings, e)
+			}
+		}
+		Expect(warnings).Should(HaveLen(2))
+		Expect(warnings[0]).Should(ContainSubstring("cost-center, propagated"))
+		Expect(warnings[1]).Should(ContainSubstring("cost-center"))
+	})
+
+	It("The configured RunningRequeueInterval is used once pods are ready", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, false))
+		awReconciler.Config.RunningRequeueInterval = 42 * time.Second
+		beginRunning()
+
+		By("Simulating all Pods Running")
+		aw := getAppWrapper(awName)
+		Expect(setPodStatus(aw, v1.PodRunning, 2)).To(Succeed())
+		result, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+		Expect(result.RequeueAfter).Should(Equal(42 * time.Second))
+	})
+
+	It("A ready InferenceService is ready even when scaled to zero pods", func() {
+		advanceToResuming(inferenceService(2, 100))
+
+		By("Reconciling: Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(2)))
+		Expect(utils.ExpectedServicePodCount(aw)).Should(Equal(int32(2)))
+
+		By("Injecting labels into the inlined predictor PodSpec")
+		cs := aw.Status.ComponentStatus[0]
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(cs.APIVersion)
+		obj.SetKind(cs.Kind)
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj)).To(Succeed())
+		labels, found, err := unstructured.NestedStringMap(obj.Object, "spec", "predictor", "labels")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).Should(BeTrue())
+		Expect(labels).Should(HaveKeyWithValue(workloadv1beta2.AppWrapperLabel, aw.Name))
+		Expect(labels).Should(HaveKeyWithValue(workloadv1beta2.ServiceComponentLabel, "true"))
+
+		By("Reconciling: Not ready while the InferenceService is not Ready")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeFalse())
+
+		By("Reconciling: PodsReady once the InferenceService reports Ready with zero pods")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj)).To(Succeed())
+		Expect(unstructured.SetNestedSlice(obj.Object, []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True"},
+		}, "status", "conditions")).To(Succeed())
+		Expect(k8sClient.Update(ctx, obj)).To(Succeed())
+		Expect(getPods(aw)).Should(BeEmpty())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+	})
+
+	It("A Job alongside a Deployment succeeds when the Job completes and the Deployment is ready", func() {
+		advanceToResuming(batchJob(100), deployment(100))
+
+		By("Reconciling: Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(utils.ExpectedServicePodCount(aw)).Should(Equal(int32(1)))
+		dep := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[1].Name, Namespace: aw.Namespace}, dep)).To(Succeed())
+		Expect(dep.Spec.Template.Labels).Should(HaveKeyWithValue(workloadv1beta2.ServiceComponentLabel, "true"))
+
+		By("Simulating the Job and Deployment controllers creating running pods")
+		jobPod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("job-pod"), Namespace: aw.Namespace, Labels: map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		depPod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("dep-pod"), Namespace: aw.Namespace, Labels: dep.Spec.Template.Labels},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		for _, p := range []*v1.Pod{jobPod, depPod} {
+			Expect(k8sClient.Create(ctx, p)).To(Succeed())
+			p.Status.Phase = v1.PodRunning
+			Expect(k8sClient.Status().Update(ctx, p)).To(Succeed())
+		}
+		Expect(setDeploymentRollout(types.NamespacedName{Name: dep.Name, Namespace: dep.Namespace}, dep.Generation)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+
+		By("Reconciling: Running -> Succeeded once the Job's pod completes")
+		jobPod.Status.Phase = v1.PodSucceeded
+		Expect(k8sClient.Status().Update(ctx, jobPod)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSucceeded))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeFalse())
+
+		By("Cleanup the simulated pods")
+		for _, p := range getPods(aw) {
+			Expect(k8sClient.Delete(ctx, &p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+
+	It("PodsReady requires the rollout of a Deployment to have completed", func() {
+		advanceToResuming(deployment(100))
+
+		By("Reconciling: Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		depName := types.NamespacedName{Name: aw.Status.ComponentStatus[0].Name, Namespace: aw.Namespace}
+		dep := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, depName, dep)).To(Succeed())
+
+		By("Simulating a running pod of a Deployment whose observedGeneration lags")
+		depPod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("dep-pod"), Namespace: aw.Namespace, Labels: dep.Spec.Template.Labels},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		Expect(k8sClient.Create(ctx, depPod)).To(Succeed())
+		depPod.Status.Phase = v1.PodRunning
+		Expect(k8sClient.Status().Update(ctx, depPod)).To(Succeed())
+		Expect(setDeploymentRollout(depName, dep.Generation-1)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeFalse())
+
+		By("Simulating the Deployment controller catching up")
+		Expect(setDeploymentRollout(depName, dep.Generation)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+
+		By("Updating the Deployment's spec starts a new rollout")
+		Expect(k8sClient.Get(ctx, depName, dep)).To(Succeed())
+		dep.Spec.Template.Annotations = map[string]string{"example.com/restartedAt": "now"}
+		Expect(k8sClient.Update(ctx, dep)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeFalse())
+
+		By("Cleanup the simulated pods")
+		Expect(k8sClient.Delete(ctx, depPod, client.GracePeriodSeconds(0))).To(Succeed())
+	})
+
+	It("A Deployment rollout past the warmup deadline only delays PodsReady", func() {
+		advanceToResuming(deployment(100))
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // Resuming -> Running
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		depName := types.NamespacedName{Name: aw.Status.ComponentStatus[0].Name, Namespace: aw.Namespace}
+		dep := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, depName, dep)).To(Succeed())
+		depPod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("dep-pod"), Namespace: aw.Namespace, Labels: dep.Spec.Template.Labels},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		Expect(k8sClient.Create(ctx, depPod)).To(Succeed())
+		depPod.Status.Phase = v1.PodRunning
+		Expect(k8sClient.Status().Update(ctx, depPod)).To(Succeed())
+		Expect(setDeploymentRollout(depName, dep.Generation)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+
+		By("Restarting the rollout of the Deployment once the warmup period has expired")
+		awReconciler.Config.FaultTolerance.WarmupGracePeriod = 0
+		awReconciler.Config.FaultTolerance.AdmissionGracePeriod = 0
+		Expect(k8sClient.Get(ctx, depName, dep)).To(Succeed())
+		dep.Spec.Template.Annotations = map[string]string{"kubectl.kubernetes.io/restartedAt": "now"}
+		Expect(k8sClient.Update(ctx, dep)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.PodsReady)).Reason).Should(Equal("RolloutInProgress"))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))).Should(BeFalse())
+		Expect(aw.Status.Retries).Should(Equal(int32(0)))
+
+		By("Completing the rollout restores PodsReady")
+		Expect(k8sClient.Get(ctx, depName, dep)).To(Succeed())
+		Expect(setDeploymentRollout(depName, dep.Generation)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+
+		By("Cleanup the simulated pods")
+		Expect(k8sClient.Delete(ctx, depPod, client.GracePeriodSeconds(0))).To(Succeed())
+	})
+
+	It("An Indexed Job succeeds only once all of its completions have succeeded", func() {
+		advanceToResuming(batchJob(100, indexed(2, 5)))
+
+		By("Reconciling: Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(2)))
+		Expect(utils.ExpectedSucceededPodCount(aw)).Should(Equal(int32(5)))
+
+		simulateSucceededPods := func(count int) {
+			for i := 0; i < count; i++ {
+				p := &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: randName("job-pod"), Namespace: aw.Namespace, Labels: map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}},
+					Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+				}
+				Expect(k8sClient.Create(ctx, p)).To(Succeed())
+				p.Status.Phase = v1.PodSucceeded
+				Expect(k8sClient.Status().Update(ctx, p)).To(Succeed())
+			}
+		}
+
+		By("Reconciling: Running with only parallelism pods succeeded")
+		simulateSucceededPods(2)
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		By("Reconciling: Running -> Succeeded once all completions have succeeded")
+		simulateSucceededPods(3)
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSucceeded))
+
+		By("Cleanup the simulated pods")
+		for _, p := range getPods(aw) {
+			Expect(k8sClient.Delete(ctx, &p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+
+	It("A Job that completes via its successPolicy succeeds without all of its completions", func() {
+		advanceToResuming(batchJob(100, indexed(2, 5)))
+
+		By("Reconciling: Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(utils.ExpectedSucceededPodCount(aw)).Should(Equal(int32(5)))
+
+		By("Simulating the leader index succeeding")
+		p := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("job-pod"), Namespace: aw.Namespace, Labels: map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		Expect(k8sClient.Create(ctx, p)).To(Succeed())
+		p.Status.Phase = v1.PodSucceeded
+		Expect(k8sClient.Status().Update(ctx, p)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		By("Simulating the Job controller declaring the Job Complete via its successPolicy")
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[0].Name, Namespace: aw.Namespace}, job)).To(Succeed())
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		job.Status.CompletionTime = &now
+		job.Status.Succeeded = 1
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: batchv1.JobSuccessCriteriaMet, Status: v1.ConditionTrue, Reason: batchv1.JobReasonSuccessPolicy, LastTransitionTime: now},
+			{Type: batchv1.JobComplete, Status: v1.ConditionTrue, Reason: batchv1.JobReasonSuccessPolicy, LastTransitionTime: now},
+		}
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+
+		By("Reconciling: Running -> Succeeded")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSucceeded))
+
+		By("Cleanup the simulated pods")
+		for _, p := range getPods(aw) {
+			Expect(k8sClient.Delete(ctx, &p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+
+	It("A Job that is Complete without its successPolicy still requires all of its completions", func() {
+		advanceToResuming(batchJob(100, indexed(2, 5)))
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // Resuming -> Running
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+
+		By("Simulating one succeeded Pod and a Job marked Complete for another reason")
+		p := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("job-pod"), Namespace: aw.Namespace, Labels: map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		Expect(k8sClient.Create(ctx, p)).To(Succeed())
+		p.Status.Phase = v1.PodSucceeded
+		Expect(k8sClient.Status().Update(ctx, p)).To(Succeed())
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[0].Name, Namespace: aw.Namespace}, job)).To(Succeed())
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		job.Status.CompletionTime = &now
+		job.Status.Succeeded = 1
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: batchv1.JobSuccessCriteriaMet, Status: v1.ConditionTrue, Reason: batchv1.JobReasonCompletionsReached, LastTransitionTime: now},
+			{Type: batchv1.JobComplete, Status: v1.ConditionTrue, Reason: batchv1.JobReasonCompletionsReached, LastTransitionTime: now},
+		}
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		By("Cleanup the simulated pods")
+		for _, p := range getPods(aw) {
+			Expect(k8sClient.Delete(ctx, &p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+
+	It("The components gauge reflects the kinds of the deployed components", func() {
+		jobKey := []string{"batch/v1/Job", string(workloadv1beta2.AppWrapperRunning)}
+		depKey := []string{"apps/v1/Deployment", string(workloadv1beta2.AppWrapperRunning)}
+		jobsBefore := gaugeValue(metrics.AppWrapperComponentsGauge, jobKey...)
+		depsBefore := gaugeValue(metrics.AppWrapperComponentsGauge, depKey...)
+
+		advanceToResuming(batchJob(100), deployment(100))
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gaugeValue(metrics.AppWrapperComponentsGauge, jobKey...)).Should(Equal(jobsBefore + 1))
+		Expect(gaugeValue(metrics.AppWrapperComponentsGauge, depKey...)).Should(Equal(depsBefore + 1))
+
+		By("Suspending the AppWrapper removes its components from the Running count")
+		aw = getAppWrapper(awName)
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gaugeValue(metrics.AppWrapperComponentsGauge, jobKey...)).Should(Equal(jobsBefore))
+		Expect(gaugeValue(metrics.AppWrapperComponentsGauge, depKey...)).Should(Equal(depsBefore))
+	})
+
+	It("The quota reserved gauge reports the user and queue of AppWrappers holding quota", func() {
+		advanceToResuming(pod(100, 0, false))
+		awReconciler.Config.UserMetricsLabel = true
+		aw := getAppWrapper(awName)
+		aw.Labels = map[string]string{workloadv1beta2.UsernameLabel: "alice", "kueue.x-k8s.io/queue-name": "team-a"}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		quotaReservedKey := []string{aw.Namespace, "alice", "team-a"}
+		quotaReservedBefore := gaugeValue(metrics.AppWrapperQuotaReservedGauge, quotaReservedKey...)
+
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(gaugeValue(metrics.AppWrapperQuotaReservedGauge, quotaReservedKey...)).Should(Equal(quotaReservedBefore + 1))
+
+		By("Suspending the AppWrapper releases its quota and removes it from the quota reserved gauge")
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // Running -> Suspending
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // initiate deletion
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // see deletion has completed
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeFalse())
+		Expect(gaugeValue(metrics.AppWrapperQuotaReservedGauge, quotaReservedKey...)).Should(Equal(quotaReservedBefore))
+	})
+
+	It("Unlabeled Pods of a Deployment are attributed to the Deployment by their owner", func() {
+		advanceToResuming(batchJob(100), deployment(100))
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // Resuming -> Running
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		By("Simulating running pods created before the service component and PodSet labels were injected")
+		jobPod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: randName("job-pod"), Namespace: aw.Namespace, Labels: map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		depPod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      randName("dep-pod"),
+				Namespace: aw.Namespace,
+				Labels:    map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       aw.Status.ComponentStatus[1].Name + "-5d8f7c9b4",
+					UID:        types.UID(randName("rs-uid")),
+					Controller: ptr.To(true),
+				}},
+			},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+		}
+		for _, p := range []*v1.Pod{jobPod, depPod} {
+			Expect(k8sClient.Create(ctx, p)).To(Succeed())
+			p.Status.Phase = v1.PodRunning
+			Expect(k8sClient.Status().Update(ctx, p)).To(Succeed())
+		}
+		Expect(isServicePod(aw, depPod)).Should(BeTrue())
+		Expect(isServicePod(aw, jobPod)).Should(BeFalse())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getAppWrapper(awName).Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		By("Reconciling: Running -> Succeeded once the Job's pod completes")
+		jobPod.Status.Phase = v1.PodSucceeded
+		Expect(k8sClient.Status().Update(ctx, jobPod)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSucceeded))
+
+		By("Cleanup the simulated pods")
+		for _, p := range getPods(aw) {
+			Expect(k8sClient.Delete(ctx, &p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+
+	It("An AppWrapper containing only a Deployment never succeeds", func() {
+		advanceToResuming(deployment(100), deployment(100))
+
+		By("Reconciling: Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		By("Simulating the Deployment controller creating running pods")
+		for idx := range aw.Status.ComponentStatus {
+			dep := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[idx].Name, Namespace: aw.Namespace}, dep)).To(Succeed())
+			p := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: randName("dep-pod"), Namespace: aw.Namespace, Labels: dep.Spec.Template.Labels},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "busybox", Image: "quay.io/project-codeflare/busybox:1.36"}}},
+			}
+			Expect(k8sClient.Create(ctx, p)).To(Succeed())
+			Expect(setDeploymentRollout(types.NamespacedName{Name: dep.Name, Namespace: dep.Namespace}, dep.Generation)).To(Succeed())
+		}
+		Expect(setPodStatus(aw, v1.PodRunning, 2)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeTrue())
+
+		By("Cleanup the simulated pods")
+		for _, p := range getPods(aw) {
+			Expect(k8sClient.Delete(ctx, &p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+
+	It("Failure during resource creation leads to a failed AppWrapper", func() {
+		advanceToResuming(pod(100, 0, false), malformedPod(100))
+
+		By("Reconciling: Resuming -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(aw.Status.DeployedComponents).Should(Equal(int32(1)))
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeTrue())
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeFalse())
+		Expect((*workload.AppWrapper)(aw).IsActive()).Should(BeTrue())
+		Expect((*workload.AppWrapper)(aw).IsSuspended()).Should(BeFalse())
+		podStatus, err := awReconciler.getPodStatus(ctx, aw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podStatus.pending).Should(Equal(int32(1)))
+	})
+
+	It("Components are created concurrently when ComponentCreationConcurrency is set", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, false), service(), pod(100, 0, false), pod(100, 0, false))
+		awReconciler.Config.ComponentCreationConcurrency = 3
+		beginRunning()
+
+		aw := getAppWrapper(awName)
+		for _, cs := range aw.Status.ComponentStatus {
+			Expect(cs.Name).ShouldNot(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(cs.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
+		}
+		Expect(getPods(aw)).Should(HaveLen(4))
+	})
+
+	It("A fatal error during concurrent resource creation leads to a failed AppWrapper", func() {
+		advanceToResuming(pod(100, 0, false), malformedPod(100), pod(100, 0, false), pod(100, 0, false))
+		awReconciler.Config.ComponentCreationConcurrency = 4
+
+		By("Reconciling: Resuming -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("CreateFailed"))
+		Expect(meta.IsStatusConditionFalse(aw.Status.ComponentStatus[1].Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
+		Expect(aw.Status.DeployedComponents).Should(Equal(deployedComponentCount(aw)))
+	})
+
+	It("A component template that no longer parses leads to a failed AppWrapper", func() {
+		advanceToResuming(pod(100, 0, false))
+		beginRunning()
+
+		By("Corrupting the template of a running AppWrapper")
+		aw := getAppWrapper(awName)
+		obj := &unstructured.Unstructured{}
+		Expect(obj.UnmarshalJSON(aw.Spec.Components[0].Template.Raw)).To(Succeed())
+		obj.SetNamespace("corrupted-namespace")
+		raw, err := obj.MarshalJSON()
+		Expect(err).NotTo(HaveOccurred())
+		aw.Spec.Components[0].Template.Raw = raw
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+
+		By("Reconciling: Running -> Failed")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		unhealthy := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))
+		Expect(unhealthy.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(unhealthy.Reason).Should(Equal("MalformedComponent"))
+
+		By("Deleting the resources of the failed AppWrapper without errors")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // initiate deletion
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // see deletion has completed
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+	})
+
+	It("A component whose resource type was uninstalled does not wedge the AppWrapper", func() {
+		advanceToResuming(pod(100, 0, false))
+		beginRunning()
+		awReconciler.Config.FaultTolerance.ForcefulDeletionGracePeriod = 0
+
+		By("Simulating the uninstallation of the CRD of a deployed component")
+		aw := getAppWrapper(awName)
+		aw.Status.ComponentStatus[0].APIVersion = "uninstalled.example.com/v1"
+		aw.Status.ComponentStatus[0].Kind = "Uninstalled"
+		Expect(k8sClient.Status().Update(ctx, aw)).To(Succeed())
+		compStatus, err := awReconciler.getComponentStatus(ctx, aw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(compStatus.deployed).Should(Equal(int32(0)))
+
+		By("Reconciling: Running -> Failed")
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("MissingComponent"))
+
+		By("Deleting the resources of the failed AppWrapper")
+		Eventually(func() bool {
+			_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			return meta.IsStatusConditionTrue(getAppWrapper(awName).Status.Conditions, string(workloadv1beta2.ResourcesDeployed))
+		}).Should(BeFalse())
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.ComponentStatus[0].Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+	})
+
+	It("The FoundFailedPods event names the PodSets of the failed pods", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		beginRunning()
+		fullyRunning()
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+
+		By("Simulating the Pod of the second component failing")
+		aw := getAppWrapper(awName)
+		for _, p := range getPods(aw) {
+			Expect(p.Labels).Should(HaveKey(workloadv1beta2.PodSetLabel))
+			if p.Labels[workloadv1beta2.PodSetLabel] == utils.PodSetLabelValue(1, 0) {
+				p.Status.Phase = v1.PodFailed
+				Expect(k8sClient.Status().Update(ctx, &p)).To(Succeed())
+			}
+		}
+
+		By("Reconciling: Running -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(drainEvents(recorder)).Should(ContainElement(
+			Equal(v1.EventTypeNormal + " " + string(workloadv1beta2.Unhealthy) + " FoundFailedPods: 1 failed pods in podSets components[1].template")))
+	})
+
+	It("The Unhealthy condition of a failed AppWrapper describes the first failed container", func() {
+		advanceToResuming(pod(100, 0, false))
+		beginRunning()
+		fullyRunning()
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+
+		By("Simulating a container exiting with code 137 and a long termination message")
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		failed := pods[0]
+		failed.Status.Phase = v1.PodFailed
+		failed.Status.ContainerStatuses = []v1.ContainerStatus{{
+			Name: failed.Spec.Containers[0].Name,
+			State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+				ExitCode: 137,
+				Message:  "lost contact with rank 0 " + strings.Repeat("x", 1000),
+			}},
+		}}
+		Expect(k8sClient.Status().Update(ctx, &failed)).To(Succeed())
+
+		By("Reconciling: Running -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		cond := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy))
+		Expect(cond.Reason).Should(Equal("FoundFailedPods"))
+		Expect(cond.Message).Should(ContainSubstring(fmt.Sprintf("container %v of pod %v exited with code 137: lost contact with rank 0", failed.Spec.Containers[0].Name, failed.Name)))
+		Expect(len(cond.Message)).Should(BeNumerically("<", 512))
+		Expect(drainEvents(recorder)).Should(ContainElement(ContainSubstring("FoundFailedPods: 1 failed pods; container %v of pod %v exited with code 137", failed.Spec.Containers[0].Name, failed.Name)))
+	})
+
+	It("The terminal event of a failed AppWrapper includes a remediation hint", func() {
+		advanceToResuming(pod(100, 0, false), malformedPod(100))
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+
+		By("Reconciling: Resuming -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(drainEvents(recorder)).Should(ContainElement(And(
+			HavePrefix(v1.EventTypeWarning+" "+string(workloadv1beta2.AppWrapperFailed)+" CreateFailed: "),
+			ContainSubstring("(hint: "+remediationHints["CreateFailed"]+")"))))
+	})
+
+	It("An OOMKilled Pod leads to a failed AppWrapper with a memory remediation hint", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true))
+		beginRunning()
+		fullyRunning()
+		recorder := record.NewFakeRecorder(100)
+		awReconciler.Recorder = recorder
+
+		By("Simulating one Pod being OOMKilled")
+		aw := getAppWrapper(awName)
+		Expect(setPodOOMKilled(aw, 1)).To(Succeed())
+
+		By("Reconciling: Running -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("OOMKilled"))
+		Expect(drainEvents(recorder)).Should(ContainElement(And(
+			HavePrefix(v1.EventTypeWarning+" "+string(workloadv1beta2.AppWrapperFailed)+" OOMKilled: "),
+			ContainSubstring("(hint: "+remediationHints["OOMKilled"]+")"))))
+	})
+
+	It("Validating PodSet Injection invariants on minimal pods", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 1, true))
+		beginRunning()
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+
+		By("Validate expected markers and Autopilot anti-affinities were injected")
+		for _, p := range pods {
+			Expect(p.Labels).Should(HaveKeyWithValue(workloadv1beta2.AppWrapperLabel, awName.Name))
+			validateMarkers(&p)
+			validateAutopilot(&p)
+		}
+	})
+
+	It("Required pod labels are injected", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 1, true))
+		awReconciler.Config.RequiredPodLabels = map[string]string{"network-policy-group": "batch"}
+		beginRunning()
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		for _, p := range pods {
+			Expect(p.Labels).Should(HaveKeyWithValue("network-policy-group", "batch"))
+			Expect(p.Labels).Should(HaveKeyWithValue(workloadv1beta2.AppWrapperLabel, awName.Name))
+		}
+	})
+
+	It("Required pod labels that change after admission to conflict with the template lead to a failed AppWrapper", func() {
+		advanceToResuming(pod(100, 0, false), complexPodYaml())
+		awReconciler.Config.RequiredPodLabels = map[string]string{"myComplexLabel": "conflictingValue"}
+
+		By("Reconciling: Resuming -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+
+		aw := getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+		Expect(meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.Unhealthy)).Reason).Should(Equal("CreateFailed"))
+	})
+
+	It("Default priority class is injected only into templates that lack one", func() {
+		defaultPC := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: randName("default-pc")}, Value: 10}
+		templatePC := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: randName("template-pc")}, Value: 20}
+		Expect(k8sClient.Create(ctx, defaultPC)).To(Succeed())
+		Expect(k8sClient.Create(ctx, templatePC)).To(Succeed())
+
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true, func(p *v1.Pod) { p.Spec.PriorityClassName = templatePC.Name }))
+		awReconciler.Config.PriorityClassName = defaultPC.Name
+		beginRunning()
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		priorityClassNames := []string{pods[0].Spec.PriorityClassName, pods[1].Spec.PriorityClassName}
+		Expect(priorityClassNames).Should(ConsistOf(defaultPC.Name, templatePC.Name))
+
+		Expect(k8sClient.Delete(ctx, defaultPC)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, templatePC)).To(Succeed())
+	})
+
+	It("The schedulerName annotation overrides the configured schedulerName but not the template", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true, func(p *v1.Pod) { p.Spec.SchedulerName = "template-scheduler" }))
+		awReconciler.Config.SchedulerName = "config-scheduler"
+		aw := getAppWrapper(awName)
+		aw.Annotations = map[string]string{workloadv1beta2.SchedulerNameAnnotation: "annotation-scheduler"}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		beginRunning()
+		aw = getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		schedulerNames := []string{pods[0].Spec.SchedulerName, pods[1].Spec.SchedulerName}
+		Expect(schedulerNames).Should(ConsistOf("annotation-scheduler", "template-scheduler"))
+	})
+
+	It("The configured runtimeClassName is injected into pods that do not set one", func() {
+		for _, name := range []string{"config-runtime", "template-runtime"} {
+			rc := &nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: name}, Handler: name}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, rc))).To(Succeed())
+		}
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true, func(p *v1.Pod) { p.Spec.RuntimeClassName = ptr.To("template-runtime") }))
+		awReconciler.Config.RuntimeClassName = "config-runtime"
+		beginRunning()
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		runtimeClassNames := []*string{pods[0].Spec.RuntimeClassName, pods[1].Spec.RuntimeClassName}
+		Expect(runtimeClassNames).Should(ConsistOf(ptr.To("config-runtime"), ptr.To("template-runtime")))
+	})
 
-		By("Simulating one Pod Failing")
+	It("The configured runtimeClassName is only injected into pods that request a RuntimeClassResource", func() {
+		rc := &nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "config-runtime"}, Handler: "config-runtime"}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, rc))).To(Succeed())
+		advanceToResuming(pod(100, 0, false), pod(100, 1, false))
+		awReconciler.Config.RuntimeClassName = "config-runtime"
+		awReconciler.Config.RuntimeClassResources = []string{"nvidia.com/gpu"}
+		beginRunning()
 		aw := getAppWrapper(awName)
-		Expect(setPodStatus(aw, v1.PodFailed, 1)).To(Succeed())
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		runtimeClassNames := []*string{pods[0].Spec.RuntimeClassName, pods[1].Spec.RuntimeClassName}
+		Expect(runtimeClassNames).Should(ConsistOf(BeNil(), Equal(ptr.To("config-runtime"))))
+	})
 
-		By("Reconciling: Running -> Failed")
-		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) //  detect failure
-		Expect(err).NotTo(HaveOccurred())
+	It("The configured default serviceAccountName is injected into pods that do not set one", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true, func(p *v1.Pod) { p.Spec.ServiceAccountName = "template-account" }))
+		awReconciler.Config.DefaultServiceAccountName = "config-account"
+		beginRunning()
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		serviceAccountNames := []string{pods[0].Spec.ServiceAccountName, pods[1].Spec.ServiceAccountName}
+		Expect(serviceAccountNames).Should(ConsistOf("config-account", "template-account"))
+	})
+
+	It("AppWrapper labels and annotations with a propagated prefix are copied to wrapped resources", func() {
+		advanceToResuming(deployment(100))
+		awReconciler.Config.PropagatedMetadataPrefixes = []string{"cost.example.com/"}
+		aw := getAppWrapper(awName)
+		aw.Labels = map[string]string{"cost.example.com/center": "ml-research", "team": "vision"}
+		aw.Annotations = map[string]string{"cost.example.com/owner": "jdoe"}
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
 
+		By("Reconciling: Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
 		aw = getAppWrapper(awName)
-		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
-		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
-		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeTrue())
-		Expect((*workload.AppWrapper)(aw).IsActive()).Should(BeTrue())
-		Expect((*workload.AppWrapper)(aw).IsSuspended()).Should(BeFalse())
-		_, _, finished := (*workload.AppWrapper)(aw).Finished()
-		Expect(finished).Should(BeFalse())
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		dep := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: aw.Status.ComponentStatus[0].Name, Namespace: aw.Namespace}, dep)).To(Succeed())
+		Expect(dep.Labels).Should(HaveKeyWithValue("cost.example.com/center", "ml-research"))
+		Expect(dep.Labels).Should(HaveKeyWithValue(workloadv1beta2.AppWrapperLabel, aw.Name))
+		Expect(dep.Labels).ShouldNot(HaveKey("team"))
+		Expect(dep.Annotations).Should(HaveKeyWithValue("cost.example.com/owner", "jdoe"))
+		Expect(dep.Spec.Template.Labels).ShouldNot(HaveKey("cost.example.com/center"))
 
-		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // initiate deletion
+		By("Propagated labels do not override the labels of the template")
+		obj, err := RenderComponent(ctx, aw, 0, awReconciler.Config)
 		Expect(err).NotTo(HaveOccurred())
-		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName}) // see deletion has completed
+		obj.SetLabels(map[string]string{"cost.example.com/center": "from-template"})
+		raw, err := obj.MarshalJSON()
 		Expect(err).NotTo(HaveOccurred())
+		aw.Spec.Components[0].Template.Raw = raw
+		obj, err = RenderComponent(ctx, aw, 0, awReconciler.Config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.GetLabels()).Should(HaveKeyWithValue("cost.example.com/center", "from-template"))
+	})
 
-		aw = getAppWrapper(awName)
-		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
-
-		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
-		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeFalse())
-		Expect((*workload.AppWrapper)(aw).IsActive()).Should(BeFalse())
-		Expect((*workload.AppWrapper)(aw).IsSuspended()).Should(BeFalse())
-		_, _, finished = (*workload.AppWrapper)(aw).Finished()
-		Expect(finished).Should(BeTrue())
+	It("Configured observability annotations are injected into wrapped pods", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true, func(p *v1.Pod) { p.Annotations = map[string]string{"profiler.example.com/enabled": "false"} }))
+		awReconciler.Config.ObservabilityPodAnnotations = map[string]string{
+			"profiler.example.com/enabled": "true",
+			"logs.example.com/ship":        "true",
+		}
+		beginRunning()
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		// The annotation that is already present in the second pod's template is not overwritten
+		Expect([]map[string]string{pods[0].Annotations, pods[1].Annotations}).Should(ConsistOf(
+			And(HaveKeyWithValue("profiler.example.com/enabled", "true"), HaveKeyWithValue("logs.example.com/ship", "true")),
+			And(HaveKeyWithValue("profiler.example.com/enabled", "false"), HaveKeyWithValue("logs.example.com/ship", "true"))))
 	})
 
-	It("Failure during resource creation leads to a failed AppWrapper", func() {
-		advanceToResuming(pod(100, 0, false), malformedPod(100))
+	It("Configured imagePullSecrets are merged into wrapped pods", func() {
+		advanceToResuming(pod(100, 0, false), pod(100, 0, true, func(p *v1.Pod) { p.Spec.ImagePullSecrets = []v1.LocalObjectReference{{Name: "mirror-secret"}} }))
+		awReconciler.Config.ImagePullSecrets = []string{"airgap-secret", "mirror-secret"}
+		beginRunning()
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		// The pod without imagePullSecrets gets the configured list; the existing secret is not duplicated in the other pod
+		Expect([][]v1.LocalObjectReference{pods[0].Spec.ImagePullSecrets, pods[1].Spec.ImagePullSecrets}).Should(ConsistOf(
+			[]v1.LocalObjectReference{{Name: "airgap-secret"}, {Name: "mirror-secret"}},
+			[]v1.LocalObjectReference{{Name: "mirror-secret"}, {Name: "airgap-secret"}}))
+	})
 
-		By("Reconciling: Resuming -> Failed")
-		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
-		Expect(err).NotTo(HaveOccurred())
+	It("Autopilot anti-affinities are injected for GPU requests of init containers by default", func() {
+		advanceToResuming(pod(100, 0, true, withGPUInitContainer(1)))
+		beginRunning()
+		pods := getPods(getAppWrapper(awName))
+		Expect(pods).Should(HaveLen(1))
+		Expect(pods[0].Spec.Affinity).ShouldNot(BeNil())
+		Expect(pods[0].Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).Should(HaveLen(1))
+	})
 
-		aw := getAppWrapper(awName)
-		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
-		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeTrue())
-		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeTrue())
-		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.PodsReady))).Should(BeFalse())
-		Expect((*workload.AppWrapper)(aw).IsActive()).Should(BeTrue())
-		Expect((*workload.AppWrapper)(aw).IsSuspended()).Should(BeFalse())
-		podStatus, err := awReconciler.getPodStatus(ctx, aw)
-		Expect(err).NotTo(HaveOccurred())
-		Expect(podStatus.pending).Should(Equal(int32(1)))
+	It("Autopilot anti-affinities are not injected for GPU requests of init containers when they are ignored", func() {
+		advanceToResuming(pod(100, 0, true, withGPUInitContainer(1)))
+		awReconciler.Config.Autopilot.IgnoreInitContainers = true
+		beginRunning()
+		pods := getPods(getAppWrapper(awName))
+		Expect(pods).Should(HaveLen(1))
+		Expect(pods[0].Spec.Affinity).Should(BeNil())
 	})
 
-	It("Validating PodSet Injection invariants on minimal pods", func() {
-		advanceToResuming(pod(100, 0, false), pod(100, 1, true))
+	It("Configured topologySpreadConstraints are injected alongside Autopilot anti-affinities", func() {
+		advanceToResuming(pod(100, 1, false), pod(100, 1, true, func(p *v1.Pod) {
+			p.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{{TopologyKey: "topology.kubernetes.io/zone", MaxSkew: 5,
+				WhenUnsatisfiable: v1.ScheduleAnyway, LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "example"}}}}
+		}))
+		awReconciler.Config.TopologySpread = &config.TopologySpreadConfig{
+			InjectConstraints: true,
+			Constraints: []config.TopologySpreadConstraint{
+				{TopologyKey: "example.com/rack", MaxSkew: 1, WhenUnsatisfiable: v1.DoNotSchedule},
+				{TopologyKey: "topology.kubernetes.io/zone", MaxSkew: 2, WhenUnsatisfiable: v1.ScheduleAnyway},
+			},
+		}
 		beginRunning()
 		aw := getAppWrapper(awName)
 		pods := getPods(aw)
 		Expect(pods).Should(HaveLen(2))
 
-		By("Validate expected markers and Autopilot anti-affinities were injected")
 		for _, p := range pods {
-			Expect(p.Labels).Should(HaveKeyWithValue(workloadv1beta2.AppWrapperLabel, awName.Name))
 			validateMarkers(&p)
 			validateAutopilot(&p)
+			Expect(p.Spec.TopologySpreadConstraints).Should(HaveLen(2))
+			idx := slices.IndexFunc(p.Spec.TopologySpreadConstraints, func(tsc v1.TopologySpreadConstraint) bool { return tsc.TopologyKey == "example.com/rack" })
+			Expect(idx).ShouldNot(Equal(-1))
+			rack := p.Spec.TopologySpreadConstraints[idx]
+			Expect(rack.MaxSkew).Should(Equal(int32(1)))
+			Expect(rack.WhenUnsatisfiable).Should(Equal(v1.DoNotSchedule))
+			Expect(rack.LabelSelector.MatchLabels).Should(HaveKeyWithValue(workloadv1beta2.AppWrapperLabel, aw.Name))
+			Expect(rack.LabelSelector.MatchLabels).Should(HaveKeyWithValue(workloadv1beta2.PodSetLabel, p.Labels[workloadv1beta2.PodSetLabel]))
+		}
+
+		By("Pre-existing constraints are preserved and take precedence")
+		zoneConstraints := []v1.TopologySpreadConstraint{}
+		for _, p := range pods {
+			for _, tsc := range p.Spec.TopologySpreadConstraints {
+				if tsc.TopologyKey == "topology.kubernetes.io/zone" {
+					zoneConstraints = append(zoneConstraints, tsc)
+				}
+			}
+		}
+		Expect(zoneConstraints).Should(HaveLen(2))
+		Expect([]int32{zoneConstraints[0].MaxSkew, zoneConstraints[1].MaxSkew}).Should(ConsistOf(int32(2), int32(5)))
+		for _, tsc := range zoneConstraints {
+			if tsc.MaxSkew == 5 {
+				Expect(tsc.LabelSelector.MatchLabels).Should(Equal(map[string]string{"app": "example"}))
+			}
 		}
 	})
 
@@ -410,6 +3020,82 @@ var _ = Describe("AppWrapper Controller", func() {
 			Expect(found).Should(BeTrue())
 		}
 	})
+
+	It("RenderComponent matches the objects created by the controller", func() {
+		advanceToResuming(complexPodYaml(), complexPodYaml())
+		beginRunning()
+		aw := getAppWrapper(awName)
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(2))
+		created := map[string]v1.Pod{}
+		for _, p := range pods {
+			created[p.Name] = p
+		}
+
+		for idx := range aw.Spec.Components {
+			obj, err := RenderComponent(ctx, aw, idx, awReconciler.Config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.GetOwnerReferences()).Should(BeEmpty())
+			rendered := &v1.Pod{}
+			Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), rendered)).To(Succeed())
+			p, ok := created[rendered.Name]
+			Expect(ok).Should(BeTrue())
+			Expect(p.Labels).Should(Equal(rendered.Labels))
+			Expect(p.Annotations).Should(Equal(rendered.Annotations))
+			Expect(p.Spec.NodeSelector).Should(Equal(rendered.Spec.NodeSelector))
+			Expect(p.Spec.Tolerations).Should(ContainElements(rendered.Spec.Tolerations))
+			Expect(p.Spec.SchedulingGates).Should(Equal(rendered.Spec.SchedulingGates))
+			Expect(p.Spec.Affinity).Should(Equal(rendered.Spec.Affinity))
+			Expect(p.Spec.SchedulerName).Should(Equal(rendered.Spec.SchedulerName))
+			Expect(p.Spec.PriorityClassName).Should(Equal(rendered.Spec.PriorityClassName))
+		}
+	})
+
+	It("RenderComponent injects imagePullSecrets into a template whose imagePullSecrets are null", func() {
+		aw := toAppWrapper(pod(100, 0, false))
+		template := map[string]interface{}{}
+		Expect(json.Unmarshal(aw.Spec.Components[0].Template.Raw, &template)).To(Succeed())
+		template["spec"].(map[string]interface{})["imagePullSecrets"] = nil
+		raw, err := json.Marshal(template)
+		Expect(err).NotTo(HaveOccurred())
+		aw.Spec.Components[0].Template.Raw = raw
+		Expect((*workload.AppWrapper)(aw).RunWithPodSetsInfo([]podset.PodSetInfo{{}})).To(Succeed())
+		awConfig := config.NewAppWrapperConfig()
+		awConfig.ImagePullSecrets = []string{"airgap-secret"}
+		obj, err := RenderComponent(ctx, aw, 0, awConfig)
+		Expect(err).NotTo(HaveOccurred())
+		rendered := &v1.Pod{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), rendered)).To(Succeed())
+		Expect(rendered.Spec.ImagePullSecrets).Should(Equal([]v1.LocalObjectReference{{Name: "airgap-secret"}}))
+	})
+
+	It("RenderComponent injects topologySpreadConstraints into a template whose topologySpreadConstraints are null", func() {
+		aw := toAppWrapper(pod(100, 0, false))
+		template := map[string]interface{}{}
+		Expect(json.Unmarshal(aw.Spec.Components[0].Template.Raw, &template)).To(Succeed())
+		template["spec"].(map[string]interface{})["topologySpreadConstraints"] = nil
+		raw, err := json.Marshal(template)
+		Expect(err).NotTo(HaveOccurred())
+		aw.Spec.Components[0].Template.Raw = raw
+		Expect((*workload.AppWrapper)(aw).RunWithPodSetsInfo([]podset.PodSetInfo{{}})).To(Succeed())
+		awConfig := config.NewAppWrapperConfig()
+		awConfig.TopologySpread = &config.TopologySpreadConfig{
+			InjectConstraints: true,
+			Constraints:       []config.TopologySpreadConstraint{{TopologyKey: "example.com/rack", MaxSkew: 1, WhenUnsatisfiable: v1.DoNotSchedule}},
+		}
+		obj, err := RenderComponent(ctx, aw, 0, awConfig)
+		Expect(err).NotTo(HaveOccurred())
+		rendered := &v1.Pod{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), rendered)).To(Succeed())
+		Expect(rendered.Spec.TopologySpreadConstraints).Should(HaveLen(1))
+		Expect(rendered.Spec.TopologySpreadConstraints[0].TopologyKey).Should(Equal("example.com/rack"))
+	})
+
+	It("RenderComponent rejects out of range component indices", func() {
+		aw := toAppWrapper(pod(100, 0, false))
+		_, err := RenderComponent(ctx, aw, 1, awReconciler.Config)
+		Expect(err).To(HaveOccurred())
+	})
 })
 
 var _ = Describe("AppWrapper Annotations", func() {
@@ -427,13 +3113,25 @@ var _ = Describe("AppWrapper Annotations", func() {
 	It("Unannotated appwrappers use defaults", func() {
 		aw := &workloadv1beta2.AppWrapper{}
 		Expect(awReconciler.admissionGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.AdmissionGracePeriod))
-		Expect(awReconciler.warmupGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.WarmupGracePeriod))
+		Expect(awReconciler.warmupGraceDuration(ctx, aw, nil)).Should(Equal(awReconciler.Config.FaultTolerance.WarmupGracePeriod))
 		Expect(awReconciler.failureGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.FailureGracePeriod))
 		Expect(awReconciler.retryLimit(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.RetryLimit))
+		Expect(awReconciler.failedPodDeletionLimit(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.FailedPodDeletionLimit))
 		Expect(awReconciler.retryPauseDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.RetryPausePeriod))
 		Expect(awReconciler.forcefulDeletionGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.ForcefulDeletionGracePeriod))
+		Expect(awReconciler.successDeletionGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.SuccessDeletionGracePeriod))
 		Expect(awReconciler.deletionOnFailureGraceDuration(ctx, aw)).Should(Equal(0 * time.Second))
+		Expect(awReconciler.migrationDrainDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.MigrationDrainPeriod))
+		Expect(awReconciler.minimumRunningDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.MinimumRunningDuration))
 		Expect(awReconciler.timeToLiveAfterSucceededDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.SuccessTTL))
+		Expect(awReconciler.timeToLiveAfterFailedDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.FailedTTL))
+		Expect(awReconciler.activeDeadlineDuration(ctx, aw)).Should(Equal(0 * time.Second))
+		Expect(awReconciler.priorityClassName(ctx, aw)).Should(Equal(awReconciler.Config.PriorityClassName))
+		Expect(awReconciler.schedulerName(ctx, aw)).Should(Equal(awReconciler.Config.SchedulerName))
+		Expect(awReconciler.forcefulDeletionDisabled(ctx, aw)).Should(BeFalse())
+		Expect(awReconciler.successPolicy(ctx, aw, 10)).Should(Equal(int32(0)))
+		Expect(awReconciler.deletionPropagation(ctx, aw)).Should(Equal(metav1.DeletePropagationBackground))
+		Expect(awReconciler.reconcilePaused(ctx, aw)).Should(BeFalse())
 	})
 
 	It("Valid annotations override defaults", func() {
@@ -446,20 +3144,40 @@ var _ = Describe("AppWrapper Annotations", func() {
 					workloadv1beta2.FailureGracePeriodDurationAnnotation:   allowed.String(),
 					workloadv1beta2.RetryPausePeriodDurationAnnotation:     allowed.String(),
 					workloadv1beta2.RetryLimitAnnotation:                   "101",
+					workloadv1beta2.FailedPodDeletionLimitAnnotation:       "3",
 					workloadv1beta2.ForcefulDeletionGracePeriodAnnotation:  allowed.String(),
+					workloadv1beta2.SuccessDeletionGracePeriodAnnotation:   allowed.String(),
 					workloadv1beta2.DeletionOnFailureGracePeriodAnnotation: allowed.String(),
 					workloadv1beta2.SuccessTTLAnnotation:                   allowed.String(),
+					workloadv1beta2.FailedTTLAnnotation:                    allowed.String(),
+					workloadv1beta2.ActiveDeadlineSecondsAnnotation:        "10",
+					workloadv1beta2.PriorityClassNameAnnotation:            "high-priority",
+					workloadv1beta2.NoForcefulDeletionAnnotation:           "true",
+					workloadv1beta2.SchedulerNameAnnotation:                "gang-scheduler",
+					workloadv1beta2.MigrationDrainPeriodAnnotation:         allowed.String(),
+					workloadv1beta2.MinimumRunningDurationAnnotation:       allowed.String(),
+					workloadv1beta2.DeletionPropagationAnnotation:          string(metav1.DeletePropagationForeground),
 				},
 			},
 		}
 		Expect(awReconciler.admissionGraceDuration(ctx, aw)).Should(Equal(allowed))
-		Expect(awReconciler.warmupGraceDuration(ctx, aw)).Should(Equal(allowed))
+		Expect(awReconciler.warmupGraceDuration(ctx, aw, nil)).Should(Equal(allowed))
 		Expect(awReconciler.failureGraceDuration(ctx, aw)).Should(Equal(allowed))
 		Expect(awReconciler.retryLimit(ctx, aw)).Should(Equal(int32(101)))
+		Expect(awReconciler.failedPodDeletionLimit(ctx, aw)).Should(Equal(int32(3)))
 		Expect(awReconciler.retryPauseDuration(ctx, aw)).Should(Equal(allowed))
 		Expect(awReconciler.forcefulDeletionGraceDuration(ctx, aw)).Should(Equal(allowed))
+		Expect(awReconciler.successDeletionGraceDuration(ctx, aw)).Should(Equal(allowed))
 		Expect(awReconciler.deletionOnFailureGraceDuration(ctx, aw)).Should(Equal(allowed))
+		Expect(awReconciler.migrationDrainDuration(ctx, aw)).Should(Equal(allowed))
+		Expect(awReconciler.minimumRunningDuration(ctx, aw)).Should(Equal(allowed))
 		Expect(awReconciler.timeToLiveAfterSucceededDuration(ctx, aw)).Should(Equal(allowed))
+		Expect(awReconciler.timeToLiveAfterFailedDuration(ctx, aw)).Should(Equal(allowed))
+		Expect(awReconciler.activeDeadlineDuration(ctx, aw)).Should(Equal(allowed))
+		Expect(awReconciler.priorityClassName(ctx, aw)).Should(Equal("high-priority"))
+		Expect(awReconciler.forcefulDeletionDisabled(ctx, aw)).Should(BeTrue())
+		Expect(awReconciler.schedulerName(ctx, aw)).Should(Equal("gang-scheduler"))
+		Expect(awReconciler.deletionPropagation(ctx, aw)).Should(Equal(metav1.DeletePropagationForeground))
 	})
 
 	It("Malformed annotations use defaults", func() {
@@ -472,20 +3190,36 @@ var _ = Describe("AppWrapper Annotations", func() {
 					workloadv1beta2.FailureGracePeriodDurationAnnotation:   malformed,
 					workloadv1beta2.RetryPausePeriodDurationAnnotation:     malformed,
 					workloadv1beta2.RetryLimitAnnotation:                   "abc",
+					workloadv1beta2.FailedPodDeletionLimitAnnotation:       "abc",
 					workloadv1beta2.ForcefulDeletionGracePeriodAnnotation:  malformed,
+					workloadv1beta2.SuccessDeletionGracePeriodAnnotation:   malformed,
 					workloadv1beta2.DeletionOnFailureGracePeriodAnnotation: malformed,
 					workloadv1beta2.SuccessTTLAnnotation:                   malformed,
+					workloadv1beta2.FailedTTLAnnotation:                    malformed,
+					workloadv1beta2.ActiveDeadlineSecondsAnnotation:        malformed,
+					workloadv1beta2.NoForcefulDeletionAnnotation:           malformed,
+					workloadv1beta2.MigrationDrainPeriodAnnotation:         malformed,
+					workloadv1beta2.MinimumRunningDurationAnnotation:       malformed,
+					workloadv1beta2.DeletionPropagationAnnotation:          malformed,
 				},
 			},
 		}
 		Expect(awReconciler.admissionGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.AdmissionGracePeriod))
-		Expect(awReconciler.warmupGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.WarmupGracePeriod))
+		Expect(awReconciler.warmupGraceDuration(ctx, aw, nil)).Should(Equal(awReconciler.Config.FaultTolerance.WarmupGracePeriod))
 		Expect(awReconciler.failureGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.FailureGracePeriod))
 		Expect(awReconciler.retryLimit(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.RetryLimit))
+		Expect(awReconciler.failedPodDeletionLimit(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.FailedPodDeletionLimit))
 		Expect(awReconciler.retryPauseDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.RetryPausePeriod))
 		Expect(awReconciler.forcefulDeletionGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.ForcefulDeletionGracePeriod))
+		Expect(awReconciler.successDeletionGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.SuccessDeletionGracePeriod))
 		Expect(awReconciler.deletionOnFailureGraceDuration(ctx, aw)).Should(Equal(0 * time.Second))
+		Expect(awReconciler.migrationDrainDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.MigrationDrainPeriod))
+		Expect(awReconciler.minimumRunningDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.MinimumRunningDuration))
 		Expect(awReconciler.timeToLiveAfterSucceededDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.SuccessTTL))
+		Expect(awReconciler.timeToLiveAfterFailedDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.FailedTTL))
+		Expect(awReconciler.activeDeadlineDuration(ctx, aw)).Should(Equal(0 * time.Second))
+		Expect(awReconciler.forcefulDeletionDisabled(ctx, aw)).Should(BeFalse())
+		Expect(awReconciler.deletionPropagation(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.DeletionPropagationPolicy))
 	})
 
 	It("Out of bounds annotations are clipped", func() {
@@ -501,18 +3235,58 @@ var _ = Describe("AppWrapper Annotations", func() {
 					workloadv1beta2.ForcefulDeletionGracePeriodAnnotation:  tooLong.String(),
 					workloadv1beta2.DeletionOnFailureGracePeriodAnnotation: tooLong.String(),
 					workloadv1beta2.SuccessTTLAnnotation:                   (awReconciler.Config.FaultTolerance.SuccessTTL + 10*time.Second).String(),
+					workloadv1beta2.FailedTTLAnnotation:                    negative.String(),
+					workloadv1beta2.ActiveDeadlineSecondsAnnotation:        fmt.Sprintf("%v", int(tooLong.Seconds())),
+					workloadv1beta2.MigrationDrainPeriodAnnotation:         tooLong.String(),
+					workloadv1beta2.MinimumRunningDurationAnnotation:       tooLong.String(),
 				},
 			},
 		}
 		Expect(awReconciler.admissionGraceDuration(ctx, aw)).Should(Equal(0 * time.Second))
-		Expect(awReconciler.warmupGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.GracePeriodMaximum))
+		Expect(awReconciler.warmupGraceDuration(ctx, aw, nil)).Should(Equal(awReconciler.Config.FaultTolerance.GracePeriodMaximum))
 		Expect(awReconciler.failureGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.GracePeriodMaximum))
 		Expect(awReconciler.retryPauseDuration(ctx, aw)).Should(Equal(0 * time.Second))
 		Expect(awReconciler.forcefulDeletionGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.GracePeriodMaximum))
 		Expect(awReconciler.deletionOnFailureGraceDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.GracePeriodMaximum))
+		Expect(awReconciler.migrationDrainDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.GracePeriodMaximum))
+		Expect(awReconciler.minimumRunningDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.GracePeriodMaximum))
 		Expect(awReconciler.timeToLiveAfterSucceededDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.SuccessTTL))
+		Expect(awReconciler.timeToLiveAfterFailedDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.FailedTTL))
+		Expect(awReconciler.activeDeadlineDuration(ctx, aw)).Should(Equal(awReconciler.Config.FaultTolerance.GracePeriodMaximum))
 	})
 
+	DescribeTable("SuccessPolicy annotations are parsed and clamped",
+		func(value string, expected int32) {
+			aw := &workloadv1beta2.AppWrapper{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{workloadv1beta2.SuccessPolicyAnnotation: value}},
+			}
+			Expect(awReconciler.successPolicy(ctx, aw, 10)).Should(Equal(expected))
+		},
+		Entry("count", "8", int32(8)),
+		Entry("count above expected", "25", int32(10)),
+		Entry("zero count", "0", int32(1)),
+		Entry("negative count", "-3", int32(1)),
+		Entry("percentage", "75%", int32(8)),
+		Entry("percentage above 100", "150%", int32(10)),
+		Entry("zero percentage", "0%", int32(1)),
+		Entry("malformed count", "most", int32(0)),
+		Entry("malformed percentage", "x%", int32(0)),
+	)
+
+	DescribeTable("Retry pause backoff",
+		func(factor float64, retries int32, expected time.Duration) {
+			Expect(backoffDuration(10*time.Second, factor, retries, 5*time.Minute)).Should(Equal(expected))
+		},
+		Entry("no backoff at zero retries", 2.0, int32(0), 10*time.Second),
+		Entry("no backoff at first retry", 2.0, int32(1), 10*time.Second),
+		Entry("doubles at second retry", 2.0, int32(2), 20*time.Second),
+		Entry("doubles again at third retry", 2.0, int32(3), 40*time.Second),
+		Entry("fractional factor", 1.5, int32(3), 22500*time.Millisecond),
+		Entry("clipped to maximum", 2.0, int32(10), 5*time.Minute),
+		Entry("clipped without overflow", 2.0, int32(1000), 5*time.Minute),
+		Entry("factor of 1.0 is constant", 1.0, int32(5), 10*time.Second),
+	)
+
 	It("Parsing of terminal exits codes", func() {
 		aw := &workloadv1beta2.AppWrapper{
 			ObjectMeta: metav1.ObjectMeta{
@@ -526,3 +3300,330 @@ var _ = Describe("AppWrapper Annotations", func() {
 		Expect(awReconciler.retryableExitCodes(ctx, aw)).Should(Equal([]int{10, 20}))
 	})
 })
+
+var _ = Describe("AppWrapper Status Summary", func() {
+	condition := func(condType workloadv1beta2.AppWrapperCondition, status metav1.ConditionStatus, reason string, message string) metav1.Condition {
+		return metav1.Condition{Type: string(condType), Status: status, Reason: reason, Message: message}
+	}
+
+	DescribeTable("Healthy and Summary are derived from the Phase and Conditions",
+		func(phase workloadv1beta2.AppWrapperPhase, conditions []metav1.Condition, healthy bool, summary string) {
+			status := &workloadv1beta2.AppWrapperStatus{Phase: phase, Conditions: conditions}
+			summarizeStatus(status)
+			Expect(status.Healthy).Should(Equal(healthy))
+			Expect(status.Summary).Should(Equal(summary))
+		},
+		Entry("suspended", workloadv1beta2.AppWrapperSuspended,
+			[]metav1.Condition{condition(workloadv1beta2.QuotaReserved, metav1.ConditionFalse, "Suspended", "")},
+			true, "Waiting for quota to be reserved"),
+		Entry("resuming with a pending deployment", workloadv1beta2.AppWrapperResuming,
+			[]metav1.Condition{
+				condition(workloadv1beta2.QuotaReserved, metav1.ConditionTrue, "Resuming", ""),
+				condition(workloadv1beta2.DeploymentPending, metav1.ConditionTrue, "CreateFailed", "quota exceeded"),
+			},
+			true, "Deploying resources; retrying CreateFailed"),
+		Entry("running with pods not yet ready", workloadv1beta2.AppWrapperRunning,
+			[]metav1.Condition{
+				condition(workloadv1beta2.ResourcesDeployed, metav1.ConditionTrue, "Running", ""),
+				condition(workloadv1beta2.PodsReady, metav1.ConditionFalse, "InsufficientPodsReady", ""),
+			},
+			true, "Running; waiting for pods to be ready"),
+		Entry("running with all pods ready", workloadv1beta2.AppWrapperRunning,
+			[]metav1.Condition{
+				condition(workloadv1beta2.ResourcesDeployed, metav1.ConditionTrue, "Running", ""),
+				condition(workloadv1beta2.PodsReady, metav1.ConditionTrue, "PodsReady", ""),
+				condition(workloadv1beta2.Unhealthy, metav1.ConditionFalse, "Running", ""),
+			},
+			true, "Running with all pods ready"),
+		Entry("running within the grace period of a failed pod", workloadv1beta2.AppWrapperRunning,
+			[]metav1.Condition{
+				condition(workloadv1beta2.ResourcesDeployed, metav1.ConditionTrue, "Running", ""),
+				condition(workloadv1beta2.PodsReady, metav1.ConditionTrue, "PodsReady", ""),
+				condition(workloadv1beta2.Unhealthy, metav1.ConditionTrue, "FoundFailedPods", "1 pods failed"),
+			},
+			false, "Running but unhealthy (FoundFailedPods: 1 pods failed)"),
+		Entry("resetting", workloadv1beta2.AppWrapperResetting,
+			[]metav1.Condition{condition(workloadv1beta2.Unhealthy, metav1.ConditionTrue, "InsufficientPodsReady", "")},
+			false, "Resetting after a failure (InsufficientPodsReady)"),
+		Entry("suspending", workloadv1beta2.AppWrapperSuspending,
+			[]metav1.Condition{condition(workloadv1beta2.ResourcesDeployed, metav1.ConditionTrue, "Running", "")},
+			true, "Suspending; deleting resources"),
+		Entry("succeeded with resources deployed", workloadv1beta2.AppWrapperSucceeded,
+			[]metav1.Condition{condition(workloadv1beta2.ResourcesDeployed, metav1.ConditionTrue, "Succeeded", "")},
+			true, "Succeeded"),
+		Entry("succeeded with resources deleted", workloadv1beta2.AppWrapperSucceeded,
+			[]metav1.Condition{condition(workloadv1beta2.ResourcesDeployed, metav1.ConditionFalse, "Succeeded", "")},
+			true, "Succeeded; resources deleted"),
+		Entry("failed with resources deployed", workloadv1beta2.AppWrapperFailed,
+			[]metav1.Condition{
+				condition(workloadv1beta2.ResourcesDeployed, metav1.ConditionTrue, "Failed", ""),
+				condition(workloadv1beta2.Unhealthy, metav1.ConditionTrue, "MissingComponent", "Only found 1 deployed components, but was expecting 2"),
+			},
+			false, "Failed (MissingComponent: Only found 1 deployed components, but was expecting 2); resources not yet deleted"),
+		Entry("failed with resources deleted", workloadv1beta2.AppWrapperFailed,
+			[]metav1.Condition{condition(workloadv1beta2.ResourcesDeployed, metav1.ConditionFalse, "Failed", "")},
+			false, "Failed"),
+		Entry("terminating", workloadv1beta2.AppWrapperTerminating, nil,
+			true, "Terminating; deleting resources"),
+	)
+})
+
+var _ = Describe("AppWrapper Deletion Policy", func() {
+	DescribeTable("Orphaned components survive deletion of the AppWrapper", func(annotations map[string]string) {
+		awReconciler := &AppWrapperReconciler{
+			Client:   k8sClient,
+			Recorder: &record.FakeRecorder{},
+			Scheme:   k8sClient.Scheme(),
+			Config:   config.NewAppWrapperConfig(),
+		}
+
+		By("Create an AppWrapper with an orphan deletion policy")
+		aw := toAppWrapper(pod(100, 0, false))
+		aw.Spec.Suspend = true
+		aw.Annotations = map[string]string{workloadv1beta2.DeletionPolicyAnnotation: workloadv1beta2.DeletionPolicyOrphan}
+		for k, v := range annotations {
+			aw.Annotations[k] = v
+		}
+		Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+		awName := types.NamespacedName{Name: aw.Name, Namespace: aw.Namespace}
+
+		By("Reconciling: Empty -> Suspended -> Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect((*workload.AppWrapper)(aw).RunWithPodSetsInfo([]podset.PodSetInfo{{}})).To(Succeed())
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		pods := getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		Expect(pods[0].OwnerReferences).ShouldNot(BeEmpty())
+
+		By("Adding the ownerReference of another controller")
+		otherOwner := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "other-owner", UID: types.UID("other-owner-uid")}
+		pods[0].OwnerReferences = append(pods[0].OwnerReferences, otherOwner)
+		Expect(k8sClient.Update(ctx, &pods[0])).To(Succeed())
+
+		By("Deleting the AppWrapper")
+		Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, awName, &workloadv1beta2.AppWrapper{}))).Should(BeTrue())
+
+		By("Validating the wrapped Pod was orphaned")
+		pods = getPods(aw)
+		Expect(pods).Should(HaveLen(1))
+		Expect(pods[0].DeletionTimestamp.IsZero()).Should(BeTrue())
+		Expect(pods[0].OwnerReferences).Should(Equal([]metav1.OwnerReference{otherOwner}))
+
+		By("Cleanup the orphaned Pod")
+		Expect(k8sClient.Delete(ctx, &pods[0], client.GracePeriodSeconds(0))).To(Succeed())
+	},
+		Entry("with the default deletion propagation", nil),
+		Entry("with a deletion propagation annotation, which the orphan deletion policy takes precedence over",
+			map[string]string{workloadv1beta2.DeletionPropagationAnnotation: string(metav1.DeletePropagationForeground)}),
+	)
+})
+
+var _ = Describe("AppWrapper Failed TTL", func() {
+	It("A failed AppWrapper is deleted after its FailedTTL", func() {
+		awReconciler := &AppWrapperReconciler{
+			Client:   k8sClient,
+			Recorder: &record.FakeRecorder{},
+			Scheme:   k8sClient.Scheme(),
+			Config:   config.NewAppWrapperConfig(),
+		}
+		awReconciler.Config.FaultTolerance.RetryLimit = 0
+
+		By("Create an AppWrapper with a FailedTTL whose only component cannot be created")
+		aw := toAppWrapper(malformedPod(100))
+		aw.Spec.Suspend = true
+		aw.Annotations = map[string]string{workloadv1beta2.FailedTTLAnnotation: "3s"}
+		Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+		awName := types.NamespacedName{Name: aw.Name, Namespace: aw.Namespace}
+
+		By("Reconciling: Empty -> Suspended -> Resuming -> Failed")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect((*workload.AppWrapper)(aw).RunWithPodSetsInfo([]podset.PodSetInfo{{}})).To(Succeed())
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperFailed))
+
+		By("Reconciling: Failed AppWrapper is retained until its FailedTTL expires")
+		result, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).Should(BeNumerically(">", 0))
+		aw = getAppWrapper(awName)
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.ResourcesDeployed))).Should(BeFalse())
+		Expect(meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.QuotaReserved))).Should(BeFalse())
+
+		By("Reconciling: Failed AppWrapper is deleted once its FailedTTL expires")
+		Eventually(func() bool {
+			_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+			return apierrors.IsNotFound(k8sClient.Get(ctx, awName, &workloadv1beta2.AppWrapper{}))
+		}, 10*time.Second, 500*time.Millisecond).Should(BeTrue())
+	})
+})
+
+var _ = Describe("AppWrapper Events", func() {
+	It("Phase transitions of a happy path lifecycle are recorded as events", func() {
+		recorder := record.NewFakeRecorder(100)
+		awReconciler := &AppWrapperReconciler{
+			Client:   k8sClient,
+			Recorder: recorder,
+			Scheme:   k8sClient.Scheme(),
+			Config:   config.NewAppWrapperConfig(),
+		}
+
+		By("Create an AppWrapper")
+		aw := toAppWrapper(pod(100, 0, false))
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+		awName := types.NamespacedName{Name: aw.Name, Namespace: aw.Namespace}
+
+		By("Reconciling: Empty -> Suspended -> Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect((*workload.AppWrapper)(aw).RunWithPodSetsInfo([]podset.PodSetInfo{{}})).To(Succeed())
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+
+		By("Reconciling: Running -> Running -> Succeeded")
+		Expect(setPodStatus(aw, v1.PodRunning, 1)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(setPodStatus(aw, v1.PodSucceeded, 1)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperSucceeded))
+
+		By("Validating the sequence of events")
+		reasons := []string{}
+		for _, e := range drainEvents(recorder) {
+			fields := strings.Fields(e)
+			Expect(fields[0]).Should(Equal(v1.EventTypeNormal))
+			reasons = append(reasons, fields[1])
+		}
+		Expect(reasons).Should(Equal([]string{
+			string(workloadv1beta2.AppWrapperSuspended),
+			string(workloadv1beta2.AppWrapperResuming),
+			string(workloadv1beta2.AppWrapperRunning),
+			string(workloadv1beta2.AppWrapperSucceeded),
+		}))
+
+		By("Cleanup the AppWrapper")
+		Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		for _, p := range getPods(aw) {
+			Expect(k8sClient.Delete(ctx, &p, client.GracePeriodSeconds(0))).To(Succeed())
+		}
+	})
+})
+
+var _ = Describe("AppWrapper Label Prefix", func() {
+	It("A non-default LabelPrefix is used for the finalizer and the labels of created Pods", func() {
+		awConfig := config.NewAppWrapperConfig()
+		awConfig.LabelPrefix = "tenant.example.com"
+		awReconciler := &AppWrapperReconciler{
+			Client:   k8sClient,
+			Recorder: &record.FakeRecorder{},
+			Scheme:   k8sClient.Scheme(),
+			Config:   awConfig,
+		}
+
+		By("Create an AppWrapper")
+		aw := toAppWrapper(pod(100, 0, false))
+		aw.Spec.Suspend = true
+		Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+		awName := types.NamespacedName{Name: aw.Name, Namespace: aw.Namespace}
+
+		By("Reconciling: Empty -> Suspended -> Resuming -> Running")
+		_, err := awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect((*workload.AppWrapper)(aw).RunWithPodSetsInfo([]podset.PodSetInfo{{}})).To(Succeed())
+		Expect(k8sClient.Update(ctx, aw)).To(Succeed())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+		Expect(err).NotTo(HaveOccurred())
+		aw = getAppWrapper(awName)
+		Expect(aw.Status.Phase).Should(Equal(workloadv1beta2.AppWrapperRunning))
+		Expect(aw.Finalizers).Should(ContainElement("tenant.example.com/finalizer"))
+		Expect(aw.Finalizers).ShouldNot(ContainElement(AppWrapperFinalizer))
+
+		By("Validating the created Pod is labeled with the non-default prefix")
+		pods := &v1.PodList{}
+		Expect(k8sClient.List(ctx, pods, client.InNamespace(aw.Namespace), client.MatchingLabels{"tenant.example.com/appwrapper": aw.Name})).To(Succeed())
+		Expect(pods.Items).Should(HaveLen(1))
+		Expect(pods.Items[0].Labels).ShouldNot(HaveKey(workloadv1beta2.AppWrapperLabel))
+		podStatus, err := awReconciler.getPodStatus(ctx, aw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(podStatus.pending).Should(Equal(int32(1)))
+
+		By("Finalizers derived from other non-default prefixes and third-party finalizers are not reported")
+		Expect(ValidateFinalizerPrefix(ctx, k8sClient, awConfig)).To(Succeed())
+		Expect(ValidateFinalizerPrefix(ctx, k8sClient, config.NewAppWrapperConfig())).To(Succeed())
+		otherConfig := config.NewAppWrapperConfig()
+		otherConfig.LabelPrefix = "other.example.com"
+		Expect(ValidateFinalizerPrefix(ctx, k8sClient, otherConfig)).To(Succeed())
+		thirdParty := toAppWrapper(pod(100, 0, false))
+		thirdParty.Spec.Suspend = true
+		thirdParty.Finalizers = []string{"example.com/finalizer"}
+		Expect(k8sClient.Create(ctx, thirdParty)).To(Succeed())
+		Expect(ValidateFinalizerPrefix(ctx, k8sClient, awConfig)).To(Succeed())
+
+		By("An AppWrapper with only the default finalizer is reported with a non-default prefix")
+		thirdParty.Finalizers = []string{"example.com/finalizer", AppWrapperFinalizer}
+		Expect(k8sClient.Update(ctx, thirdParty)).To(Succeed())
+		Expect(ValidateFinalizerPrefix(ctx, k8sClient, awConfig)).ShouldNot(Succeed())
+		Expect(ValidateFinalizerPrefix(ctx, k8sClient, config.NewAppWrapperConfig())).To(Succeed())
+		thirdParty.Finalizers = nil
+		Expect(k8sClient.Update(ctx, thirdParty)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, thirdParty)).To(Succeed())
+
+		By("Deleting the AppWrapper removes the non-default finalizer")
+		Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		for i := 0; i < 3 && !apierrors.IsNotFound(k8sClient.Get(ctx, awName, &workloadv1beta2.AppWrapper{})); i++ {
+			_, err = awReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: awName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, awName, &workloadv1beta2.AppWrapper{}))).Should(BeTrue())
+		Expect(ValidateFinalizerPrefix(ctx, k8sClient, config.NewAppWrapperConfig())).To(Succeed())
+	})
+})
+
+var _ = Describe("AppWrapper Node Affinity Injection", func() {
+	It("Identical node selector requirements are only injected once", func() {
+		spec := map[string]interface{}{}
+		requirement := v1.NodeSelectorRequirement{Key: "autopilot.ibm.com/gpuhealth", Operator: v1.NodeSelectorOpNotIn, Values: []string{"ERR", "EVICT"}}
+		other := v1.NodeSelectorRequirement{Key: "autopilot.ibm.com/gpuhealth", Operator: v1.NodeSelectorOpNotIn, Values: []string{"ERR"}}
+		Expect(addNodeSelectorsToAffinity(spec, []v1.NodeSelectorRequirement{requirement})).To(Succeed())
+		Expect(addNodeSelectorsToAffinity(spec, []v1.NodeSelectorRequirement{requirement, other})).To(Succeed())
+
+		podSpec := &v1.PodSpec{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(spec, podSpec)).To(Succeed())
+		terms := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		Expect(terms).Should(HaveLen(1))
+		Expect(terms[0].MatchExpressions).Should(Equal([]v1.NodeSelectorRequirement{requirement, other}))
+	})
+})
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appwrapper
+
+import (
+	"context"
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/project-codeflare/appwrapper/pkg/config"
+)
+
+// ConfigMapMonitor watches the operator's ConfigMap and publishes the changes to the
+// fields of the live configuration that can safely be changed at runtime.
+// Changes to fields that are only consulted at startup are logged and ignored.
+type ConfigMapMonitor struct {
+	client.Reader
+	Config        *config.OperatorConfig       // the configuration the operator was started with
+	Live          *config.LiveAppWrapperConfig // the AppWrapper configuration currently in effect
+	ConfigMapName types.NamespacedName
+}
+
+func (r *ConfigMapMonitor) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if req.NamespacedName != r.ConfigMapName {
+		return ctrl.Result{}, nil
+	}
+
+	configMap := &v1.ConfigMap{}
+	if err := r.Get(ctx, req.NamespacedName, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil // keep using the current configuration
+		}
+		return ctrl.Result{}, err
+	}
+
+	updated := config.NewOperatorConfig(r.ConfigMapName.Namespace)
+	if err := config.UnmarshalConfigMap(configMap, updated); err != nil {
+		log.FromContext(ctx).Error(err, "Malformed operator configuration; keeping current configuration")
+		return ctrl.Result{}, nil
+	}
+	if updated.AppWrapper.LenientConfigValidation {
+		repaired, err := config.SanitizeAppWrapperConfig(updated.AppWrapper)
+		for _, problem := range repaired {
+			log.FromContext(ctx).Info("WARNING: invalid operator configuration field reset to its default", "problem", problem.Error())
+		}
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Invalid operator configuration; keeping current configuration")
+			return ctrl.Result{}, nil
+		}
+	} else if err := config.ValidateAppWrapperConfig(updated.AppWrapper); err != nil {
+		log.FromContext(ctx).Error(err, "Invalid operator configuration; keeping current configuration")
+		return ctrl.Result{}, nil
+	}
+
+	if ignored := r.staticFieldChanges(updated); len(ignored) > 0 {
+		log.FromContext(ctx).Info("WARNING: ignoring configuration changes that require an operator restart", "fields", ignored)
+	}
+
+	// Publish a modified copy of the live configuration; reconciles that are in progress keep using
+	// the configuration they started with and the next reconciles see all the updated values at once.
+	next := *r.Live.Load()
+	next.FaultTolerance = updated.AppWrapper.FaultTolerance
+	if next.Autopilot != nil && updated.AppWrapper.Autopilot != nil {
+		autopilot := *next.Autopilot
+		autopilot.InjectAntiAffinities = updated.AppWrapper.Autopilot.InjectAntiAffinities
+		autopilot.IgnoreInitContainers = updated.AppWrapper.Autopilot.IgnoreInitContainers
+		autopilot.ResourceTaints = updated.AppWrapper.Autopilot.ResourceTaints
+		autopilot.NodeHealthRules = updated.AppWrapper.Autopilot.NodeHealthRules
+		next.Autopilot = &autopilot
+	}
+	next.LenientConfigValidation = updated.AppWrapper.LenientConfigValidation
+	next.SchedulerName = updated.AppWrapper.SchedulerName
+	next.PriorityClassName = updated.AppWrapper.PriorityClassName
+	next.RuntimeClassName = updated.AppWrapper.RuntimeClassName
+	next.RuntimeClassResources = updated.AppWrapper.RuntimeClassResources
+	next.DefaultServiceAccountName = updated.AppWrapper.DefaultServiceAccountName
+	next.RequiredPodLabels = updated.AppWrapper.RequiredPodLabels
+	next.PropagatedMetadataPrefixes = updated.AppWrapper.PropagatedMetadataPrefixes
+	next.ObservabilityPodAnnotations = updated.AppWrapper.ObservabilityPodAnnotations
+	next.AdmissionGateName = updated.AppWrapper.AdmissionGateName
+	next.ImagePullSecrets = updated.AppWrapper.ImagePullSecrets
+	next.ComponentFailureRules = updated.AppWrapper.ComponentFailureRules
+	next.DeletionRequeueInterval = updated.AppWrapper.DeletionRequeueInterval
+	next.RunningRequeueInterval = updated.AppWrapper.RunningRequeueInterval
+	next.WarmupRequeueInterval = updated.AppWrapper.WarmupRequeueInterval
+	next.DeploymentRetryInterval = updated.AppWrapper.DeploymentRetryInterval
+	next.MaxReconcileDuration = updated.AppWrapper.MaxReconcileDuration
+	next.ComponentCreationConcurrency = updated.AppWrapper.ComponentCreationConcurrency
+	next.TopologySpread = updated.AppWrapper.TopologySpread
+	next.RecordLastReconcile = updated.AppWrapper.RecordLastReconcile
+	next.MissingQueuePolicy = updated.AppWrapper.MissingQueuePolicy
+	next.CreatePodlessComponentsFirst = updated.AppWrapper.CreatePodlessComponentsFirst
+	next.UserMetricsLabel = updated.AppWrapper.UserMetricsLabel
+	r.Live.Store(&next)
+
+	log.FromContext(ctx).Info("Applied updated operator configuration", "config", &next)
+	return ctrl.Result{}, nil
+}
+
+// staticFieldChanges returns the names of the fields that differ between the startup and updated
+// configurations but are only consulted when the operator starts.
+func (r *ConfigMapMonitor) staticFieldChanges(updated *config.OperatorConfig) []string {
+	live := r.Config
+	changed := []string{}
+	if !reflect.DeepEqual(live.CertManagement, updated.CertManagement) {
+		changed = append(changed, "certManagement")
+	}
+	if !reflect.DeepEqual(live.ControllerManager, updated.ControllerManager) {
+		changed = append(changed, "controllerManager")
+	}
+	if ptr.Deref(live.WebhooksEnabled, false) != ptr.Deref(updated.WebhooksEnabled, false) {
+		changed = append(changed, "webhooksEnabled")
+	}
+	if live.AppWrapper.EnableKueueIntegrations != updated.AppWrapper.EnableKueueIntegrations {
+		changed = append(changed, "appwrapper.enableKueueIntegrations")
+	}
+	if !reflect.DeepEqual(live.AppWrapper.KueueJobReconciller, updated.AppWrapper.KueueJobReconciller) {
+		changed = append(changed, "appwrapper.kueueJobReconciller")
+	}
+	if (live.AppWrapper.Autopilot == nil) != (updated.AppWrapper.Autopilot == nil) ||
+		(live.AppWrapper.Autopilot != nil && live.AppWrapper.Autopilot.MonitorNodes != updated.AppWrapper.Autopilot.MonitorNodes) {
+		changed = append(changed, "appwrapper.autopilot.monitorNodes")
+	}
+	if live.AppWrapper.Autopilot != nil && updated.AppWrapper.Autopilot != nil &&
+		live.AppWrapper.Autopilot.ResyncPeriod != updated.AppWrapper.Autopilot.ResyncPeriod {
+		changed = append(changed, "appwrapper.autopilot.resyncPeriod")
+	}
+	if live.AppWrapper.SlackQueueName != updated.AppWrapper.SlackQueueName {
+		changed = append(changed, "appwrapper.slackQueueName")
+	}
+	if live.AppWrapper.LabelPrefix != updated.AppWrapper.LabelPrefix {
+		changed = append(changed, "appwrapper.labelPrefix")
+	}
+	if !reflect.DeepEqual(live.AppWrapper.PodSetTemplates, updated.AppWrapper.PodSetTemplates) {
+		changed = append(changed, "appwrapper.podSetTemplates")
+	}
+	if !reflect.DeepEqual(live.AppWrapper.ParentRefKinds, updated.AppWrapper.ParentRefKinds) {
+		changed = append(changed, "appwrapper.parentRefKinds")
+	}
+	if live.AppWrapper.DefaultQueueName != updated.AppWrapper.DefaultQueueName {
+		changed = append(changed, "appwrapper.defaultQueueName")
+	}
+	if live.AppWrapper.UserRBACAdmissionCheck != updated.AppWrapper.UserRBACAdmissionCheck {
+		changed = append(changed, "appwrapper.userRBACAdmissionCheck")
+	}
+	if live.AppWrapper.UserRBACCheckConcurrency != updated.AppWrapper.UserRBACCheckConcurrency {
+		changed = append(changed, "appwrapper.userRBACCheckConcurrency")
+	}
+	if live.AppWrapper.UserRBACCheckTimeout != updated.AppWrapper.UserRBACCheckTimeout {
+		changed = append(changed, "appwrapper.userRBACCheckTimeout")
+	}
+	if !reflect.DeepEqual(live.AppWrapper.UserRBACExemptKinds, updated.AppWrapper.UserRBACExemptKinds) {
+		changed = append(changed, "appwrapper.userRBACExemptKinds")
+	}
+	if live.AppWrapper.ResyncPeriod != updated.AppWrapper.ResyncPeriod {
+		changed = append(changed, "appwrapper.resyncPeriod")
+	}
+	return changed
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ConfigMapMonitor) SetupWithManager(mgr ctrl.Manager) error {
+	// Use a dedicated cache restricted to the operator's namespace to avoid caching every ConfigMap in the cluster
+	cmCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:            mgr.GetScheme(),
+		Mapper:            mgr.GetRESTMapper(),
+		DefaultNamespaces: map[string]cache.Config{r.ConfigMapName.Namespace: {}},
+	})
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(cmCache); err != nil {
+		return err
+	}
+	if r.Reader == nil {
+		r.Reader = cmCache
+	}
+
+	isConfigMap := predicate.NewTypedPredicateFuncs(func(cm *v1.ConfigMap) bool {
+		return cm.Namespace == r.ConfigMapName.Namespace && cm.Name == r.ConfigMapName.Name
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		WatchesRawSource(source.Kind(cmCache, &v1.ConfigMap{}, &handler.TypedEnqueueRequestForObject[*v1.ConfigMap]{}, isConfigMap)).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}). // every replica serves webhooks with the live config
+		Named("ConfigMapMonitor").
+		Complete(r)
+}
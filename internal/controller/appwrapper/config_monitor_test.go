@@ -0,0 +1,120 @@
+/*
+Copyright 2024 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appwrapper
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	workloadv1beta2 "github.com/project-codeflare/appwrapper/api/v1beta2"
+	"github.com/project-codeflare/appwrapper/pkg/config"
+)
+
+var _ = Describe("ConfigMap Monitor", func() {
+	var cmName = types.NamespacedName{Name: "appwrapper-operator-config", Namespace: "default"}
+	var startupConfig *config.OperatorConfig
+	var liveConfig *config.LiveAppWrapperConfig
+	var cmMonitor *ConfigMapMonitor
+
+	writeConfigMap := func(cfg *config.OperatorConfig) {
+		content, err := yaml.Marshal(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		cm := &v1.ConfigMap{}
+		if err := k8sClient.Get(ctx, cmName, cm); err == nil {
+			cm.Data = map[string]string{"config.yaml": string(content)}
+			Expect(k8sClient.Update(ctx, cm)).To(Succeed())
+		} else {
+			cm = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: cmName.Name, Namespace: cmName.Namespace},
+				Data:       map[string]string{"config.yaml": string(content)},
+			}
+			Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		startupConfig = config.NewOperatorConfig(cmName.Namespace)
+		liveConfig = config.NewLiveAppWrapperConfig(startupConfig.AppWrapper)
+		cmMonitor = &ConfigMapMonitor{
+			Reader:        k8sClient,
+			Config:        startupConfig,
+			Live:          liveConfig,
+			ConfigMapName: cmName,
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cmName.Name, Namespace: cmName.Namespace}})).To(Succeed())
+		cmMonitor = nil
+	})
+
+	It("Changes to the ConfigMap are seen by the AppWrapper reconciler", func() {
+		awReconciler := &AppWrapperReconciler{
+			Client:   k8sClient,
+			Recorder: &record.FakeRecorder{},
+			Scheme:   k8sClient.Scheme(),
+			Config:   startupConfig.AppWrapper,
+			Live:     liveConfig,
+		}
+		aw := &workloadv1beta2.AppWrapper{}
+		writeConfigMap(config.NewOperatorConfig(cmName.Namespace))
+		_, err := cmMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: cmName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(awReconciler.withLiveConfig().warmupGraceDuration(ctx, aw, nil)).Should(Equal(5 * time.Minute))
+
+		By("Updating the grace period and fields that require a restart")
+		inProgress := awReconciler.withLiveConfig()
+		updated := config.NewOperatorConfig(cmName.Namespace)
+		updated.AppWrapper.FaultTolerance.WarmupGracePeriod = 7 * time.Minute
+		updated.AppWrapper.Autopilot.ResourceTaints["example.com/accelerator"] = []v1.Taint{{Key: "example.com/health", Value: "BAD", Effect: v1.TaintEffectNoSchedule}}
+		updated.AppWrapper.EnableKueueIntegrations = false
+		updated.AppWrapper.UserRBACCheckTimeout = 2 * time.Second
+		updated.AppWrapper.Autopilot.ResyncPeriod = time.Hour
+		updated.AppWrapper.LenientConfigValidation = true
+		writeConfigMap(updated)
+		_, err = cmMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: cmName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(awReconciler.withLiveConfig().warmupGraceDuration(ctx, aw, nil)).Should(Equal(7 * time.Minute))
+		Expect(awReconciler.withLiveConfig().Config.Autopilot.ResourceTaints).Should(HaveKey("example.com/accelerator"))
+		Expect(awReconciler.withLiveConfig().Config.LenientConfigValidation).Should(BeTrue())
+		Expect(awReconciler.withLiveConfig().Config.EnableKueueIntegrations).Should(BeTrue())
+		Expect(awReconciler.withLiveConfig().Config.UserRBACCheckTimeout).Should(Equal(startupConfig.AppWrapper.UserRBACCheckTimeout))
+		Expect(awReconciler.withLiveConfig().Config.Autopilot.ResyncPeriod).Should(Equal(startupConfig.AppWrapper.Autopilot.ResyncPeriod))
+		Expect(cmMonitor.staticFieldChanges(updated)).Should(ConsistOf("appwrapper.enableKueueIntegrations",
+			"appwrapper.userRBACCheckTimeout", "appwrapper.autopilot.resyncPeriod"))
+
+		By("A reconcile that was in progress keeps the configuration it started with")
+		Expect(inProgress.warmupGraceDuration(ctx, aw, nil)).Should(Equal(5 * time.Minute))
+		Expect(startupConfig.AppWrapper.FaultTolerance.WarmupGracePeriod).Should(Equal(5 * time.Minute))
+
+		By("Invalid configurations are ignored")
+		invalid := config.NewOperatorConfig(cmName.Namespace)
+		invalid.AppWrapper.FaultTolerance.WarmupGracePeriod = 48 * time.Hour
+		writeConfigMap(invalid)
+		_, err = cmMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: cmName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(awReconciler.withLiveConfig().warmupGraceDuration(ctx, aw, nil)).Should(Equal(7 * time.Minute))
+	})
+})
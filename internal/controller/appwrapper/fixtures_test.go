@@ -17,19 +17,27 @@ limitations under the License.
 package appwrapper
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/yaml"
 
@@ -47,6 +55,18 @@ func randName(baseName string) string {
 	return fmt.Sprintf("%s-%s", baseName, string(b))
 }
 
+func gaugeValue(gauge *prometheus.GaugeVec, labelValues ...string) float64 {
+	m := &dto.Metric{}
+	ExpectWithOffset(1, gauge.WithLabelValues(labelValues...).Write(m)).To(Succeed())
+	return m.GetGauge().GetValue()
+}
+
+func counterValue(counter *prometheus.CounterVec, labelValues ...string) float64 {
+	m := &dto.Metric{}
+	ExpectWithOffset(1, counter.WithLabelValues(labelValues...).Write(m)).To(Succeed())
+	return m.GetCounter().GetValue()
+}
+
 func toAppWrapper(components ...workloadv1beta2.AppWrapperComponent) *workloadv1beta2.AppWrapper {
 	return &workloadv1beta2.AppWrapper{
 		TypeMeta:   metav1.TypeMeta{APIVersion: workloadv1beta2.GroupVersion.String(), Kind: "AppWrapper"},
@@ -69,6 +89,26 @@ func getNode(name string) *v1.Node {
 	return node
 }
 
+// forcefulDeletionCountingClient returns a client of the test cluster that counts the deletions with a GracePeriod of 0
+// in forcefulDeletes. If opts are given, every deletion is performed with them instead of the options it was requested with.
+func forcefulDeletionCountingClient(forcefulDeletes *int, opts ...client.DeleteOption) client.Client {
+	watchingClient, err := client.NewWithWatch(cfg, client.Options{Scheme: k8sClient.Scheme()})
+	Expect(err).NotTo(HaveOccurred())
+	return interceptor.NewClient(watchingClient, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, requested ...client.DeleteOption) error {
+			deleteOpts := &client.DeleteOptions{}
+			deleteOpts.ApplyOptions(requested)
+			if deleteOpts.GracePeriodSeconds != nil && *deleteOpts.GracePeriodSeconds == 0 {
+				*forcefulDeletes += 1
+			}
+			if len(opts) > 0 {
+				return c.Delete(ctx, obj, opts...)
+			}
+			return c.Delete(ctx, obj, requested...)
+		},
+	})
+}
+
 func getPods(aw *workloadv1beta2.AppWrapper) []v1.Pod {
 	result := []v1.Pod{}
 	podList := &v1.PodList{}
@@ -105,6 +145,108 @@ func setPodStatus(aw *workloadv1beta2.AppWrapper, phase v1.PodPhase, numToChange
 	return nil
 }
 
+func setPodOOMKilled(aw *workloadv1beta2.AppWrapper, numToChange int32) error {
+	podList := &v1.PodList{}
+	err := k8sClient.List(ctx, podList, &client.ListOptions{Namespace: aw.Namespace})
+	if err != nil {
+		return err
+	}
+	for _, pod := range podList.Items {
+		if numToChange <= 0 {
+			return nil
+		}
+		if awn, found := pod.Labels[workloadv1beta2.AppWrapperLabel]; found && awn == aw.Name {
+			pod.Status.Phase = v1.PodFailed
+			pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+				Name:  pod.Spec.Containers[0].Name,
+				State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+			}}
+			err = k8sClient.Status().Update(ctx, &pod)
+			if err != nil {
+				return err
+			}
+			numToChange -= 1
+		}
+	}
+	return nil
+}
+
+func setPodEvicted(aw *workloadv1beta2.AppWrapper, numToChange int32) error {
+	podList := &v1.PodList{}
+	err := k8sClient.List(ctx, podList, &client.ListOptions{Namespace: aw.Namespace})
+	if err != nil {
+		return err
+	}
+	for _, pod := range podList.Items {
+		if numToChange <= 0 {
+			return nil
+		}
+		if awn, found := pod.Labels[workloadv1beta2.AppWrapperLabel]; found && awn == aw.Name {
+			pod.Status.Phase = v1.PodFailed
+			pod.Status.Reason = "Evicted"
+			pod.Status.Message = "The node was low on resource: memory."
+			err = k8sClient.Status().Update(ctx, &pod)
+			if err != nil {
+				return err
+			}
+			numToChange -= 1
+		}
+	}
+	return nil
+}
+
+// setPodScheduled marks the i'th Pod of aw as scheduled latencies[i] after its creation
+func setPodScheduled(aw *workloadv1beta2.AppWrapper, latencies ...time.Duration) error {
+	podList := &v1.PodList{}
+	err := k8sClient.List(ctx, podList, &client.ListOptions{Namespace: aw.Namespace})
+	if err != nil {
+		return err
+	}
+	for _, pod := range podList.Items {
+		if len(latencies) == 0 {
+			return nil
+		}
+		if awn, found := pod.Labels[workloadv1beta2.AppWrapperLabel]; found && awn == aw.Name {
+			pod.Status.Conditions = append(pod.Status.Conditions, v1.PodCondition{
+				Type:               v1.PodScheduled,
+				Status:             v1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(pod.CreationTimestamp.Add(latencies[0])),
+			})
+			err = k8sClient.Status().Update(ctx, &pod)
+			if err != nil {
+				return err
+			}
+			latencies = latencies[1:]
+		}
+	}
+	return nil
+}
+
+// setDeploymentRollout simulates the Deployment controller rolling out the given generation of a Deployment
+func setDeploymentRollout(name types.NamespacedName, observedGeneration int64) error {
+	dep := &appsv1.Deployment{}
+	if err := k8sClient.Get(ctx, name, dep); err != nil {
+		return err
+	}
+	replicas := ptr.Deref(dep.Spec.Replicas, 1)
+	dep.Status.ObservedGeneration = observedGeneration
+	dep.Status.Replicas = replicas
+	dep.Status.UpdatedReplicas = replicas
+	return k8sClient.Status().Update(ctx, dep)
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	events := []string{}
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
 const podYAML = `
 apiVersion: v1
 kind: Pod
@@ -123,17 +265,16 @@ spec:
       limits:
         nvidia.com/gpu: %v`
 
-func pod(milliCPU int64, numGPU int64, declarePodSets bool) workloadv1beta2.AppWrapperComponent {
+// pod returns a Pod component; the Pod can be modified by mutate (eg to set the field of the PodSpec under test)
+func pod(milliCPU int64, numGPU int64, declarePodSets bool, mutate ...func(*v1.Pod)) workloadv1beta2.AppWrapperComponent {
 	yamlString := fmt.Sprintf(podYAML,
 		randName("pod"),
 		resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
 		resource.NewQuantity(numGPU, resource.DecimalSI),
 		resource.NewQuantity(numGPU, resource.DecimalSI))
 
-	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
-	Expect(err).NotTo(HaveOccurred())
 	awc := &workloadv1beta2.AppWrapperComponent{
-		Template: runtime.RawExtension{Raw: jsonBytes},
+		Template: runtime.RawExtension{Raw: encodeTemplate(yamlString, mutate...)},
 	}
 	if declarePodSets {
 		awc.DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(int32(1)), Path: "template"}}
@@ -141,6 +282,224 @@ func pod(milliCPU int64, numGPU int64, declarePodSets bool) workloadv1beta2.AppW
 	return *awc
 }
 
+// withSchedulingGates adds the named scheduling gates to a Pod
+func withSchedulingGates(gates ...string) func(*v1.Pod) {
+	return func(p *v1.Pod) {
+		for _, gate := range gates {
+			p.Spec.SchedulingGates = append(p.Spec.SchedulingGates, v1.PodSchedulingGate{Name: gate})
+		}
+	}
+}
+
+// withGPUInitContainer adds an init container that requests numGPU GPUs to a Pod
+func withGPUInitContainer(numGPU int64) func(*v1.Pod) {
+	return func(p *v1.Pod) {
+		gpus := v1.ResourceList{"nvidia.com/gpu": *resource.NewQuantity(numGPU, resource.DecimalSI)}
+		p.Spec.InitContainers = append(p.Spec.InitContainers, v1.Container{
+			Name:      "warmup",
+			Image:     "quay.io/project-codeflare/busybox:1.36",
+			Command:   []string{"sh", "-c", "sleep 1"},
+			Resources: v1.ResourceRequirements{Requests: gpus, Limits: gpus},
+		})
+	}
+}
+
+// encodeTemplate returns the JSON encoding of yamlString; if mutate is given, the encoded object of type T is modified by it
+func encodeTemplate[T any](yamlString string, mutate ...func(*T)) []byte {
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	if len(mutate) == 0 {
+		return jsonBytes
+	}
+	obj := new(T)
+	Expect(json.Unmarshal(jsonBytes, obj)).To(Succeed())
+	for _, m := range mutate {
+		m(obj)
+	}
+	jsonBytes, err = json.Marshal(obj)
+	Expect(err).NotTo(HaveOccurred())
+	return jsonBytes
+}
+
+const daemonSetYAML = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: %v
+spec:
+  selector:
+    matchLabels:
+      app: %v
+  template:
+    metadata:
+      labels:
+        app: %v
+    spec:
+      containers:
+      - name: busybox
+        image: quay.io/project-codeflare/busybox:1.36
+        command: ["sh", "-c", "sleep 10"]
+        resources:
+          requests:
+            cpu: %v`
+
+func daemonSet(replicas int32, milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	name := randName("daemonset")
+	yamlString := fmt.Sprintf(daemonSetYAML,
+		name, name, name,
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		DeclaredPodSets: []workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(replicas), Path: "template.spec.template"}},
+		Template:        runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const batchJobYAML = `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: %v
+spec:
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: busybox
+        image: quay.io/project-codeflare/busybox:1.36
+        command: ["sh", "-c", "sleep 10"]
+        resources:
+          requests:
+            cpu: %v`
+
+// batchJob returns a Job component; the Job can be modified by mutate (eg by indexed)
+func batchJob(milliCPU int64, mutate ...func(*batchv1.Job)) workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(batchJobYAML,
+		randName("job"),
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: encodeTemplate(yamlString, mutate...)},
+	}
+}
+
+// indexed makes a Job an Indexed Job with the given parallelism and completions
+func indexed(parallelism int32, completions int32) func(*batchv1.Job) {
+	return func(job *batchv1.Job) {
+		job.Spec.CompletionMode = ptr.To(batchv1.IndexedCompletion)
+		job.Spec.Parallelism = ptr.To(parallelism)
+		job.Spec.Completions = ptr.To(completions)
+	}
+}
+
+const deploymentYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: %v
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: %v
+  template:
+    metadata:
+      labels:
+        app: %v
+    spec:
+      containers:
+      - name: busybox
+        image: quay.io/project-codeflare/busybox:1.36
+        command: ["sh", "-c", "sleep 10000"]
+        resources:
+          requests:
+            cpu: %v`
+
+func deployment(milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	name := randName("deployment")
+	yamlString := fmt.Sprintf(deploymentYAML,
+		name, name, name,
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const serviceYAML = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: %v
+spec:
+  selector:
+    app: %v
+  ports:
+  - port: 8080
+    targetPort: 8080`
+
+func service() workloadv1beta2.AppWrapperComponent {
+	name := randName("service")
+	yamlString := fmt.Sprintf(serviceYAML, name, name)
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const syntheticJobYAML = `
+apiVersion: test.codeflare.dev/v1
+kind: SyntheticJob
+metadata:
+  name: %v
+spec:
+  workers: 1`
+
+func syntheticJob() workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(syntheticJobYAML, randName("synthetic"))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const inferenceServiceYAML = `
+apiVersion: serving.kserve.io/v1beta1
+kind: InferenceService
+metadata:
+  name: %v
+spec:
+  predictor:
+    minReplicas: %v
+    containers:
+    - name: kserve-container
+      image: quay.io/project-codeflare/busybox:1.36
+      command: ["sh", "-c", "sleep 10"]
+      resources:
+        requests:
+          cpu: %v`
+
+func inferenceService(minReplicas int, milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(inferenceServiceYAML,
+		randName("isvc"),
+		minReplicas,
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
 const complexPodYAML = `
 apiVersion: v1
 kind: Pod
@@ -19,6 +19,7 @@ package appwrapper
 import (
 	"context"
 	"maps"
+	"slices"
 	"sync"
 
 	v1 "k8s.io/api/core/v1"
@@ -26,12 +27,14 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/project-codeflare/appwrapper/pkg/config"
 )
@@ -41,10 +44,12 @@ import (
 // they have resources that Autopilot has tainted as NoSchedule or NoExecute.
 // This information is used to automate the maintenance of the lendingLimit of
 // a designated slack ClusterQueue and to migrate running workloads away from NoExecute resources.
+// It also tracks the Nodes that are unreachable so that the Pods bound to them can be recognized as lost.
 type NodeHealthMonitor struct {
 	client.Client
 	Config *config.AppWrapperConfig
-	Events chan event.GenericEvent // event channel for NodeHealthMonitor to trigger SlackClusterQueueMonitor
+	Live   *config.LiveAppWrapperConfig // if set, supersedes Config
+	Events chan event.GenericEvent      // event channel for NodeHealthMonitor to trigger SlackClusterQueueMonitor
 }
 
 var (
@@ -60,12 +65,20 @@ var (
 	noScheduleNodes = make(map[string]v1.ResourceList)
 	// noScheduleNodesMutex synchronizes access to noScheduleNodes
 	noScheduleNodesMutex sync.RWMutex
+
+	// unreachableNodes is the set of Nodes that are tainted as unreachable or whose Ready condition is Unknown
+	unreachableNodes = make(sets.Set[string])
+	// unreachableNodesMutex synchronizes access to unreachableNodes
+	unreachableNodesMutex sync.RWMutex
 )
 
 // permission to watch nodes
 //+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 
 func (r *NodeHealthMonitor) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.Live != nil {
+		return r.withLiveConfig().Reconcile(ctx, req)
+	}
 	node := &v1.Node{}
 	if err := r.Get(ctx, req.NamespacedName, node); err != nil {
 		if errors.IsNotFound(err) {
@@ -78,6 +91,7 @@ func (r *NodeHealthMonitor) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if node.DeletionTimestamp.IsZero() {
 		r.updateNoExecuteNodes(ctx, node)
 		r.updateNoScheduleNodes(ctx, node)
+		r.updateUnreachableNodes(ctx, node)
 	} else {
 		r.updateForNodeDeletion(ctx, req.Name)
 	}
@@ -85,6 +99,17 @@ func (r *NodeHealthMonitor) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	return ctrl.Result{}, nil
 }
 
+// withLiveConfig returns a copy of r whose Config is the configuration currently published by Live
+func (r *NodeHealthMonitor) withLiveConfig() *NodeHealthMonitor {
+	if r.Live == nil {
+		return r
+	}
+	snapshot := *r
+	snapshot.Config = r.Live.Load()
+	snapshot.Live = nil
+	return &snapshot
+}
+
 func (r *NodeHealthMonitor) triggerSlackCQMonitor() {
 	if r.Config.SlackQueueName != "" {
 		select {
@@ -95,6 +120,42 @@ func (r *NodeHealthMonitor) triggerSlackCQMonitor() {
 	}
 }
 
+// resync re-lists all Nodes and recomputes noExecuteNodes and noScheduleNodes from their current state.
+// This corrects the mappings if Node events were missed, for example Node deletions during a controller restart.
+func (r *NodeHealthMonitor) resync(ctx context.Context) error {
+	nodes := &v1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return err
+	}
+	current := make(sets.Set[string])
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.DeletionTimestamp.IsZero() {
+			current.Insert(node.GetName())
+			r.updateNoExecuteNodes(ctx, node)
+			r.updateNoScheduleNodes(ctx, node)
+			r.updateUnreachableNodes(ctx, node)
+		}
+	}
+
+	noExecuteNodesMutex.RLock() // BEGIN CRITICAL SECTION
+	stale := sets.KeySet(noExecuteNodes).Difference(current)
+	noExecuteNodesMutex.RUnlock() // END CRITICAL SECTION
+	noScheduleNodesMutex.RLock()  // BEGIN CRITICAL SECTION
+	stale = stale.Union(sets.KeySet(noScheduleNodes).Difference(current))
+	noScheduleNodesMutex.RUnlock() // END CRITICAL SECTION
+	unreachableNodesMutex.RLock()  // BEGIN CRITICAL SECTION
+	stale = stale.Union(unreachableNodes.Difference(current))
+	unreachableNodesMutex.RUnlock() // END CRITICAL SECTION
+	for nodeName := range stale {
+		r.updateForNodeDeletion(ctx, nodeName)
+	}
+
+	// Always trigger the SlackClusterQueueMonitor so that its lending limits self-correct
+	r.triggerSlackCQMonitor()
+	return nil
+}
+
 // update noExecuteNodes and noScheduleNodes for the deletion of nodeName
 func (r *NodeHealthMonitor) updateForNodeDeletion(ctx context.Context, nodeName string) {
 	if _, ok := noExecuteNodes[nodeName]; ok {
@@ -113,17 +174,34 @@ func (r *NodeHealthMonitor) updateForNodeDeletion(ctx context.Context, nodeName
 			"Number NoSchedule Nodes", len(noScheduleNodes), "NoSchedule Resource Details", noScheduleNodes)
 		r.triggerSlackCQMonitor()
 	}
+	unreachableNodesMutex.Lock() // BEGIN CRITICAL SECTION
+	unreachableNodes.Delete(nodeName)
+	unreachableNodesMutex.Unlock() // END CRITICAL SECTION
+}
+
+// noExecuteRules returns the rules equivalent to the NoExecute taints of the Autopilot ResourceTaints
+// followed by the configured NodeHealthRules
+func (r *NodeHealthMonitor) noExecuteRules() []config.NodeHealthRule {
+	rules := []config.NodeHealthRule{}
+	for resourceName, taints := range r.Config.Autopilot.ResourceTaints {
+		for _, taint := range taints {
+			if taint.Effect == v1.TaintEffectNoExecute {
+				rules = append(rules, config.NodeHealthRule{LabelKey: taint.Key, UnhealthyValues: []string{taint.Value}, Resource: resourceName})
+			}
+		}
+	}
+	return append(rules, r.Config.Autopilot.NodeHealthRules...)
 }
 
-// update noExecuteNodes entry for node
+// update noExecuteNodes entry for node.
+// Resources for which the Node does not (yet) report a non-zero capacity are skipped;
+// the Node will be re-evaluated when an update to its status reports the capacity.
 func (r *NodeHealthMonitor) updateNoExecuteNodes(ctx context.Context, node *v1.Node) {
 	noExecuteResources := make(sets.Set[string])
-	for key, value := range node.GetLabels() {
-		for resourceName, taints := range r.Config.Autopilot.ResourceTaints {
-			for _, taint := range taints {
-				if key == taint.Key && value == taint.Value && taint.Effect == v1.TaintEffectNoExecute {
-					noExecuteResources.Insert(resourceName)
-				}
+	for _, rule := range r.noExecuteRules() {
+		if value, ok := node.GetLabels()[rule.LabelKey]; ok && slices.Contains(rule.UnhealthyValues, value) {
+			if quantity := node.Status.Capacity.Name(v1.ResourceName(rule.Resource), resource.DecimalSI); !quantity.IsZero() {
+				noExecuteResources.Insert(rule.Resource)
 			}
 		}
 	}
@@ -150,12 +228,17 @@ func (r *NodeHealthMonitor) updateNoExecuteNodes(ctx context.Context, node *v1.N
 	}
 }
 
-// update noScheduleNodes entry for node
+// update noScheduleNodes entry for node.
+// As for noExecuteNodes, resources with an absent or zero capacity are skipped.
 func (r *NodeHealthMonitor) updateNoScheduleNodes(ctx context.Context, node *v1.Node) {
 	var noScheduleResources v1.ResourceList
 	if node.Spec.Unschedulable {
-		noScheduleResources = node.Status.Capacity.DeepCopy()
-		delete(noScheduleResources, v1.ResourcePods)
+		noScheduleResources = make(v1.ResourceList)
+		for resourceName, quantity := range node.Status.Capacity {
+			if resourceName != v1.ResourcePods && !quantity.IsZero() {
+				noScheduleResources[resourceName] = quantity.DeepCopy()
+			}
+		}
 	} else {
 		noScheduleResources = make(v1.ResourceList)
 		for key, value := range node.GetLabels() {
@@ -170,6 +253,14 @@ func (r *NodeHealthMonitor) updateNoScheduleNodes(ctx context.Context, node *v1.
 				}
 			}
 		}
+		for _, rule := range r.Config.Autopilot.NodeHealthRules {
+			if value, ok := node.GetLabels()[rule.LabelKey]; ok && slices.Contains(rule.UnhealthyValues, value) {
+				quantity := node.Status.Capacity.Name(v1.ResourceName(rule.Resource), resource.DecimalSI)
+				if !quantity.IsZero() {
+					noScheduleResources[v1.ResourceName(rule.Resource)] = *quantity
+				}
+			}
+		}
 	}
 
 	noScheduleNodesChanged := false
@@ -194,8 +285,49 @@ func (r *NodeHealthMonitor) updateNoScheduleNodes(ctx context.Context, node *v1.
 	}
 }
 
+// update unreachableNodes entry for node
+func (r *NodeHealthMonitor) updateUnreachableNodes(ctx context.Context, node *v1.Node) {
+	unreachable := false
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == v1.TaintNodeUnreachable {
+			unreachable = true
+		}
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == v1.NodeReady && cond.Status == v1.ConditionUnknown {
+			unreachable = true
+		}
+	}
+
+	unreachableNodesMutex.Lock() // BEGIN CRITICAL SECTION
+	changed := unreachable != unreachableNodes.Has(node.GetName())
+	if unreachable {
+		unreachableNodes.Insert(node.GetName())
+	} else {
+		unreachableNodes.Delete(node.GetName())
+	}
+	unreachableNodesMutex.Unlock() // END CRITICAL SECTION
+
+	if changed {
+		log.FromContext(ctx).Info("Updated unreachable Node information", "Node", node.GetName(), "Unreachable", unreachable)
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
+// If a ResyncPeriod is configured, a periodic resync of all Nodes is also added to the Manager.
 func (r *NodeHealthMonitor) SetupWithManager(mgr ctrl.Manager) error {
+	if period := r.Config.Autopilot.ResyncPeriod; period > 0 {
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			wait.UntilWithContext(ctx, func(ctx context.Context) {
+				if err := r.withLiveConfig().resync(ctx); err != nil {
+					log.FromContext(ctx).Error(err, "Failed to resync Nodes")
+				}
+			}, period)
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		Watches(&v1.Node{}, &handler.EnqueueRequestForObject{}).
 		Named("NodeMonitor").
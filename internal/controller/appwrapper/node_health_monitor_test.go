@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -121,6 +122,172 @@ var _ = Describe("NodeMonitor Controller", func() {
 		deleteNode(node2Name.Name)
 	})
 
+	It("Custom Node Health Rules", func() {
+		nodeMonitor.Config.Autopilot.NodeHealthRules = []config.NodeHealthRule{
+			{LabelKey: "example.com/node-problem", UnhealthyValues: []string{"KernelDeadlock", "Evict"}, Resource: "nvidia.com/gpu"},
+		}
+		createNode(node1Name.Name)
+		createNode(node2Name.Name)
+
+		By("A node labeled with a custom unhealthy value is detected as unhealthy")
+		node1 := getNode(node1Name.Name)
+		node1.Labels["example.com/node-problem"] = "KernelDeadlock"
+		Expect(k8sClient.Update(ctx, node1)).Should(Succeed())
+		_, err := nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(noExecuteNodes)).Should(Equal(1))
+		Expect(noExecuteNodes).Should(HaveKey(node1Name.Name))
+		Expect(noExecuteNodes[node1Name.Name]).Should(HaveKey("nvidia.com/gpu"))
+
+		By("Configured rules are in addition to the rules derived from the Autopilot taints")
+		node2 := getNode(node2Name.Name)
+		node2.Labels["autopilot.ibm.com/gpuhealth"] = "EVICT"
+		Expect(k8sClient.Update(ctx, node2)).Should(Succeed())
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node2Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(noExecuteNodes).Should(HaveKey(node2Name.Name))
+		Expect(noScheduleNodes).Should(HaveKey(node1Name.Name))
+		Expect(noScheduleNodes).Should(HaveKey(node2Name.Name))
+
+		By("Clearing the labels updates unhealthyNodes")
+		node1.Labels["example.com/node-problem"] = "None"
+		Expect(k8sClient.Update(ctx, node1)).Should(Succeed())
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		node2 = getNode(node2Name.Name)
+		node2.Labels["autopilot.ibm.com/gpuhealth"] = "OK"
+		Expect(k8sClient.Update(ctx, node2)).Should(Succeed())
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node2Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(noExecuteNodes)).Should(Equal(0))
+		Expect(len(noScheduleNodes)).Should(Equal(0))
+
+		deleteNode(node1Name.Name)
+		deleteNode(node2Name.Name)
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node2Name})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Nodes without GPU capacity", func() {
+		node := &v1.Node{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+			ObjectMeta: metav1.ObjectMeta{Name: node1Name.Name, Labels: map[string]string{"autopilot.ibm.com/gpuhealth": "EVICT"}},
+		}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+
+		By("A labeled node that does not report GPU capacity is skipped")
+		_, err := nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(noExecuteNodes).ShouldNot(HaveKey(node1Name.Name))
+		Expect(noScheduleNodes).ShouldNot(HaveKey(node1Name.Name))
+
+		By("A cordoned node does not record resources with zero capacity")
+		node = getNode(node1Name.Name)
+		node.Labels["autopilot.ibm.com/gpuhealth"] = "OK"
+		node.Spec.Unschedulable = true
+		Expect(k8sClient.Update(ctx, node)).Should(Succeed())
+		node = getNode(node1Name.Name)
+		node.Status.Capacity = v1.ResourceList{v1.ResourceName("nvidia.com/gpu"): resource.MustParse("0"), v1.ResourceCPU: resource.MustParse("8")}
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(noScheduleNodes).Should(HaveKey(node1Name.Name))
+		Expect(noScheduleNodes[node1Name.Name]).Should(HaveKey(v1.ResourceCPU))
+		Expect(noScheduleNodes[node1Name.Name]).ShouldNot(HaveKey(v1.ResourceName("nvidia.com/gpu")))
+
+		By("The node is re-evaluated once its GPU capacity appears")
+		node = getNode(node1Name.Name)
+		node.Labels["autopilot.ibm.com/gpuhealth"] = "EVICT"
+		node.Spec.Unschedulable = false
+		Expect(k8sClient.Update(ctx, node)).Should(Succeed())
+		node = getNode(node1Name.Name)
+		node.Status.Capacity = nodeGPUs
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(noExecuteNodes).Should(HaveKey(node1Name.Name))
+		Expect(noExecuteNodes[node1Name.Name]).Should(HaveKey("nvidia.com/gpu"))
+		Expect(noScheduleNodes).Should(HaveKey(node1Name.Name))
+		Expect(noScheduleNodes[node1Name.Name]).Should(HaveKey(v1.ResourceName("nvidia.com/gpu")))
+
+		deleteNode(node1Name.Name)
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(noExecuteNodes).ShouldNot(HaveKey(node1Name.Name))
+		Expect(noScheduleNodes).ShouldNot(HaveKey(node1Name.Name))
+	})
+
+	It("Unreachable Nodes", func() {
+		createNode(node1Name.Name)
+
+		By("A node tainted as unreachable is detected")
+		node := getNode(node1Name.Name)
+		node.Spec.Taints = []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute}}
+		Expect(k8sClient.Update(ctx, node)).Should(Succeed())
+		_, err := nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unreachableNodes.Has(node1Name.Name)).Should(BeTrue())
+
+		By("Removing the taint clears the entry")
+		node = getNode(node1Name.Name)
+		node.Spec.Taints = nil
+		Expect(k8sClient.Update(ctx, node)).Should(Succeed())
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unreachableNodes.Has(node1Name.Name)).Should(BeFalse())
+
+		By("A node whose Ready condition is Unknown is detected")
+		node = getNode(node1Name.Name)
+		node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionUnknown, Reason: "NodeStatusUnknown"}}
+		Expect(k8sClient.Status().Update(ctx, node)).Should(Succeed())
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unreachableNodes.Has(node1Name.Name)).Should(BeTrue())
+
+		deleteNode(node1Name.Name)
+		_, err = nodeMonitor.Reconcile(ctx, reconcile.Request{NamespacedName: node1Name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unreachableNodes.Has(node1Name.Name)).Should(BeFalse())
+	})
+
+	It("A resync recomputes the maps from the current Node state", func() {
+		createNode(node1Name.Name)
+		createNode(node2Name.Name)
+
+		By("Simulating a missed update event for an EVICT label and a missed deletion event")
+		node1 := getNode(node1Name.Name)
+		node1.Labels["autopilot.ibm.com/gpuhealth"] = "EVICT"
+		Expect(k8sClient.Update(ctx, node1)).Should(Succeed())
+		noExecuteNodesMutex.Lock()
+		noExecuteNodes["fake-deleted-node"] = sets.New("nvidia.com/gpu")
+		noExecuteNodesMutex.Unlock()
+		noScheduleNodesMutex.Lock()
+		noScheduleNodes["fake-deleted-node"] = nodeGPUs
+		noScheduleNodesMutex.Unlock()
+
+		By("Resyncing updates the entries of existing Nodes and forgets deleted Nodes")
+		Expect(nodeMonitor.resync(ctx)).To(Succeed())
+		Expect(noExecuteNodes).Should(HaveLen(1))
+		Expect(noExecuteNodes).Should(HaveKey(node1Name.Name))
+		Expect(noExecuteNodes[node1Name.Name]).Should(HaveKey("nvidia.com/gpu"))
+		Expect(noScheduleNodes).Should(HaveLen(1))
+		Expect(noScheduleNodes).Should(HaveKey(node1Name.Name))
+		Expect(nodeMonitor.Events).Should(Receive())
+
+		By("Resyncing without missed events triggers the SlackClusterQueueMonitor")
+		Expect(nodeMonitor.resync(ctx)).To(Succeed())
+		Expect(noExecuteNodes).Should(HaveLen(1))
+		Expect(nodeMonitor.Events).Should(Receive())
+
+		deleteNode(node1Name.Name)
+		deleteNode(node2Name.Name)
+		Expect(nodeMonitor.resync(ctx)).To(Succeed())
+		Expect(noExecuteNodes).Should(BeEmpty())
+		Expect(noScheduleNodes).Should(BeEmpty())
+	})
+
 	It("ClusterQueue Lending Adjustment", func() {
 		createNode(node1Name.Name)
 		createNode(node2Name.Name)
@@ -20,16 +20,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	workloadv1beta2 "github.com/project-codeflare/appwrapper/api/v1beta2"
+	"github.com/project-codeflare/appwrapper/internal/metrics"
+	"github.com/project-codeflare/appwrapper/pkg/config"
 	"github.com/project-codeflare/appwrapper/pkg/utils"
+	"golang.org/x/sync/errgroup"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	kresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -52,7 +62,19 @@ func parseComponent(raw []byte, expectedNamespace string) (*unstructured.Unstruc
 	return obj, nil
 }
 
-func hasResourceRequest(spec map[string]interface{}, resource string) bool {
+// parseComponents returns an error if the template of any component of aw cannot be parsed
+func parseComponents(aw *workloadv1beta2.AppWrapper) error {
+	for idx, component := range aw.Spec.Components {
+		if _, err := parseComponent(component.Template.Raw, aw.Namespace); err != nil {
+			return fmt.Errorf("component %v: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// hasResourceRequest returns true if a container of spec has a non-zero request or limit for resource;
+// initContainers are only considered if includeInitContainers is true
+func hasResourceRequest(spec map[string]interface{}, resource string, includeInitContainers bool) bool {
 	usesResource := func(container map[string]interface{}) bool {
 		_, ok := container["resources"]
 		if !ok {
@@ -92,7 +114,11 @@ func hasResourceRequest(spec map[string]interface{}, resource string) bool {
 		return false
 	}
 
-	for _, key := range []string{"containers", "initContainers"} {
+	keys := []string{"containers"}
+	if includeInitContainers {
+		keys = append(keys, "initContainers")
+	}
+	for _, key := range keys {
 		if containers, ok := spec[key]; ok {
 			if carray, ok := containers.([]interface{}); ok {
 				for _, containerI := range carray {
@@ -158,7 +184,10 @@ func addNodeSelectorsToAffinity(spec map[string]interface{}, exprsToAdd []v1.Nod
 			if err = json.Unmarshal(bytes, &obj); err != nil {
 				return fmt.Errorf("unmarshalling selectorTerm %v: %w", expr, err)
 			}
-			matchExpressions = append(matchExpressions, obj)
+			// identical requirements may already have been injected (eg by an earlier injection into the same template)
+			if !slices.ContainsFunc(matchExpressions, func(existing interface{}) bool { return reflect.DeepEqual(existing, obj) }) {
+				matchExpressions = append(matchExpressions, obj)
+			}
 		}
 		selTerm["matchExpressions"] = matchExpressions
 	}
@@ -166,8 +195,104 @@ func addNodeSelectorsToAffinity(spec map[string]interface{}, exprsToAdd []v1.Nod
 	return nil
 }
 
+// injectSharedScratch adds an emptyDir volume named SharedScratchVolumeName to spec and mounts it at mountPath into every
+// container and initContainer. An existing volume with that name is kept, and containers that already have a mount with
+// that name or at mountPath are left unchanged.
+func injectSharedScratch(spec map[string]interface{}, sizeLimit *kresource.Quantity, mountPath string) {
+	hasEntry := func(entries []interface{}, key string, value string) bool {
+		for _, entry := range entries {
+			if emap, ok := entry.(map[string]interface{}); ok && emap[key] == value {
+				return true
+			}
+		}
+		return false
+	}
+	volumes, _ := spec["volumes"].([]interface{})
+	if !hasEntry(volumes, "name", workloadv1beta2.SharedScratchVolumeName) {
+		emptyDir := map[string]interface{}{}
+		if sizeLimit != nil {
+			emptyDir["sizeLimit"] = sizeLimit.String()
+		}
+		spec["volumes"] = append(volumes, map[string]interface{}{"name": workloadv1beta2.SharedScratchVolumeName, "emptyDir": emptyDir})
+	}
+	for _, key := range []string{"initContainers", "containers"} {
+		containers, _ := spec[key].([]interface{})
+		for _, containerI := range containers {
+			container, ok := containerI.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			mounts, _ := container["volumeMounts"].([]interface{})
+			if hasEntry(mounts, "name", workloadv1beta2.SharedScratchVolumeName) || hasEntry(mounts, "mountPath", mountPath) {
+				continue
+			}
+			container["volumeMounts"] = append(mounts, map[string]interface{}{"name": workloadv1beta2.SharedScratchVolumeName, "mountPath": mountPath})
+		}
+	}
+}
+
+// invalidLabelValues returns the sorted keys of labels whose values are not valid label values
+func invalidLabelValues(labels map[string]string) []string {
+	invalid := []string{}
+	for key, value := range labels {
+		if len(validation.IsValidLabelValue(value)) > 0 {
+			invalid = append(invalid, key)
+		}
+	}
+	slices.Sort(invalid)
+	return invalid
+}
+
+// injectableLabels returns a copy of labels without the labels that have invalid values, which would cause
+// the creation of the component to be rejected.
+func injectableLabels(ctx context.Context, labels map[string]string) map[string]string {
+	result := utilmaps.MergeKeepFirst(nil, labels)
+	for _, key := range invalidLabelValues(labels) {
+		log.FromContext(ctx).Info("WARNING: not injecting label with an invalid value", "key", key, "value", labels[key],
+			"reason", strings.Join(validation.IsValidLabelValue(labels[key]), "; "))
+		delete(result, key)
+	}
+	return result
+}
+
+// withKeyPrefixes returns the entries of m whose keys start with one of prefixes
+func withKeyPrefixes(m map[string]string, prefixes []string) map[string]string {
+	result := map[string]string{}
+	for k, v := range m {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(k, prefix) {
+				result[k] = v
+				break
+			}
+		}
+	}
+	return result
+}
+
+// invalidInjectedLabels returns the sorted keys of the labels that will not be injected into the
+// PodSets of component because their values are invalid
+func invalidInjectedLabels(component workloadv1beta2.AppWrapperComponent, awConfig *config.AppWrapperConfig) []string {
+	invalid := sets.New(invalidLabelValues(awConfig.RequiredPodLabels)...)
+	if awConfig.EnableKueueIntegrations {
+		for _, psi := range component.PodSetInfos {
+			invalid.Insert(invalidLabelValues(psi.Labels)...)
+		}
+	}
+	return sets.List(invalid)
+}
+
+// RenderComponent returns the object that will be created for the componentIdx-th component of aw.
+// The returned object contains all labels, annotations, and PodSpec modifications that are injected
+// by the AppWrapper controller.  It does not have an owner reference to aw.  All returned errors are fatal.
+//
 //gocyclo:ignore
-func (r *AppWrapperReconciler) createComponent(ctx context.Context, aw *workloadv1beta2.AppWrapper, componentIdx int) (error, bool) {
+func RenderComponent(ctx context.Context, aw *workloadv1beta2.AppWrapper, componentIdx int, awConfig *config.AppWrapperConfig) (*unstructured.Unstructured, error) {
+	if err := utils.EnsureComponentStatusInitialized(aw); err != nil {
+		return nil, err
+	}
+	if componentIdx < 0 || componentIdx >= len(aw.Spec.Components) {
+		return nil, fmt.Errorf("component index %v out of range", componentIdx)
+	}
 	component := aw.Spec.Components[componentIdx]
 	componentStatus := aw.Status.ComponentStatus[componentIdx]
 	toMap := func(x interface{}) map[string]string {
@@ -195,45 +320,72 @@ func (r *AppWrapperReconciler) createComponent(ctx context.Context, aw *workload
 
 	obj, err := parseComponent(component.Template.Raw, aw.Namespace)
 	if err != nil {
-		return err, true
+		return nil, err
 	}
-	awLabels := map[string]string{workloadv1beta2.AppWrapperLabel: aw.Name}
+	awLabels := map[string]string{config.AppWrapperLabel(awConfig): aw.Name}
 	obj.SetLabels(utilmaps.MergeKeepFirst(obj.GetLabels(), awLabels))
+	if prefixes := awConfig.PropagatedMetadataPrefixes; len(prefixes) > 0 {
+		obj.SetLabels(utilmaps.MergeKeepFirst(obj.GetLabels(), withKeyPrefixes(aw.Labels, prefixes)))
+		if annotations := withKeyPrefixes(aw.Annotations, prefixes); len(annotations) > 0 {
+			obj.SetAnnotations(utilmaps.MergeKeepFirst(obj.GetAnnotations(), annotations))
+		}
+	}
+	podLabels := awLabels
+	if utils.IsServiceComponent(obj.GetAPIVersion(), obj.GetKind()) {
+		podLabels = utilmaps.MergeKeepFirst(awLabels, map[string]string{workloadv1beta2.ServiceComponentLabel: "true"})
+	}
+	priorityClassName := priorityClassNameFor(aw, awConfig)
+	schedulerName := schedulerNameFor(aw, awConfig)
+	var scratchSize *kresource.Quantity
+	scratchPath := ""
+	if value, ok := component.Annotations[workloadv1beta2.SharedScratchAnnotation]; ok {
+		if scratchSize, scratchPath, err = utils.ParseSharedScratch(value); err != nil {
+			return nil, fmt.Errorf("malformed %v annotation: %w", workloadv1beta2.SharedScratchAnnotation, err)
+		}
+	}
 
 	for podSetsIdx, podSet := range componentStatus.PodSets {
 		toInject := &workloadv1beta2.AppWrapperPodSetInfo{}
-		if r.Config.EnableKueueIntegrations {
+		if awConfig.EnableKueueIntegrations {
 			if podSetsIdx < len(component.PodSetInfos) {
 				toInject = &component.PodSetInfos[podSetsIdx]
 			} else {
-				return fmt.Errorf("missing podSetInfo %v for component %v", podSetsIdx, componentIdx), true
+				return nil, fmt.Errorf("missing podSetInfo %v for component %v", podSetsIdx, componentIdx)
 			}
 		}
 
 		p, err := utils.GetRawTemplate(obj.UnstructuredContent(), podSet.Path)
 		if err != nil {
-			return err, true // Should not happen, path validity is enforced by validateAppWrapperInvariants
+			return nil, err // Should not happen, path validity is enforced by validateAppWrapperInvariants
 		}
-		if md, ok := p["metadata"]; !ok || md == nil {
-			p["metadata"] = make(map[string]interface{})
+		metadata, spec, err := utils.PodTemplateParts(obj.GroupVersionKind(), p)
+		if err != nil {
+			return nil, err // Should not happen, spec must exist, enforced by validateAppWrapperInvariants
+		}
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+			p["metadata"] = metadata
 		}
-		metadata := p["metadata"].(map[string]interface{})
-		spec := p["spec"].(map[string]interface{}) // Must exist, enforced by validateAppWrapperInvariants
 
 		// Annotations
 		if len(toInject.Annotations) > 0 {
 			existing := toMap(metadata["annotations"])
 			if err := utilmaps.HaveConflict(existing, toInject.Annotations); err != nil {
-				return podset.BadPodSetsUpdateError("annotations", err), true
+				return nil, podset.BadPodSetsUpdateError("annotations", err)
 			}
 			metadata["annotations"] = utilmaps.MergeKeepFirst(existing, toInject.Annotations)
 		}
+		if len(awConfig.ObservabilityPodAnnotations) > 0 {
+			// annotations that are already present in the template are not overridden
+			metadata["annotations"] = utilmaps.MergeKeepFirst(toMap(metadata["annotations"]), awConfig.ObservabilityPodAnnotations)
+		}
 
 		// Labels
-		mergedLabels := utilmaps.MergeKeepFirst(toInject.Labels, awLabels)
+		podSetLabels := utilmaps.MergeKeepFirst(podLabels, map[string]string{workloadv1beta2.PodSetLabel: utils.PodSetLabelValue(componentIdx, podSetsIdx)})
+		mergedLabels := utilmaps.MergeKeepFirst(utilmaps.MergeKeepFirst(injectableLabels(ctx, toInject.Labels), podSetLabels), injectableLabels(ctx, awConfig.RequiredPodLabels))
 		existing := toMap(metadata["labels"])
 		if err := utilmaps.HaveConflict(existing, mergedLabels); err != nil {
-			return podset.BadPodSetsUpdateError("labels", err), true
+			return nil, podset.BadPodSetsUpdateError("labels", err)
 		}
 		metadata["labels"] = utilmaps.MergeKeepFirst(existing, mergedLabels)
 
@@ -241,7 +393,7 @@ func (r *AppWrapperReconciler) createComponent(ctx context.Context, aw *workload
 		if len(toInject.NodeSelector) > 0 {
 			existing := toMap(spec["nodeSelector"])
 			if err := utilmaps.HaveConflict(existing, toInject.NodeSelector); err != nil {
-				return podset.BadPodSetsUpdateError("nodeSelector", err), true
+				return nil, podset.BadPodSetsUpdateError("nodeSelector", err)
 			}
 			spec["nodeSelector"] = utilmaps.MergeKeepFirst(existing, toInject.NodeSelector)
 		}
@@ -283,17 +435,105 @@ func (r *AppWrapperReconciler) createComponent(ctx context.Context, aw *workload
 			spec["schedulingGates"] = schedulingGates
 		}
 
+		// ImagePullSecrets
+		if len(awConfig.ImagePullSecrets) > 0 {
+			imagePullSecrets, _ := spec["imagePullSecrets"].([]interface{}) // a missing, null, or malformed list is treated as empty
+			for _, addition := range awConfig.ImagePullSecrets {
+				duplicate := false
+				for _, existing := range imagePullSecrets {
+					if imap, ok := existing.(map[string]interface{}); ok {
+						if iName, ok := imap["name"]; ok {
+							if sName, ok := iName.(string); ok && sName == addition {
+								duplicate = true
+								break
+							}
+						}
+					}
+				}
+				if !duplicate {
+					imagePullSecrets = append(imagePullSecrets, map[string]interface{}{"name": addition})
+				}
+			}
+			spec["imagePullSecrets"] = imagePullSecrets
+		}
+
+		// Shared scratch volume
+		if scratchPath != "" {
+			injectSharedScratch(spec, scratchSize, scratchPath)
+		}
+
 		// Scheduler Name
-		if r.Config.SchedulerName != "" {
+		if schedulerName != "" {
 			if existing, _ := spec["schedulerName"].(string); existing == "" {
-				spec["schedulerName"] = r.Config.SchedulerName
+				spec["schedulerName"] = schedulerName
+			}
+		}
+
+		// Priority Class Name
+		if priorityClassName != "" {
+			if existing, _ := spec["priorityClassName"].(string); existing == "" {
+				spec["priorityClassName"] = priorityClassName
+			}
+		}
+
+		// Service Account Name; the deprecated serviceAccount field also counts as specifying one
+		if awConfig.DefaultServiceAccountName != "" {
+			existing, _ := spec["serviceAccountName"].(string)
+			deprecated, _ := spec["serviceAccount"].(string)
+			if existing == "" && deprecated == "" {
+				spec["serviceAccountName"] = awConfig.DefaultServiceAccountName
+			}
+		}
+
+		// Runtime Class Name; if RuntimeClassResources is configured, only injected into podSets that request one of them
+		if awConfig.RuntimeClassName != "" {
+			if existing, _ := spec["runtimeClassName"].(string); existing == "" {
+				inject := len(awConfig.RuntimeClassResources) == 0
+				for _, resource := range awConfig.RuntimeClassResources {
+					if hasResourceRequest(spec, resource, true) {
+						inject = true
+						break
+					}
+				}
+				if inject {
+					spec["runtimeClassName"] = awConfig.RuntimeClassName
+				}
 			}
 		}
 
-		if r.Config.Autopilot != nil && r.Config.Autopilot.InjectAntiAffinities {
+		// TopologySpreadConstraints; a constraint of the template with the same topologyKey and whenUnsatisfiable takes precedence
+		if awConfig.TopologySpread != nil && awConfig.TopologySpread.InjectConstraints && len(awConfig.TopologySpread.Constraints) > 0 {
+			constraints, _ := spec["topologySpreadConstraints"].([]interface{}) // a missing, null, or malformed list is treated as empty
+			matchLabels := map[string]interface{}{}
+			for k, v := range podSetLabels {
+				matchLabels[k] = v
+			}
+			for _, addition := range awConfig.TopologySpread.Constraints {
+				duplicate := false
+				for _, existing := range constraints {
+					if imap, ok := existing.(map[string]interface{}); ok {
+						if imap["topologyKey"] == addition.TopologyKey && imap["whenUnsatisfiable"] == string(addition.WhenUnsatisfiable) {
+							duplicate = true
+							break
+						}
+					}
+				}
+				if !duplicate {
+					constraints = append(constraints, map[string]interface{}{
+						"topologyKey":       addition.TopologyKey,
+						"maxSkew":           int64(addition.MaxSkew),
+						"whenUnsatisfiable": string(addition.WhenUnsatisfiable),
+						"labelSelector":     map[string]interface{}{"matchLabels": matchLabels},
+					})
+				}
+			}
+			spec["topologySpreadConstraints"] = constraints
+		}
+
+		if awConfig.Autopilot != nil && awConfig.Autopilot.InjectAntiAffinities {
 			toAdd := map[string][]string{}
-			for resource, taints := range r.Config.Autopilot.ResourceTaints {
-				if hasResourceRequest(spec, resource) {
+			for resource, taints := range awConfig.Autopilot.ResourceTaints {
+				if hasResourceRequest(spec, resource, !awConfig.Autopilot.IgnoreInitContainers) {
 					for _, taint := range taints {
 						toAdd[taint.Key] = append(toAdd[taint.Key], taint.Value)
 					}
@@ -311,60 +551,66 @@ func (r *AppWrapperReconciler) createComponent(ctx context.Context, aw *workload
 		}
 	}
 
-	if err := controllerutil.SetControllerReference(aw, obj, r.Scheme); err != nil {
-		return err, true
+	return obj, nil
+}
+
+// createComponent creates the componentIdx-th component of aw and patches aw.Status to record its creation.
+// aw is only accessed while holding awLock, so that several components may be created concurrently.
+func (r *AppWrapperReconciler) createComponent(ctx context.Context, aw *workloadv1beta2.AppWrapper, componentIdx int, awLock sync.Locker) (error, bool) {
+	awLock.Lock()
+	awName := aw.Name
+	obj, err, fatal := r.prepareComponent(ctx, aw, componentIdx)
+	awLock.Unlock()
+	if err != nil {
+		return err, fatal
 	}
 
-	orig := copyForStatusPatch(aw)
-	if meta.FindStatusCondition(aw.Status.ComponentStatus[componentIdx].Conditions, string(workloadv1beta2.ResourcesDeployed)) == nil {
-		aw.Status.ComponentStatus[componentIdx].Name = obj.GetName()
-		aw.Status.ComponentStatus[componentIdx].Kind = obj.GetKind()
-		aw.Status.ComponentStatus[componentIdx].APIVersion = obj.GetAPIVersion()
-		meta.SetStatusCondition(&aw.Status.ComponentStatus[componentIdx].Conditions, metav1.Condition{
-			Type:   string(workloadv1beta2.ResourcesDeployed),
-			Status: metav1.ConditionUnknown,
-			Reason: "ComponentCreationInitiated",
-		})
-		if err := r.Status().Patch(ctx, aw, client.MergeFrom(orig)); err != nil {
+	createErr := r.Create(ctx, obj)
+	if apierrors.IsAlreadyExists(createErr) {
+		// obj is not updated if Create returns an error; Get required for accurate information
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
 			return err, false
 		}
+		ctrlRef := metav1.GetControllerOf(obj)
+		if ctrlRef == nil || ctrlRef.Name != awName {
+			return fmt.Errorf("resource %v exists, but is not controlled by appwrapper", obj.GetName()), true
+		}
+		if !obj.GetDeletionTimestamp().IsZero() {
+			// A prior instance (eg a Failed bare Pod from before a reset) is still terminating; retry creation once it is gone
+			return fmt.Errorf("resource %v exists, but is still being deleted", obj.GetName()), false
+		}
+		// fall through.  This is not actually an error. The object already exists and the correct appwrapper owns it.
+		createErr = nil
 	}
 
-	if err := r.Create(ctx, obj); err != nil {
-		if apierrors.IsAlreadyExists(err) {
-			// obj is not updated if Create returns an error; Get required for accurate information
-			if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
-				return err, false
-			}
-			ctrlRef := metav1.GetControllerOf(obj)
-			if ctrlRef == nil || ctrlRef.Name != aw.Name {
-				return fmt.Errorf("resource %v exists, but is not controlled by appwrapper", obj.GetName()), true
-			}
-			// fall through.  This is not actually an error. The object already exists and the correct appwrapper owns it.
-		} else {
-			// resource not actually created; patch status to reflect that
-			orig := copyForStatusPatch(aw)
-			meta.SetStatusCondition(&aw.Status.ComponentStatus[componentIdx].Conditions, metav1.Condition{
-				Type:   string(workloadv1beta2.ResourcesDeployed),
-				Status: metav1.ConditionFalse,
-				Reason: "ComponentCreationErrored",
-			})
-			if patchErr := r.Status().Patch(ctx, aw, client.MergeFrom(orig)); patchErr != nil {
-				// ugh.  Patch failed, so retry the create so we can get to a consistient state
-				return patchErr, false
-			}
-			// return actual error
-			return err, meta.IsNoMatchError(err) || apierrors.IsInvalid(err) // fatal
+	awLock.Lock()
+	defer awLock.Unlock()
+	if createErr != nil {
+		// resource not actually created; patch status to reflect that
+		orig := copyForStatusPatch(aw)
+		meta.SetStatusCondition(&aw.Status.ComponentStatus[componentIdx].Conditions, metav1.Condition{
+			Type:   string(workloadv1beta2.ResourcesDeployed),
+			Status: metav1.ConditionFalse,
+			Reason: "ComponentCreationErrored",
+		})
+		aw.Status.DeployedComponents = deployedComponentCount(aw)
+		if patchErr := r.Status().Patch(ctx, aw, client.MergeFrom(orig)); patchErr != nil {
+			// ugh.  Patch failed, so retry the create so we can get to a consistient state
+			return patchErr, false
 		}
+		// return actual error
+		return createErr, meta.IsNoMatchError(createErr) || apierrors.IsInvalid(createErr) // fatal
 	}
 
-	orig = copyForStatusPatch(aw)
+	orig := copyForStatusPatch(aw)
 	aw.Status.ComponentStatus[componentIdx].Name = obj.GetName() // Update name to support usage of GenerateName
 	meta.SetStatusCondition(&aw.Status.ComponentStatus[componentIdx].Conditions, metav1.Condition{
 		Type:   string(workloadv1beta2.ResourcesDeployed),
 		Status: metav1.ConditionTrue,
 		Reason: "ComponentCreatedSuccessfully",
 	})
+	meta.RemoveStatusCondition(&aw.Status.ComponentStatus[componentIdx].Conditions, string(workloadv1beta2.DeletionComplete))
+	aw.Status.DeployedComponents = deployedComponentCount(aw)
 	if err := r.Status().Patch(ctx, aw, client.MergeFrom(orig)); err != nil {
 		return err, false
 	}
@@ -372,19 +618,158 @@ func (r *AppWrapperReconciler) createComponent(ctx context.Context, aw *workload
 	return nil, false
 }
 
+// prepareComponent renders the componentIdx-th component of aw and records in aw.Status that its creation has been initiated
+func (r *AppWrapperReconciler) prepareComponent(ctx context.Context, aw *workloadv1beta2.AppWrapper, componentIdx int) (*unstructured.Unstructured, error, bool) {
+	obj, err := RenderComponent(ctx, aw, componentIdx, r.Config)
+	if err != nil {
+		return nil, err, true
+	}
+
+	if err := controllerutil.SetControllerReference(aw, obj, r.Scheme); err != nil {
+		return nil, err, true
+	}
+	if invalid := invalidInjectedLabels(aw.Spec.Components[componentIdx], r.Config); len(invalid) > 0 {
+		r.Recorder.Eventf(aw, v1.EventTypeWarning, "InvalidLabelValue",
+			"Labels %v were not injected into component %v because their values are not valid label values", strings.Join(invalid, ", "), componentIdx)
+	}
+
+	if meta.FindStatusCondition(aw.Status.ComponentStatus[componentIdx].Conditions, string(workloadv1beta2.ResourcesDeployed)) == nil {
+		orig := copyForStatusPatch(aw)
+		aw.Status.ComponentStatus[componentIdx].Name = obj.GetName()
+		aw.Status.ComponentStatus[componentIdx].Kind = obj.GetKind()
+		aw.Status.ComponentStatus[componentIdx].APIVersion = obj.GetAPIVersion()
+		meta.SetStatusCondition(&aw.Status.ComponentStatus[componentIdx].Conditions, metav1.Condition{
+			Type:   string(workloadv1beta2.ResourcesDeployed),
+			Status: metav1.ConditionUnknown,
+			Reason: "ComponentCreationInitiated",
+		})
+		if err := r.Status().Patch(ctx, aw, client.MergeFrom(orig)); err != nil {
+			return nil, err, false
+		}
+	}
+	return obj, nil, false
+}
+
+// deployedComponentCount returns the number of components whose ResourcesDeployed condition is true
+func deployedComponentCount(aw *workloadv1beta2.AppWrapper) int32 {
+	count := int32(0)
+	for _, cs := range aw.Status.ComponentStatus {
+		if meta.IsStatusConditionTrue(cs.Conditions, string(workloadv1beta2.ResourcesDeployed)) {
+			count += 1
+		}
+	}
+	return count
+}
+
 // createComponents incrementally patches aw.Status -- MUST NOT CARRY STATUS PATCHES ACROSS INVOCATIONS
+// If the MaxReconcileDuration elapses, createComponents returns without creating the remaining components;
+// at least one component is created by every invocation so that progress is always made.
+// Up to ComponentCreationConcurrency components of a stage are created at once, and every stage is
+// completed before the next one is started. Once a creation has failed no further creations are started;
+// if several fail, a fatal error takes precedence and ties are broken by creation order.
 func (r *AppWrapperReconciler) createComponents(ctx context.Context, aw *workloadv1beta2.AppWrapper) (error, bool) {
-	for componentIdx := range aw.Spec.Components {
-		if !meta.IsStatusConditionTrue(aw.Status.ComponentStatus[componentIdx].Conditions, string(workloadv1beta2.ResourcesDeployed)) {
-			if err, fatal := r.createComponent(ctx, aw, componentIdx); err != nil {
-				return err, fatal
+	start := time.Now()
+	created := 0
+	stopped := false
+	awLock := &sync.Mutex{}
+	for _, stage := range r.componentCreationStages(aw) {
+		results := make([]struct {
+			err   error
+			fatal bool
+		}, len(stage))
+		group := errgroup.Group{}
+		group.SetLimit(max(r.Config.ComponentCreationConcurrency, 1))
+		for i, componentIdx := range stage {
+			group.Go(func() error {
+				awLock.Lock()
+				if stopped || meta.IsStatusConditionTrue(aw.Status.ComponentStatus[componentIdx].Conditions, string(workloadv1beta2.ResourcesDeployed)) {
+					awLock.Unlock()
+					return nil
+				}
+				if created > 0 && r.Config.MaxReconcileDuration > 0 && time.Since(start) > r.Config.MaxReconcileDuration {
+					log.FromContext(ctx).Info("MaxReconcileDuration exceeded; deferring creation of remaining components",
+						"created", created, "deployed", deployedComponentCount(aw), "expected", len(aw.Spec.Components))
+					stopped = true
+					awLock.Unlock()
+					return nil
+				}
+				created += 1
+				awLock.Unlock()
+
+				err, fatal := r.createComponent(ctx, aw, componentIdx, awLock)
+				if err != nil {
+					awLock.Lock()
+					stopped = true
+					awLock.Unlock()
+				}
+				results[i].err, results[i].fatal = err, fatal
+				return nil
+			})
+		}
+		_ = group.Wait() // errors are reported in results
+		var firstErr error
+		for _, result := range results {
+			if result.err != nil && result.fatal {
+				return result.err, true
+			}
+			if firstErr == nil {
+				firstErr = result.err
 			}
 		}
+		if firstErr != nil {
+			return firstErr, false
+		}
+		if stopped {
+			return nil, false
+		}
 	}
 	return nil, false
 }
 
-func (r *AppWrapperReconciler) deleteComponents(ctx context.Context, aw *workloadv1beta2.AppWrapper) bool {
+// componentCreationStages returns the indices of the components of aw grouped into the stages in which they should be created.
+// When CreatePodlessComponentsFirst is enabled, components without PodSets (Services, ConfigMaps, etc.)
+// are created in a stage before the pod-bearing components so that workloads find their dependencies on startup.
+func (r *AppWrapperReconciler) componentCreationStages(aw *workloadv1beta2.AppWrapper) [][]int {
+	order := make([]int, 0, len(aw.Spec.Components))
+	podBearing := make([]int, 0, len(aw.Spec.Components))
+	for componentIdx := range aw.Spec.Components {
+		if r.Config.CreatePodlessComponentsFirst && len(aw.Status.ComponentStatus[componentIdx].PodSets) > 0 {
+			podBearing = append(podBearing, componentIdx)
+		} else {
+			order = append(order, componentIdx)
+		}
+	}
+	return [][]int{order, podBearing}
+}
+
+// removeAdmissionGate removes the configured admission gate from the Pods of aw, leaving all other scheduling gates in place
+func (r *AppWrapperReconciler) removeAdmissionGate(ctx context.Context, aw *workloadv1beta2.AppWrapper) error {
+	pods := &v1.PodList{}
+	if err := r.List(ctx, pods,
+		client.InNamespace(aw.Namespace),
+		client.MatchingLabels{config.AppWrapperLabel(r.Config): aw.Name}); err != nil {
+		return err
+	}
+	gate := r.Config.AdmissionGateName
+	for _, pod := range pods.Items {
+		idx := slices.IndexFunc(pod.Spec.SchedulingGates, func(g v1.PodSchedulingGate) bool { return g.Name == gate })
+		if idx < 0 {
+			continue
+		}
+		orig := pod.DeepCopy()
+		pod.Spec.SchedulingGates = slices.Delete(pod.Spec.SchedulingGates, idx, idx+1)
+		if err := r.Patch(ctx, &pod, client.MergeFrom(orig)); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		log.FromContext(ctx).Info("Removed admission gate", "pod", pod.Name, "gate", gate)
+	}
+	return nil
+}
+
+// deleteComponents deletes the wrapped resources of aw, recording the reason for their deletion in its status.
+// It returns true once all of the resources are gone.
+func (r *AppWrapperReconciler) deleteComponents(ctx context.Context, aw *workloadv1beta2.AppWrapper, reason workloadv1beta2.AppWrapperDeletionReason) bool {
+	aw.Status.DeletionReason = reason
 	deleteIfPresent := func(idx int, opts ...client.DeleteOption) bool {
 		cs := &aw.Status.ComponentStatus[idx]
 		rd := meta.FindStatusCondition(cs.Conditions, string(workloadv1beta2.ResourcesDeployed))
@@ -396,22 +781,36 @@ func (r *AppWrapperReconciler) deleteComponents(ctx context.Context, aw *workloa
 			ObjectMeta: metav1.ObjectMeta{Name: cs.Name, Namespace: aw.Namespace},
 		}
 		if err := r.Delete(ctx, obj, opts...); err != nil {
-			if apierrors.IsNotFound(err) {
+			if isComponentGone(err) {
 				// Has already been undeployed; update componentStatus and return not present
 				meta.SetStatusCondition(&cs.Conditions, metav1.Condition{
 					Type:   string(workloadv1beta2.ResourcesDeployed),
 					Status: metav1.ConditionFalse,
 					Reason: "CompononetDeleted",
 				})
+				meta.SetStatusCondition(&cs.Conditions, metav1.Condition{
+					Type:   string(workloadv1beta2.DeletionComplete),
+					Status: metav1.ConditionTrue,
+					Reason: "ComponentDeleted",
+				})
 				return false
 			} else {
 				log.FromContext(ctx).Error(err, "Deletion error")
 				return true // unexpected error ==> still present
 			}
 		}
+		meta.SetStatusCondition(&cs.Conditions, metav1.Condition{
+			Type:   string(workloadv1beta2.DeletionComplete),
+			Status: metav1.ConditionFalse,
+			Reason: "DeletionInProgress",
+		})
 		return true // still present
 	}
 
+	// forceful deletion is only reported when this deletion first escalates to it
+	alreadyForceful := meta.IsStatusConditionTrue(aw.Status.Conditions, string(workloadv1beta2.DeletingResources)) &&
+		meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeletingResources)).Reason == "ForcefulDeletion"
+
 	meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
 		Type:   string(workloadv1beta2.DeletingResources),
 		Status: metav1.ConditionTrue,
@@ -419,11 +818,27 @@ func (r *AppWrapperReconciler) deleteComponents(ctx context.Context, aw *workloa
 	})
 
 	componentsRemaining := false
+	propagation := r.deletionPropagation(ctx, aw)
+	propagationPolicy := client.PropagationPolicy(propagation)
 	for componentIdx := range aw.Spec.Components {
-		componentsRemaining = deleteIfPresent(componentIdx, client.PropagationPolicy(metav1.DeletePropagationBackground)) || componentsRemaining
+		componentsRemaining = deleteIfPresent(componentIdx, propagationPolicy) || componentsRemaining
+	}
+
+	if propagation == metav1.DeletePropagationOrphan {
+		// The Pods of orphaned components are deliberately left in place; neither wait for them nor forcefully delete them
+		if componentsRemaining {
+			return false
+		}
+		clearCondition(aw, workloadv1beta2.DeletingResources, "DeletionComplete", "")
+		aw.Status.DeployedComponents = 0
+		return true
 	}
 
 	deletionGracePeriod := r.forcefulDeletionGraceDuration(ctx, aw)
+	if hasSucceeded(aw) {
+		// Cleanup after success has its own grace period, including when a Succeeded AppWrapper is deleted
+		deletionGracePeriod = r.successDeletionGraceDuration(ctx, aw)
+	}
 	whenInitiated := meta.FindStatusCondition(aw.Status.Conditions, string(workloadv1beta2.DeletingResources)).LastTransitionTime
 	gracePeriodExpired := time.Now().After(whenInitiated.Time.Add(deletionGracePeriod))
 
@@ -436,17 +851,59 @@ func (r *AppWrapperReconciler) deleteComponents(ctx context.Context, aw *workloa
 	if err := r.List(ctx, pods,
 		client.UnsafeDisableDeepCopy,
 		client.InNamespace(aw.Namespace),
-		client.MatchingLabels{workloadv1beta2.AppWrapperLabel: aw.Name}); err != nil {
+		client.MatchingLabels{config.AppWrapperLabel(r.Config): aw.Name}); err != nil {
 		log.FromContext(ctx).Error(err, "Pod list error")
 	}
 
+	if r.Config.FaultTolerance.IgnoreTerminatedPods {
+		// Terminated pods hold no resources; opportunistically remove them without waiting for them to be gone
+		deleteOpts := []client.DeleteOption{client.GracePeriodSeconds(0)}
+		if r.forcefulDeletionDisabled(ctx, aw) {
+			deleteOpts = []client.DeleteOption{}
+		}
+		activePods := []v1.Pod{}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+				if err := r.Delete(ctx, &pod, deleteOpts...); err != nil && !apierrors.IsNotFound(err) {
+					log.FromContext(ctx).Error(err, "Terminated pod deletion error")
+				}
+			} else {
+				activePods = append(activePods, pod)
+			}
+		}
+		pods.Items = activePods
+	}
+
 	if !componentsRemaining && len(pods.Items) == 0 {
 		// no resources or pods left; deletion is complete
 		clearCondition(aw, workloadv1beta2.DeletingResources, "DeletionComplete", "")
+		aw.Status.DeployedComponents = 0
 		return true
 	}
 
+	if gracePeriodExpired && r.forcefulDeletionDisabled(ctx, aw) {
+		// Never escalate to forceful deletion; keep waiting for graceful deletion to complete
+		meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+			Type:    string(workloadv1beta2.DeletingResources),
+			Status:  metav1.ConditionTrue,
+			Reason:  "ForcefulDeletionDisabled",
+			Message: fmt.Sprintf("%v is set; waiting for graceful deletion to complete", workloadv1beta2.NoForcefulDeletionAnnotation),
+		})
+		return false
+	}
+
 	if gracePeriodExpired {
+		meta.SetStatusCondition(&aw.Status.Conditions, metav1.Condition{
+			Type:    string(workloadv1beta2.DeletingResources),
+			Status:  metav1.ConditionTrue,
+			Reason:  "ForcefulDeletion",
+			Message: fmt.Sprintf("Deletion grace period of %v expired", deletionGracePeriod),
+		})
+		if !alreadyForceful {
+			metrics.AppWrapperForcefulDeletionCounter.WithLabelValues(aw.Namespace).Inc()
+			r.Recorder.Eventf(aw, v1.EventTypeWarning, "ForcefulDeletion", "Deletion grace period of %v expired; forcefully deleting %v remaining pods",
+				deletionGracePeriod, len(pods.Items))
+		}
 		if len(pods.Items) > 0 {
 			// force deletion of pods first
 			for _, pod := range pods.Items {
@@ -457,7 +914,7 @@ func (r *AppWrapperReconciler) deleteComponents(ctx context.Context, aw *workloa
 		} else {
 			// force deletion of wrapped resources once pods are gone
 			for componentIdx := range aw.Spec.Components {
-				_ = deleteIfPresent(componentIdx, client.GracePeriodSeconds(0))
+				_ = deleteIfPresent(componentIdx, client.GracePeriodSeconds(0), propagationPolicy)
 			}
 		}
 	}
@@ -465,3 +922,48 @@ func (r *AppWrapperReconciler) deleteComponents(ctx context.Context, aw *workloa
 	// requeue deletion
 	return false
 }
+
+// isComponentGone returns true if err shows that a component no longer exists, either because it was
+// deleted or because its resource type was uninstalled (eg the CRD of a wrapped custom resource was removed)
+func isComponentGone(err error) bool {
+	return apierrors.IsNotFound(err) || meta.IsNoMatchError(err)
+}
+
+// orphanComponents removes the owner references from all deployed components so that they
+// are not garbage collected when the AppWrapper is deleted. Returns true if all components were orphaned.
+func (r *AppWrapperReconciler) orphanComponents(ctx context.Context, aw *workloadv1beta2.AppWrapper) bool {
+	allOrphaned := true
+	for idx := range aw.Status.ComponentStatus {
+		cs := &aw.Status.ComponentStatus[idx]
+		rd := meta.FindStatusCondition(cs.Conditions, string(workloadv1beta2.ResourcesDeployed))
+		if rd == nil || rd.Status == metav1.ConditionFalse || cs.Name == "" {
+			continue // not present
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(cs.APIVersion)
+		obj.SetKind(cs.Kind)
+		if err := r.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: aw.Namespace}, obj); err != nil && !isComponentGone(err) {
+			log.FromContext(ctx).Error(err, "Orphaning error", "kind", cs.Kind, "name", cs.Name)
+			allOrphaned = false
+			continue
+		} else if err == nil {
+			// only remove the AppWrapper's ownerReference; those of other controllers are preserved
+			orig := obj.DeepCopy()
+			refs := slices.DeleteFunc(obj.GetOwnerReferences(), func(ref metav1.OwnerReference) bool { return ref.UID == aw.UID })
+			if len(refs) != len(orig.GetOwnerReferences()) {
+				obj.SetOwnerReferences(refs)
+				if err := r.Patch(ctx, obj, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})); err != nil && !isComponentGone(err) {
+					log.FromContext(ctx).Error(err, "Orphaning error", "kind", cs.Kind, "name", cs.Name)
+					allOrphaned = false
+					continue
+				}
+			}
+		}
+		meta.SetStatusCondition(&cs.Conditions, metav1.Condition{
+			Type:   string(workloadv1beta2.ResourcesDeployed),
+			Status: metav1.ConditionFalse,
+			Reason: "ComponentOrphaned",
+		})
+	}
+	return allOrphaned
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package metrics
 
 import (
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
@@ -28,8 +31,114 @@ var (
 			Help: `The total number of times an appwrapper transitioned to a given phase per namespace.`,
 		}, []string{"namespace", "phase"},
 	)
+	AppWrapperForcefulDeletionCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "appwrapper_forceful_deletions_total",
+			Help: `The total number of times the deletion of an appwrapper's resources was escalated to forceful deletion per namespace.`,
+		}, []string{"namespace"},
+	)
+	// AppWrapperComponentsGauge keeps its requested name appwrapper_components_total despite being a gauge
+	AppWrapperComponentsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "appwrapper_components_total",
+			Help: `The number of deployed components of a given kind per phase of their appwrapper.`,
+		}, []string{"gvk", "phase"},
+	)
+	AppWrapperQuotaReservedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "appwrapper_quota_reserved",
+			Help: `The number of appwrappers holding quota per namespace, user, and queue.`,
+		}, []string{"namespace", "user", "queue"},
+	)
 )
 
 func Register() {
 	metrics.Registry.MustRegister(AppWrapperPhaseCounter)
+	metrics.Registry.MustRegister(AppWrapperForcefulDeletionCounter)
+	metrics.Registry.MustRegister(AppWrapperComponentsGauge)
+	metrics.Registry.MustRegister(AppWrapperQuotaReservedGauge)
+}
+
+type componentKey struct {
+	gvk   string
+	phase string
+}
+
+// componentCounts remembers the contribution of each appwrapper to AppWrapperComponentsGauge
+var componentCounts = struct {
+	sync.Mutex
+	byAppWrapper map[types.NamespacedName]map[componentKey]float64
+}{byAppWrapper: map[types.NamespacedName]map[componentKey]float64{}}
+
+// RecordComponents replaces the contribution of the appwrapper aw to AppWrapperComponentsGauge
+// with one deployed component in phase for each entry in gvks
+func RecordComponents(aw types.NamespacedName, phase string, gvks []string) {
+	counts := map[componentKey]float64{}
+	for _, gvk := range gvks {
+		counts[componentKey{gvk: gvk, phase: phase}] += 1
+	}
+	componentCounts.Lock()
+	defer componentCounts.Unlock()
+	updateComponentsGauge(componentCounts.byAppWrapper[aw], counts)
+	if len(counts) == 0 {
+		delete(componentCounts.byAppWrapper, aw)
+	} else {
+		componentCounts.byAppWrapper[aw] = counts
+	}
+}
+
+// ForgetComponents removes the contribution of the appwrapper aw from AppWrapperComponentsGauge
+func ForgetComponents(aw types.NamespacedName) {
+	RecordComponents(aw, "", nil)
+}
+
+func updateComponentsGauge(prev map[componentKey]float64, next map[componentKey]float64) {
+	for k, v := range prev {
+		if next[k] != v {
+			AppWrapperComponentsGauge.WithLabelValues(k.gvk, k.phase).Sub(v)
+		}
+	}
+	for k, v := range next {
+		if prev[k] != v {
+			AppWrapperComponentsGauge.WithLabelValues(k.gvk, k.phase).Add(v)
+		}
+	}
+}
+
+type quotaReservedKey struct {
+	namespace string
+	user      string
+	queue     string
+}
+
+// quotaReservedKeys remembers the contribution of each appwrapper to AppWrapperQuotaReservedGauge
+var quotaReservedKeys = struct {
+	sync.Mutex
+	byAppWrapper map[types.NamespacedName]quotaReservedKey
+}{byAppWrapper: map[types.NamespacedName]quotaReservedKey{}}
+
+// RecordQuotaReserved replaces the contribution of the appwrapper aw to AppWrapperQuotaReservedGauge
+// with a single appwrapper of the given user and queue
+func RecordQuotaReserved(aw types.NamespacedName, user string, queue string) {
+	next := quotaReservedKey{namespace: aw.Namespace, user: user, queue: queue}
+	quotaReservedKeys.Lock()
+	defer quotaReservedKeys.Unlock()
+	if prev, ok := quotaReservedKeys.byAppWrapper[aw]; ok {
+		if prev == next {
+			return
+		}
+		AppWrapperQuotaReservedGauge.WithLabelValues(prev.namespace, prev.user, prev.queue).Dec()
+	}
+	AppWrapperQuotaReservedGauge.WithLabelValues(next.namespace, next.user, next.queue).Inc()
+	quotaReservedKeys.byAppWrapper[aw] = next
+}
+
+// ForgetQuotaReserved removes the contribution of the appwrapper aw from AppWrapperQuotaReservedGauge
+func ForgetQuotaReserved(aw types.NamespacedName) {
+	quotaReservedKeys.Lock()
+	defer quotaReservedKeys.Unlock()
+	if prev, ok := quotaReservedKeys.byAppWrapper[aw]; ok {
+		AppWrapperQuotaReservedGauge.WithLabelValues(prev.namespace, prev.user, prev.queue).Dec()
+		delete(quotaReservedKeys.byAppWrapper, aw)
+	}
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package webhook
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"time"
@@ -24,11 +25,14 @@ import (
 	. "github.com/onsi/gomega"
 
 	workloadv1beta2 "github.com/project-codeflare/appwrapper/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/yaml"
 )
 
@@ -73,29 +77,56 @@ spec:
       requests:
         cpu: %v`
 
-func pod(milliCPU int64) workloadv1beta2.AppWrapperComponent {
+// pod returns a Pod component with a declared PodSet; the Pod can be modified by mutate
+func pod(milliCPU int64, mutate ...func(*corev1.Pod)) workloadv1beta2.AppWrapperComponent {
+	component := podForInference(milliCPU, mutate...)
+	component.DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{{Path: "template"}}
+	return component
+}
+
+// podForInference returns a Pod component whose PodSets are inferred; the Pod can be modified by mutate
+func podForInference(milliCPU int64, mutate ...func(*corev1.Pod)) workloadv1beta2.AppWrapperComponent {
 	yamlString := fmt.Sprintf(podYAML,
 		randName("pod"),
 		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
 
-	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
-	Expect(err).NotTo(HaveOccurred())
 	return workloadv1beta2.AppWrapperComponent{
-		DeclaredPodSets: []workloadv1beta2.AppWrapperPodSet{{Path: "template"}},
-		Template:        runtime.RawExtension{Raw: jsonBytes},
+		Template: runtime.RawExtension{Raw: encodeTemplate(yamlString, mutate...)},
 	}
 }
 
-func podForInference(milliCPU int64) workloadv1beta2.AppWrapperComponent {
-	yamlString := fmt.Sprintf(podYAML,
-		randName("pod"),
-		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+// withGenerateName replaces the name of a Pod by a generateName
+func withGenerateName(prefix string) func(*corev1.Pod) {
+	return func(p *corev1.Pod) {
+		p.Name = ""
+		p.GenerateName = prefix
+	}
+}
+
+// withGPUs makes the container of a Pod request numGPU GPUs
+func withGPUs(numGPU int64) func(*corev1.Pod) {
+	return func(p *corev1.Pod) {
+		gpus := *resource.NewQuantity(numGPU, resource.DecimalSI)
+		p.Spec.Containers[0].Resources.Requests["nvidia.com/gpu"] = gpus
+		p.Spec.Containers[0].Resources.Limits = corev1.ResourceList{"nvidia.com/gpu": gpus}
+	}
+}
 
+// encodeTemplate returns the JSON encoding of yamlString; if mutate is given, the encoded object of type T is modified by it
+func encodeTemplate[T any](yamlString string, mutate ...func(*T)) []byte {
 	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
 	Expect(err).NotTo(HaveOccurred())
-	return workloadv1beta2.AppWrapperComponent{
-		Template: runtime.RawExtension{Raw: jsonBytes},
+	if len(mutate) == 0 {
+		return jsonBytes
 	}
+	obj := new(T)
+	Expect(json.Unmarshal(jsonBytes, obj)).To(Succeed())
+	for _, m := range mutate {
+		m(obj)
+	}
+	jsonBytes, err = json.Marshal(obj)
+	Expect(err).NotTo(HaveOccurred())
+	return jsonBytes
 }
 
 const namespacedPodYAML = `
@@ -204,6 +235,43 @@ func deploymentForInference(replicaCount int, milliCPU int64) workloadv1beta2.Ap
 	}
 }
 
+const daemonSetYAML = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: %v
+  labels:
+    app: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      terminationGracePeriodSeconds: 0
+      containers:
+      - name: busybox
+        image: quay.io/project-codeflare/busybox:1.36
+        command: ["sh", "-c", "sleep 10000"]
+        resources:
+          requests:
+            cpu: %v`
+
+func daemonSetForInference(milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(daemonSetYAML,
+		randName("daemonset"),
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
 const rayClusterYAML = `
 apiVersion: ray.io/v1
 kind: RayCluster
@@ -497,6 +565,53 @@ func jobForInference(parallelism int, completions int, milliCPU int64) workloadv
 	}
 }
 
+const cronJobYAML = `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: %v
+spec:
+  schedule: "*/10 * * * *"
+  jobTemplate:
+    spec:
+      parallelism: %v
+      completions: %v
+      template:
+        spec:
+          restartPolicy: Never
+          containers:
+          - name: busybox
+            image: quay.io/project-codeflare/busybox:1.36
+            command: ["sh", "-c", "sleep 30"]
+            resources:
+              requests:
+                cpu: %v`
+
+func cronJobForInference(parallelism int, completions int, milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(cronJobYAML,
+		randName("cronjob"),
+		parallelism,
+		completions,
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+// withAPIVersion replaces the apiVersion of the template of component
+func withAPIVersion(component workloadv1beta2.AppWrapperComponent, apiVersion string) workloadv1beta2.AppWrapperComponent {
+	obj := &unstructured.Unstructured{}
+	Expect(obj.UnmarshalJSON(component.Template.Raw)).To(Succeed())
+	obj.SetAPIVersion(apiVersion)
+	jsonBytes, err := obj.MarshalJSON()
+	Expect(err).NotTo(HaveOccurred())
+	component.Template = runtime.RawExtension{Raw: jsonBytes}
+	return component
+}
+
 const pytorchJobYAML = `
 apiVersion: "kubeflow.org/v1"
 kind: PyTorchJob
@@ -588,3 +703,290 @@ func rayJobForInference(workerCount int, milliCPU int64) workloadv1beta2.AppWrap
 		Template: runtime.RawExtension{Raw: jsonBytes},
 	}
 }
+
+const rayServiceYAML = `
+apiVersion: ray.io/v1
+kind: RayService
+metadata:
+  name: %v
+spec:
+  rayClusterConfig:
+    headGroupSpec:
+      template:
+        spec:
+          containers:
+            - name: ray-head
+              image: rayproject/ray:2.9.0
+              resources:
+                requests:
+                  cpu: 1
+    workerGroupSpecs:
+      - replicas: %v
+        template:
+          spec:
+            containers:
+              - name: ray-worker
+                image: rayproject/ray:2.9.0
+                resources:
+                  requests:
+                    cpu: %v
+`
+
+func rayServiceForInference(workerCount int, milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(rayServiceYAML,
+		randName("rayservice"),
+		workerCount,
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const leaderWorkerSetYAML = `
+apiVersion: leaderworkerset.x-k8s.io/v1
+kind: LeaderWorkerSet
+metadata:
+  name: %v
+spec:
+  replicas: %v
+  leaderWorkerTemplate:
+    size: %v
+    leaderTemplate:
+      spec:
+        containers:
+        - name: leader
+          image: quay.io/project-codeflare/busybox:1.36
+          resources:
+            requests:
+              cpu: %v
+    workerTemplate:
+      spec:
+        containers:
+        - name: worker
+          image: quay.io/project-codeflare/busybox:1.36
+          resources:
+            requests:
+              cpu: %v
+`
+
+func leaderWorkerSet(replicas int, size int, milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(leaderWorkerSetYAML,
+		randName("lws"),
+		replicas,
+		size,
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const inferenceServiceYAML = `
+apiVersion: serving.kserve.io/v1beta1
+kind: InferenceService
+metadata:
+  name: %v
+spec:
+  predictor:
+    minReplicas: %v
+    containers:
+    - name: kserve-container
+      image: quay.io/project-codeflare/busybox:1.36
+      resources:
+        requests:
+          cpu: %v
+  transformer:
+    containers:
+    - name: kserve-container
+      image: quay.io/project-codeflare/busybox:1.36
+      resources:
+        requests:
+          cpu: %v
+`
+
+func inferenceServiceForInference(minReplicas int, milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(inferenceServiceYAML,
+		randName("isvc"),
+		minReplicas,
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const modelInferenceServiceYAML = `
+apiVersion: serving.kserve.io/v1beta1
+kind: InferenceService
+metadata:
+  name: %v
+spec:
+  predictor:
+    model:
+      modelFormat:
+        name: sklearn
+      storageUri: gs://kfserving-examples/models/sklearn/1.0/model
+      resources:
+        requests:
+          cpu: %v
+`
+
+func modelInferenceServiceForInference(milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(modelInferenceServiceYAML,
+		randName("isvc"),
+		resource.NewMilliQuantity(milliCPU, resource.DecimalSI))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const noContainersInferenceServiceYAML = `
+apiVersion: serving.kserve.io/v1beta1
+kind: InferenceService
+metadata:
+  name: %v
+spec:
+  predictor:
+    minReplicas: 1
+`
+
+func noContainersInferenceService() workloadv1beta2.AppWrapperComponent {
+	yamlString := fmt.Sprintf(noContainersInferenceServiceYAML, randName("isvc"))
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const tfJobYAML = `
+apiVersion: kubeflow.org/v1
+kind: TFJob
+metadata:
+  name: %v
+spec:
+  tfReplicaSpecs:
+    Chief:
+      template:
+        spec:
+          containers:
+          - name: tensorflow
+            image: quay.io/project-codeflare/busybox:1.36
+            resources:
+              requests:
+                cpu: %v
+    PS:
+      replicas: %v
+      template:
+        spec:
+          containers:
+          - name: tensorflow
+            image: quay.io/project-codeflare/busybox:1.36
+            resources:
+              requests:
+                cpu: %v
+    Worker:
+      replicas: %v
+      template:
+        spec:
+          containers:
+          - name: tensorflow
+            image: quay.io/project-codeflare/busybox:1.36
+            resources:
+              requests:
+                cpu: %v
+`
+
+func tfJobForInference(psReplicas int, workerReplicas int, milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	cpu := resource.NewMilliQuantity(milliCPU, resource.DecimalSI)
+	yamlString := fmt.Sprintf(tfJobYAML,
+		randName("tfjob"),
+		cpu,
+		psReplicas, cpu,
+		workerReplicas, cpu)
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+const xgboostJobYAML = `
+apiVersion: kubeflow.org/v1
+kind: XGBoostJob
+metadata:
+  name: %v
+spec:
+  xgbReplicaSpecs:
+    Master:
+      replicas: 1
+      template:
+        spec:
+          containers:
+          - name: xgboost
+            image: quay.io/project-codeflare/busybox:1.36
+            resources:
+              requests:
+                cpu: %v
+    Worker:
+      replicas: %v
+      template:
+        spec:
+          containers:
+          - name: xgboost
+            image: quay.io/project-codeflare/busybox:1.36
+            resources:
+              requests:
+                cpu: %v
+`
+
+func xgboostJobForInference(workerReplicas int, milliCPU int64) workloadv1beta2.AppWrapperComponent {
+	cpu := resource.NewMilliQuantity(milliCPU, resource.DecimalSI)
+	yamlString := fmt.Sprintf(xgboostJobYAML,
+		randName("xgboostjob"),
+		cpu,
+		workerReplicas, cpu)
+
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	Expect(err).NotTo(HaveOccurred())
+	return workloadv1beta2.AppWrapperComponent{
+		Template: runtime.RawExtension{Raw: jsonBytes},
+	}
+}
+
+func clusterQueue(name string, coveredResources ...corev1.ResourceName) *kueue.ClusterQueue {
+	quotas := []kueue.ResourceQuota{}
+	for _, r := range coveredResources {
+		quotas = append(quotas, kueue.ResourceQuota{Name: r, NominalQuota: resource.MustParse("8")})
+	}
+	return &kueue.ClusterQueue{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kueue.ClusterQueueSpec{
+			ResourceGroups: []kueue.ResourceGroup{{
+				CoveredResources: coveredResources,
+				Flavors:          []kueue.FlavorQuotas{{Name: "default-flavor", Resources: quotas}},
+			}},
+		},
+	}
+}
+
+func localQueue(name string, namespace string, clusterQueue string) *kueue.LocalQueue {
+	return &kueue.LocalQueue{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       kueue.LocalQueueSpec{ClusterQueue: kueue.ClusterQueueReference(clusterQueue)},
+	}
+}
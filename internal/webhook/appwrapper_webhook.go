@@ -20,17 +20,27 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
+	"golang.org/x/sync/errgroup"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	discovery "k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	authClientv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/utils/ptr"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -39,6 +49,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 
 	workloadv1beta2 "github.com/project-codeflare/appwrapper/api/v1beta2"
@@ -48,27 +59,54 @@ import (
 )
 
 const (
-	AppWrapperUsernameLabel = "workload.codeflare.dev/user"
+	AppWrapperUsernameLabel = workloadv1beta2.UsernameLabel
 	AppWrapperUserIDLabel   = "workload.codeflare.dev/userid"
 	QueueNameLabel          = "kueue.x-k8s.io/queue-name"
+	WorkloadPriorityLabel   = "kueue.x-k8s.io/priority-class"
 )
 
 type rbacACSupport struct {
 	discoveryClient       *discovery.DiscoveryClient
 	subjectAccessReviewer authClientv1.SubjectAccessReviewInterface
-	kindToResourceCache   map[string]string
+	// the maximum number of concurrent SubjectAccessReviews and the deadline for completing all of them
+	concurrency int
+	timeout     time.Duration
+	// the kinds of resources for which no SubjectAccessReview is performed
+	exemptKinds sets.Set[schema.GroupVersionKind]
+
+	cacheMutex          sync.Mutex
+	kindToResourceCache map[string]string
+}
+
+// priorityClassCacheTTL bounds how long the existence of a PriorityClass is cached by the webhook
+const priorityClassCacheTTL = 30 * time.Second
+
+type priorityClassCacheEntry struct {
+	exists  bool
+	expires time.Time
+}
+
+// priorityClassCache briefly caches PriorityClass and WorkloadPriorityClass lookups to limit webhook latency
+type priorityClassCache struct {
+	sync.Mutex
+	entries map[string]priorityClassCacheEntry
 }
 
 type appWrapperWebhook struct {
 	client                       client.Client
+	apiReader                    client.Reader
 	defaultQueueName             string
 	enableKueueIntegrations      bool
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
 	userRBACAdmissionCheck       bool
+	requiredPodLabels            map[string]string
 
 	// support for userRBACAdmissionCheck; will be nil if it is not enabled
 	rbacACSupport *rbacACSupport
+
+	priorityClasses         priorityClassCache
+	workloadPriorityClasses priorityClassCache
 }
 
 //+kubebuilder:webhook:path=/mutate-workload-codeflare-dev-v1beta2-appwrapper,mutating=true,failurePolicy=fail,sideEffects=None,groups=workload.codeflare.dev,resources=appwrappers,verbs=create,versions=v1beta2,name=mappwrapper.kb.io,admissionReviewVersions=v1
@@ -77,17 +115,21 @@ var _ webhook.CustomDefaulter = &appWrapperWebhook{}
 
 // Default fills in default values when an AppWrapper is created:
 //  1. Inject default queue name
-//  2. Ensure Suspend is set appropriately
-//  3. Add labels with the user name and id
+//  2. Translate the workload priority annotation into Kueue's priority class label
+//  3. Ensure Suspend is set appropriately
+//  4. Add labels with the user name and id
 func (w *appWrapperWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	aw := obj.(*workloadv1beta2.AppWrapper)
 	log.FromContext(ctx).V(2).Info("Applying defaults", "job", aw)
 
-	// Queue name and Suspend
+	// Queue name, workload priority, and Suspend
 	if w.enableKueueIntegrations {
 		if w.defaultQueueName != "" {
 			aw.Labels = utilmaps.MergeKeepFirst(aw.Labels, map[string]string{QueueNameLabel: w.defaultQueueName})
 		}
+		if priority := aw.Annotations[workloadv1beta2.WorkloadPriorityAnnotation]; priority != "" {
+			aw.Labels = utilmaps.MergeKeepFirst(aw.Labels, map[string]string{WorkloadPriorityLabel: priority})
+		}
 		err := jobframework.ApplyDefaultForSuspend(ctx, (*wlc.AppWrapper)(aw), w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector)
 		if err != nil {
 			return err
@@ -114,11 +156,18 @@ var _ webhook.CustomValidator = &appWrapperWebhook{}
 func (w *appWrapperWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	aw := obj.(*workloadv1beta2.AppWrapper)
 	log.FromContext(ctx).V(2).Info("Validating create", "job", aw)
+	templates := podSetTemplates(aw)
 	allErrors := w.validateAppWrapperCreate(ctx, aw)
+	allErrors = append(allErrors, w.validateRequiredPodLabels(templates)...)
+	allErrors = append(allErrors, w.validateConcurrencyKey(ctx, aw)...)
+	allErrors = append(allErrors, validateParentRef(aw)...)
 	if w.enableKueueIntegrations {
 		allErrors = append(allErrors, jobframework.ValidateJobOnCreate((*wlc.AppWrapper)(aw))...)
+		allErrors = append(allErrors, w.validateQueueName(ctx, aw)...)
 	}
-	return nil, allErrors.ToAggregate()
+	warnings := append(w.priorityClassWarnings(ctx, templates), w.workloadPriorityClassWarnings(ctx, aw)...)
+	warnings = append(warnings, w.queueCoverageWarnings(ctx, aw)...)
+	return append(warnings, imageReferenceWarnings(templates)...), allErrors.ToAggregate()
 }
 
 // ValidateUpdate validates invariants when an AppWrapper is updated
@@ -142,110 +191,325 @@ func (w *appWrapperWebhook) ValidateDelete(context.Context, runtime.Object) (adm
 //+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 //+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=list
 
-// validateAppWrapperCreate checks these invariants:
-//  1. AppWrappers must not contain other AppWrappers
-//  2. AppWrappers must only contain resources intended for their own namespace
-//  3. AppWrappers must not contain any resources that the user could not create directly
-//  4. Every PodSet must be well-formed: the Path must exist and must be parseable as a PodSpecTemplate
-//  5. AppWrappers must contain between 1 and 8 PodSets (Kueue invariant)
+// validateAppWrapperCreate checks the structural invariants of utils.ValidateComponents and that
+// AppWrappers must not contain any resources that the user could not create directly
 func (w *appWrapperWebhook) validateAppWrapperCreate(ctx context.Context, aw *workloadv1beta2.AppWrapper) field.ErrorList {
-	allErrors := field.ErrorList{}
-	components := aw.Spec.Components
+	allErrors := utils.ValidateComponents(aw)
 	componentsPath := field.NewPath("spec").Child("components")
-	podSpecCount := 0
 	request, err := admission.RequestFromContext(ctx)
 	if err != nil {
 		allErrors = append(allErrors, field.InternalError(componentsPath, err))
 	}
+	if !w.userRBACAdmissionCheck {
+		return allErrors
+	}
 	userInfo := request.UserInfo
 
-	for idx, component := range components {
-		compPath := componentsPath.Index(idx)
+	// RBAC check: Perform SubjectAccessReviews in parallel to verify user is entitled to create each component.
+	// All reviews must complete before the webhook itself times out.
+	if w.rbacACSupport.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.rbacACSupport.timeout)
+		defer cancel()
+	}
+	componentErrors := make([]*field.Error, len(aw.Spec.Components))
+	group := errgroup.Group{}
+	group.SetLimit(max(w.rbacACSupport.concurrency, 1))
+	for idx, component := range aw.Spec.Components {
 		unstruct := &unstructured.Unstructured{}
 		_, gvk, err := unstructured.UnstructuredJSONScheme.Decode(component.Template.Raw, nil, unstruct)
+		if err != nil || utils.ValidateAPIVersion(unstruct.GetAPIVersion(), unstruct.GetKind()) != nil {
+			continue // reported by utils.ValidateComponents
+		}
+		if w.rbacACSupport.exemptKinds.Has(*gvk) {
+			continue
+		}
+		group.Go(func() error {
+			componentErrors[idx] = w.checkComponentRBAC(ctx, componentsPath.Index(idx).Child("template"), aw.Namespace, gvk, userInfo)
+			return nil
+		})
+	}
+	_ = group.Wait() // errors are reported in componentErrors
+	for _, err := range componentErrors {
 		if err != nil {
-			allErrors = append(allErrors, field.Invalid(compPath.Child("template"), component.Template, "failed to decode as JSON"))
+			allErrors = append(allErrors, err)
 		}
+	}
 
-		// 1. Deny nested AppWrappers
-		if *gvk == wlc.GVK {
-			allErrors = append(allErrors, field.Forbidden(compPath.Child("template"), "Nested AppWrappers are forbidden"))
-		}
+	return allErrors
+}
 
-		// 2. Forbid creation of resources in other namespaces
-		if unstruct.GetNamespace() != "" && unstruct.GetNamespace() != aw.Namespace {
-			allErrors = append(allErrors, field.Forbidden(compPath.Child("template").Child("metadata").Child("namespace"),
-				"AppWrappers cannot create objects in other namespaces"))
+// checkComponentRBAC performs a SubjectAccessReview to verify that the user is entitled to create a resource of kind gvk in namespace
+func (w *appWrapperWebhook) checkComponentRBAC(ctx context.Context, templatePath *field.Path, namespace string,
+	gvk *schema.GroupVersionKind, userInfo authenticationv1.UserInfo) *field.Error {
+	ra := authv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "create",
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Resource:  w.lookupResource(gvk),
+	}
+	sar := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &ra,
+			User:               userInfo.Username,
+			UID:                userInfo.UID,
+			Groups:             userInfo.Groups,
+		}}
+	if len(userInfo.Extra) > 0 {
+		sar.Spec.Extra = make(map[string]authv1.ExtraValue, len(userInfo.Extra))
+		for k, v := range userInfo.Extra {
+			sar.Spec.Extra[k] = authv1.ExtraValue(v)
 		}
+	}
+	sar, err := w.rbacACSupport.subjectAccessReviewer.Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return field.InternalError(templatePath, err)
+	}
+	if !sar.Status.Allowed {
+		reason := fmt.Sprintf("User %v is not authorized to create %v in %v", userInfo.Username, ra.Resource, ra.Namespace)
+		return field.Forbidden(templatePath, reason)
+	}
+	return nil
+}
 
-		// 3. RBAC check: Perform SubjectAccessReview to verify user is entitled to create component
-		if w.userRBACAdmissionCheck {
-			ra := authv1.ResourceAttributes{
-				Namespace: aw.Namespace,
-				Verb:      "create",
-				Group:     gvk.Group,
-				Version:   gvk.Version,
-				Resource:  w.lookupResource(gvk),
-			}
-			sar := &authv1.SubjectAccessReview{
-				Spec: authv1.SubjectAccessReviewSpec{
-					ResourceAttributes: &ra,
-					User:               userInfo.Username,
-					UID:                userInfo.UID,
-					Groups:             userInfo.Groups,
-				}}
-			if len(userInfo.Extra) > 0 {
-				sar.Spec.Extra = make(map[string]authv1.ExtraValue, len(userInfo.Extra))
-				for k, v := range userInfo.Extra {
-					sar.Spec.Extra[k] = authv1.ExtraValue(v)
-				}
+// podSetTemplate is the PodTemplateSpec of a PodSet of a component
+type podSetTemplate struct {
+	componentIdx int
+	path         string
+	template     *corev1.PodTemplateSpec
+}
+
+// podSetTemplates decodes the components of aw once and returns the PodTemplateSpecs of their declared or inferred PodSets.
+// Components and PodSets that cannot be decoded are skipped; they are reported by validateAppWrapperCreate.
+func podSetTemplates(aw *workloadv1beta2.AppWrapper) []podSetTemplate {
+	templates := []podSetTemplate{}
+	for idx, component := range aw.Spec.Components {
+		unstruct := &unstructured.Unstructured{}
+		if _, _, err := unstructured.UnstructuredJSONScheme.Decode(component.Template.Raw, nil, unstruct); err != nil {
+			continue
+		}
+		podSets := component.DeclaredPodSets
+		if len(podSets) == 0 {
+			if inferred, err := utils.InferPodSets(unstruct); err == nil {
+				podSets = inferred
 			}
-			sar, err = w.rbacACSupport.subjectAccessReviewer.Create(ctx, sar, metav1.CreateOptions{})
-			if err != nil {
-				allErrors = append(allErrors, field.InternalError(compPath.Child("template"), err))
-			} else {
-				if !sar.Status.Allowed {
-					reason := fmt.Sprintf("User %v is not authorized to create %v in %v", userInfo.Username, ra.Resource, ra.Namespace)
-					allErrors = append(allErrors, field.Forbidden(compPath.Child("template"), reason))
-				}
+		}
+		for _, ps := range podSets {
+			if pts, err := utils.GetPodTemplateSpec(unstruct, ps.Path); err == nil {
+				templates = append(templates, podSetTemplate{componentIdx: idx, path: ps.Path, template: pts})
 			}
 		}
+	}
+	return templates
+}
 
-		// 4. Every DeclaredPodSet must specify a path within Template to a v1.PodSpecTemplate
-		podSetsPath := compPath.Child("podSets")
-		for psIdx, ps := range component.DeclaredPodSets {
-			podSetPath := podSetsPath.Index(psIdx)
-			if ps.Path == "" {
-				allErrors = append(allErrors, field.Required(podSetPath.Child("path"), "podspec must specify path"))
+// validateRequiredPodLabels rejects PodSets whose template sets one of the RequiredPodLabels to a different value;
+// the controller could not inject the required label and would fail the AppWrapper when deploying it.
+// The check uses the RequiredPodLabels in effect when the webhook was set up.
+// Required labels with invalid values are not injected by the controller and are therefore not checked.
+func (w *appWrapperWebhook) validateRequiredPodLabels(templates []podSetTemplate) field.ErrorList {
+	allErrors := field.ErrorList{}
+	componentsPath := field.NewPath("spec").Child("components")
+	for _, t := range templates {
+		for _, key := range sets.List(sets.KeySet(w.requiredPodLabels)) {
+			required := w.requiredPodLabels[key]
+			if value, ok := t.template.Labels[key]; ok && value != required && len(validation.IsValidLabelValue(required)) == 0 {
+				allErrors = append(allErrors, field.Invalid(componentsPath.Index(t.componentIdx).Child("template"), t.path,
+					fmt.Sprintf("podSet %v sets label %v to %q, which conflicts with the required Pod label value %q", t.path, key, value, required)))
 			}
-			if _, err := utils.GetPodTemplateSpec(unstruct, ps.Path); err != nil {
-				allErrors = append(allErrors, field.Invalid(podSetPath.Child("path"), ps.Path, fmt.Sprintf("path does not refer to a v1.PodSpecTemplate: %v", err)))
+		}
+	}
+	return allErrors
+}
+
+// priorityClassWarnings warns about PodSets whose priorityClassName refers to a PriorityClass that cannot be found.
+// These are warnings and not errors because a failed lookup may be caused by RBAC rather than a missing PriorityClass.
+func (w *appWrapperWebhook) priorityClassWarnings(ctx context.Context, templates []podSetTemplate) admission.Warnings {
+	warnings := admission.Warnings{}
+	for _, t := range templates {
+		if name := t.template.Spec.PriorityClassName; name != "" && !w.priorityClassExists(ctx, name) {
+			warnings = append(warnings, fmt.Sprintf("spec.components[%v] podSet %v: PriorityClass %q not found; its Pods may fail to be created",
+				t.componentIdx, t.path, name))
+		}
+	}
+	return warnings
+}
+
+// imageReferenceWarnings warns about containers whose image cannot be parsed as an image reference; their Pods would never start.
+// These are warnings and not errors to avoid rejecting unusual references that the container runtime accepts.
+func imageReferenceWarnings(templates []podSetTemplate) admission.Warnings {
+	warnings := admission.Warnings{}
+	for _, t := range templates {
+		for _, container := range append(t.template.Spec.InitContainers, t.template.Spec.Containers...) {
+			if err := utils.ValidateImageReference(container.Image); err != nil {
+				warnings = append(warnings, fmt.Sprintf("spec.components[%v] podSet %v: container %v has invalid image reference %q: %v",
+					t.componentIdx, t.path, container.Name, container.Image, err))
 			}
 		}
+	}
+	return warnings
+}
 
-		// 5. Validate PodSets for known GVKs
-		if inferred, err := utils.InferPodSets(unstruct); err != nil {
-			allErrors = append(allErrors, field.Invalid(compPath.Child("template"), component.Template, fmt.Sprintf("error inferring PodSets: %v", err)))
-		} else {
-			if len(component.DeclaredPodSets) > len(inferred) {
-				podSpecCount += len(component.DeclaredPodSets)
-			} else {
-				podSpecCount += len(inferred)
+// workloadPriorityClassWarnings warns about an AppWrapper whose Kueue priority class label refers to a
+// WorkloadPriorityClass that cannot be found. As for PriorityClasses, a failed lookup is not an error.
+func (w *appWrapperWebhook) workloadPriorityClassWarnings(ctx context.Context, aw *workloadv1beta2.AppWrapper) admission.Warnings {
+	name := aw.Labels[WorkloadPriorityLabel]
+	if !w.enableKueueIntegrations || name == "" {
+		return nil
+	}
+	if w.workloadPriorityClasses.exists(ctx, w.client, name, &kueue.WorkloadPriorityClass{}) {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("metadata.labels[%v]: WorkloadPriorityClass %q not found; Kueue may not admit the AppWrapper",
+		WorkloadPriorityLabel, name)}
+}
+
+// queueCoverageWarnings warns about resources requested by the PodSets of an AppWrapper that are not covered by the
+// ClusterQueue of its LocalQueue; Kueue will never admit such an AppWrapper. Queues that cannot be found are not reported.
+func (w *appWrapperWebhook) queueCoverageWarnings(ctx context.Context, aw *workloadv1beta2.AppWrapper) admission.Warnings {
+	queueName := jobframework.QueueNameForObject(aw)
+	if !w.enableKueueIntegrations || queueName == "" {
+		return nil
+	}
+	lq := &kueue.LocalQueue{}
+	if err := w.client.Get(ctx, client.ObjectKey{Namespace: aw.Namespace, Name: queueName}, lq); err != nil {
+		return nil
+	}
+	cq := &kueue.ClusterQueue{}
+	if err := w.client.Get(ctx, client.ObjectKey{Name: string(lq.Spec.ClusterQueue)}, cq); err != nil {
+		return nil
+	}
+	podSets, err := utils.GetPodSets(aw)
+	if err != nil {
+		return nil // reported by validateAppWrapperCreate
+	}
+
+	covered := sets.New[corev1.ResourceName]()
+	for _, rg := range cq.Spec.ResourceGroups {
+		covered.Insert(rg.CoveredResources...)
+	}
+	uncovered := sets.New[corev1.ResourceName]()
+	for _, ps := range podSets {
+		spec := &ps.Template.Spec
+		for _, container := range append(spec.InitContainers, spec.Containers...) {
+			for name := range container.Resources.Requests {
+				if !covered.Has(name) {
+					uncovered.Insert(name)
+				}
 			}
-			if err := utils.ValidatePodSets(component.DeclaredPodSets, inferred); err != nil {
-				allErrors = append(allErrors, field.Invalid(podSetsPath, component.DeclaredPodSets, err.Error()))
+			for name := range container.Resources.Limits {
+				if !covered.Has(name) {
+					uncovered.Insert(name)
+				}
 			}
 		}
 	}
+	if uncovered.Len() == 0 {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("resources %v are not covered by ClusterQueue %v of LocalQueue %v; the AppWrapper will never be admitted",
+		sets.List(uncovered), cq.Name, queueName)}
+}
+
+func (w *appWrapperWebhook) priorityClassExists(ctx context.Context, name string) bool {
+	return w.priorityClasses.exists(ctx, w.client, name, &schedulingv1.PriorityClass{})
+}
+
+// exists returns false if the cluster-scoped obj with the given name is not found by c.
+// Only successful lookups and NotFound results are cached; other errors are assumed to be transient
+// or caused by RBAC and are treated as the obj existing so that they do not produce a warning.
+func (cache *priorityClassCache) exists(ctx context.Context, c client.Reader, name string, obj client.Object) bool {
+	cache.Lock()
+	entry, ok := cache.entries[name]
+	cache.Unlock()
+	now := time.Now()
+	if ok && now.Before(entry.expires) {
+		return entry.exists
+	}
+
+	err := c.Get(ctx, client.ObjectKey{Name: name}, obj)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return true
+	}
+	exists := err == nil
+	cache.Lock()
+	defer cache.Unlock()
+	if cache.entries == nil {
+		cache.entries = make(map[string]priorityClassCacheEntry)
+	}
+	cache.entries[name] = priorityClassCacheEntry{exists: exists, expires: now.Add(priorityClassCacheTTL)}
+	return exists
+}
+
+// rbacs required to read the labels of the AppWrapper's namespace
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// validateQueueName rejects AppWrappers without a queue name that Kueue will never admit
+// because jobs without a queue name are not managed in their namespace.
+// If the namespace cannot be read it fails closed and rejects the AppWrapper.
+func (w *appWrapperWebhook) validateQueueName(ctx context.Context, aw *workloadv1beta2.AppWrapper) field.ErrorList {
+	if w.manageJobsWithoutQueueName || jobframework.QueueNameForObject(aw) != "" {
+		return nil
+	}
+	queueNamePath := field.NewPath("metadata").Child("labels").Key(QueueNameLabel)
+	if w.managedJobsNamespaceSelector != nil {
+		ns := &corev1.Namespace{}
+		if err := w.client.Get(ctx, client.ObjectKey{Name: aw.Namespace}, ns); err != nil {
+			return field.ErrorList{field.InternalError(queueNamePath,
+				fmt.Errorf("cannot determine whether Kueue manages jobs without a queue name in namespace %v: %w", aw.Namespace, err))}
+		}
+		if !w.managedJobsNamespaceSelector.Matches(labels.Set(ns.GetLabels())) {
+			return nil // Kueue does not manage jobs in this namespace
+		}
+	}
+	return field.ErrorList{field.Required(queueNamePath,
+		fmt.Sprintf("AppWrappers in namespace %v must specify a queue; add a %v label naming a LocalQueue", aw.Namespace, QueueNameLabel))}
+}
 
-	// 6. Enforce Kueue limitation that 0 < podSpecCount <= 8
-	if podSpecCount == 0 {
-		allErrors = append(allErrors, field.Invalid(componentsPath, components, "components contains no podspecs"))
+// validateConcurrencyKey rejects an AppWrapper whose ConcurrencyKey is the same as that of an active AppWrapper in its namespace.
+// The lookup bypasses the cache so that an AppWrapper created immediately before aw is not missed.
+// The check is best-effort: AppWrappers created concurrently may all be admitted. The AppWrapper controller
+// ensures that only the first of them to be deployed creates its components until it completes.
+func (w *appWrapperWebhook) validateConcurrencyKey(ctx context.Context, aw *workloadv1beta2.AppWrapper) field.ErrorList {
+	if aw.Spec.ConcurrencyKey == "" {
+		return nil
+	}
+	keyPath := field.NewPath("spec").Child("concurrencyKey")
+	aws := &workloadv1beta2.AppWrapperList{}
+	if err := w.apiReader.List(ctx, aws, client.InNamespace(aw.Namespace)); err != nil {
+		return field.ErrorList{field.InternalError(keyPath, err)}
 	}
-	if podSpecCount > 8 {
-		allErrors = append(allErrors, field.Invalid(componentsPath, components, fmt.Sprintf("components contains %v podspecs; at most 8 are allowed", podSpecCount)))
+	for _, other := range aws.Items {
+		if other.Spec.ConcurrencyKey != aw.Spec.ConcurrencyKey || other.Name == aw.Name || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if other.Status.Phase == workloadv1beta2.AppWrapperSucceeded || other.Status.Phase == workloadv1beta2.AppWrapperFailed {
+			continue
+		}
+		return field.ErrorList{field.Forbidden(keyPath,
+			fmt.Sprintf("AppWrapper %v with concurrencyKey %q is still active", other.Name, aw.Spec.ConcurrencyKey))}
 	}
+	return nil
+}
 
+// validateParentRef rejects a ParentRef that cannot identify a resource
+func validateParentRef(aw *workloadv1beta2.AppWrapper) field.ErrorList {
+	ref := aw.Spec.ParentRef
+	if ref == nil {
+		return nil
+	}
+	allErrors := field.ErrorList{}
+	refPath := field.NewPath("spec").Child("parentRef")
+	if gv, err := schema.ParseGroupVersion(ref.APIVersion); err != nil || gv.Version == "" {
+		allErrors = append(allErrors, field.Invalid(refPath.Child("apiVersion"), ref.APIVersion, "must be of the form 'group/version' or 'version'"))
+	}
+	if ref.Kind == "" {
+		allErrors = append(allErrors, field.Required(refPath.Child("kind"), "parentRef must specify kind"))
+	}
+	if ref.Name == "" {
+		allErrors = append(allErrors, field.Required(refPath.Child("name"), "parentRef must specify name"))
+	}
 	return allErrors
 }
 
@@ -291,11 +555,24 @@ func (w *appWrapperWebhook) validateAppWrapperUpdate(old *workloadv1beta2.AppWra
 		allErrors = append(allErrors, field.Forbidden(field.NewPath("spec").Child("managedBy"), msg))
 	}
 
+	// ensure concurrencyKey field is immutable
+	if old.Spec.ConcurrencyKey != new.Spec.ConcurrencyKey {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("spec").Child("concurrencyKey"), msg))
+	}
+
+	// ensure parentRef field is immutable
+	if !ptr.Equal(old.Spec.ParentRef, new.Spec.ParentRef) {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("spec").Child("parentRef"), msg))
+	}
+
 	return allErrors
 }
 
 func (w *appWrapperWebhook) lookupResource(gvk *schema.GroupVersionKind) string {
-	if known, ok := w.rbacACSupport.kindToResourceCache[gvk.String()]; ok {
+	w.rbacACSupport.cacheMutex.Lock()
+	known, ok := w.rbacACSupport.kindToResourceCache[gvk.String()]
+	w.rbacACSupport.cacheMutex.Unlock()
+	if ok {
 		return known
 	}
 	resources, err := w.rbacACSupport.discoveryClient.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
@@ -304,7 +581,9 @@ func (w *appWrapperWebhook) lookupResource(gvk *schema.GroupVersionKind) string
 	}
 	for _, r := range resources.APIResources {
 		if r.Kind == gvk.Kind {
+			w.rbacACSupport.cacheMutex.Lock()
 			w.rbacACSupport.kindToResourceCache[gvk.String()] = r.Name
+			w.rbacACSupport.cacheMutex.Unlock()
 			return r.Name
 		}
 	}
@@ -318,11 +597,13 @@ func SetupAppWrapperWebhook(mgr ctrl.Manager, awConfig *config.AppWrapperConfig)
 	}
 	wh := &appWrapperWebhook{
 		client:                       mgr.GetClient(),
+		apiReader:                    mgr.GetAPIReader(),
 		defaultQueueName:             awConfig.DefaultQueueName,
 		enableKueueIntegrations:      awConfig.EnableKueueIntegrations,
 		manageJobsWithoutQueueName:   awConfig.KueueJobReconciller.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: nsSelector,
 		userRBACAdmissionCheck:       awConfig.UserRBACAdmissionCheck,
+		requiredPodLabels:            awConfig.RequiredPodLabels,
 	}
 
 	if awConfig.UserRBACAdmissionCheck {
@@ -333,8 +614,16 @@ func SetupAppWrapperWebhook(mgr ctrl.Manager, awConfig *config.AppWrapperConfig)
 		wh.rbacACSupport = &rbacACSupport{
 			discoveryClient:       kubeClient.DiscoveryClient,
 			subjectAccessReviewer: kubeClient.AuthorizationV1().SubjectAccessReviews(),
+			concurrency:           awConfig.UserRBACCheckConcurrency,
+			timeout:               awConfig.UserRBACCheckTimeout,
+			exemptKinds:           sets.New[schema.GroupVersionKind](),
 			kindToResourceCache:   make(map[string]string),
 		}
+		for _, k := range awConfig.UserRBACExemptKinds {
+			if gv, err := schema.ParseGroupVersion(k.APIVersion); err == nil {
+				wh.rbacACSupport.exemptKinds.Insert(gv.WithKind(k.Kind))
+			}
+		}
 
 	}
 
@@ -17,16 +17,35 @@ limitations under the License.
 package webhook
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	workloadv1beta2 "github.com/project-codeflare/appwrapper/api/v1beta2"
-
+	"github.com/project-codeflare/appwrapper/pkg/config"
+	"github.com/project-codeflare/appwrapper/pkg/utils"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 )
 
@@ -58,6 +77,28 @@ var _ = Describe("AppWrapper Webhook Tests", func() {
 			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
 		})
 
+		It("Kueue's priority class label is set from the workload priority annotation", func() {
+			warnings := &warningRecorder{}
+			warningCfg := rest.CopyConfig(cfg)
+			warningCfg.WarningHandler = warnings
+			warningClient, err := client.New(warningCfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+
+			aw := toAppWrapper(pod(100))
+			aw.Annotations = map[string]string{workloadv1beta2.WorkloadPriorityAnnotation: "high-priority"}
+			Expect(warningClient.Create(ctx, aw)).To(Succeed(), "Missing WorkloadPriorityClasses should not cause rejection")
+			Expect(aw.Labels).Should(HaveKeyWithValue(WorkloadPriorityLabel, "high-priority"))
+			Expect(warnings.messages()).Should(ContainElement(ContainSubstring(`WorkloadPriorityClass "high-priority" not found`)))
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+			aw = toAppWrapper(pod(100))
+			aw.Annotations = map[string]string{workloadv1beta2.WorkloadPriorityAnnotation: "high-priority"}
+			aw.Labels = utilmaps.MergeKeepFirst(map[string]string{WorkloadPriorityLabel: "low-priority"}, aw.Labels)
+			Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+			Expect(aw.Labels).Should(HaveKeyWithValue(WorkloadPriorityLabel, "low-priority"), "priority class label should not be overridden")
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		})
+
 		It("User name and ID are set", func() {
 			aw := toAppWrapper(pod(100))
 			aw.Labels = utilmaps.MergeKeepFirst(map[string]string{AppWrapperUsernameLabel: "bad", AppWrapperUserIDLabel: "bad"}, aw.Labels)
@@ -104,6 +145,12 @@ var _ = Describe("AppWrapper Webhook Tests", func() {
 				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed())
 			})
 
+			It("Negative PodSet replicas are rejected", func() {
+				aw := toAppWrapper(pod(100), withAPIVersion(jobForInference(1, 1, 100), "example.com/v1alpha1"))
+				aw.Spec.Components[1].DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(int32(-1)), Path: "template.spec.template"}}
+				Expect(k8sClient.Create(ctx, aw)).Should(MatchError(ContainSubstring("spec.components[1].podSets[0].replicas")))
+			})
+
 			It("Validation of Array and Map path elements", func() {
 				comp := jobSet(2, 100)
 				comp.DeclaredPodSets[0].Path = "template.spec.replicatedJobs.template.spec.template"
@@ -145,6 +192,112 @@ var _ = Describe("AppWrapper Webhook Tests", func() {
 			Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed())
 		})
 
+		It("Components with the same explicit name and kind are rejected", func() {
+			component := pod(100)
+			aw := toAppWrapper(component, component)
+			err := k8sClient.Create(ctx, aw)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("spec.components[1].template.metadata.name: Duplicate value"))
+		})
+
+		It("Endpoint paths must refer to a field within status", func() {
+			component := pod(100)
+			component.EndpointPath = "status.podIP"
+			aw := toAppWrapper(component)
+			Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+			component = pod(100)
+			component.EndpointPath = "spec.nodeName"
+			err := k8sClient.Create(ctx, toAppWrapper(component))
+			Expect(err).Should(MatchError(ContainSubstring("spec.components[0].endpointPath")))
+		})
+
+		It("Components with the same generateName are accepted", func() {
+			aw := toAppWrapper(pod(100, withGenerateName("pod-")), pod(100, withGenerateName("pod-")))
+			Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		})
+
+		It("AppWrappers with the same concurrencyKey as an active AppWrapper are rejected", func() {
+			first := toAppWrapper(pod(100))
+			first.Spec.ConcurrencyKey = "nightly-training"
+			Expect(k8sClient.Create(ctx, first)).To(Succeed())
+
+			conflicting := toAppWrapper(pod(100))
+			conflicting.Spec.ConcurrencyKey = "nightly-training"
+			err := k8sClient.Create(ctx, conflicting)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("spec.concurrencyKey"))
+			Expect(err.Error()).Should(ContainSubstring(first.Name))
+
+			distinct := toAppWrapper(pod(100))
+			distinct.Spec.ConcurrencyKey = "nightly-evaluation"
+			Expect(k8sClient.Create(ctx, distinct)).To(Succeed())
+
+			Expect(k8sClient.Delete(ctx, first)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, distinct)).To(Succeed())
+		})
+
+		It("AppWrappers with the same concurrencyKey as a completed AppWrapper are accepted", func() {
+			first := toAppWrapper(pod(100))
+			first.Spec.ConcurrencyKey = "weekly-training"
+			Expect(k8sClient.Create(ctx, first)).To(Succeed())
+			first.Status.Phase = workloadv1beta2.AppWrapperSucceeded
+			Expect(k8sClient.Status().Update(ctx, first)).To(Succeed())
+
+			second := toAppWrapper(pod(100))
+			second.Spec.ConcurrencyKey = "weekly-training"
+			Expect(k8sClient.Create(ctx, second)).To(Succeed())
+
+			Expect(k8sClient.Delete(ctx, first)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, second)).To(Succeed())
+		})
+
+		It("Malformed sharedScratch annotations are rejected", func() {
+			for _, value := range []string{"scratch", "lots:/scratch", "10Gi:scratch"} {
+				component := pod(100)
+				component.Annotations = map[string]string{workloadv1beta2.SharedScratchAnnotation: value}
+				aw := toAppWrapper(component)
+				Expect(k8sClient.Create(ctx, aw)).Should(MatchError(ContainSubstring("spec.components[0].annotations[workload.codeflare.dev/sharedScratch]")), value)
+			}
+		})
+
+		It("ParentRefs must identify a resource and are immutable", func() {
+			aw := toAppWrapper(pod(100))
+			aw.Spec.ParentRef = &workloadv1beta2.AppWrapperParentReference{APIVersion: "a/b/c", Kind: "PipelineRun"}
+			err := k8sClient.Create(ctx, aw)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("spec.parentRef.apiVersion"))
+			Expect(err.Error()).Should(ContainSubstring("spec.parentRef.name"))
+
+			aw.Spec.ParentRef = &workloadv1beta2.AppWrapperParentReference{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Name: "nightly"}
+			Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+			aw = getAppWrapper(types.NamespacedName{Name: aw.Name, Namespace: aw.Namespace})
+			aw.Spec.ParentRef.Name = "weekly"
+			Expect(k8sClient.Update(ctx, aw)).Should(MatchError(ContainSubstring("spec.parentRef")))
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		})
+
+		It("Components with malformed apiVersions are rejected", func() {
+			for _, apiVersion := range []string{"batch/V1", "v1", "BATCH/v1", "batch/v1/extra", "batch/", "my_group.io/v1"} {
+				aw := toAppWrapper(pod(100), withAPIVersion(jobForInference(1, 1, 100), apiVersion))
+				err := k8sClient.Create(ctx, aw)
+				Expect(err).Should(HaveOccurred(), apiVersion)
+				Expect(err.Error()).Should(ContainSubstring("spec.components[1].template.apiVersion"), apiVersion)
+			}
+
+			aw := toAppWrapper(pod(100), withAPIVersion(jobForInference(1, 1, 100), "v1"))
+			Expect(k8sClient.Create(ctx, aw)).Should(MatchError(ContainSubstring(`did you mean "batch/v1"`)))
+		})
+
+		It("Components with well-formed apiVersions for unknown kinds are accepted", func() {
+			aw := toAppWrapper(pod(100), withAPIVersion(jobForInference(1, 1, 100), "example.com/v1alpha1"))
+			aw.Spec.Components[1].DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(int32(1)), Path: "template.spec.template"}}
+			Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		})
+
 		It("Nested AppWrappers are rejected", func() {
 			child := toAppWrapper(pod(100))
 			childBytes, err := json.Marshal(child)
@@ -241,6 +394,63 @@ var _ = Describe("AppWrapper Webhook Tests", func() {
 			})
 		})
 
+		Context("RBAC checks of many components are performed in parallel", func() {
+			var wh *appWrapperWebhook
+			var reqCtx context.Context
+			var mutex sync.Mutex
+			var reviewed []string
+
+			BeforeEach(func() {
+				reviewed = []string{}
+				clientset := fake.NewClientset()
+				clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					sar := action.(k8stesting.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+					mutex.Lock()
+					reviewed = append(reviewed, sar.Spec.ResourceAttributes.Resource)
+					mutex.Unlock()
+					sar.Status.Allowed = sar.Spec.ResourceAttributes.Resource != "deployments"
+					return true, sar, nil
+				})
+				wh = &appWrapperWebhook{
+					client:                 k8sClient,
+					userRBACAdmissionCheck: true,
+					rbacACSupport: &rbacACSupport{
+						subjectAccessReviewer: clientset.AuthorizationV1().SubjectAccessReviews(),
+						concurrency:           2,
+						timeout:               5 * time.Second,
+						kindToResourceCache:   map[string]string{"/v1, Kind=Pod": "pods", "apps/v1, Kind=Deployment": "deployments"},
+					},
+				}
+				reqCtx = admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{Username: limitedUserName},
+				}})
+			})
+
+			It("All components are checked", func() {
+				aw := toAppWrapper(pod(100), pod(100), pod(100), pod(100), pod(100), pod(100))
+				_, err := wh.ValidateCreate(reqCtx, aw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reviewed).Should(HaveLen(6))
+				Expect(reviewed).Should(HaveEach("pods"))
+			})
+
+			It("A denial among them still fails creation", func() {
+				aw := toAppWrapper(pod(100), pod(100), pod(100), deployment(1, 100), pod(100), pod(100))
+				_, err := wh.ValidateCreate(reqCtx, aw)
+				Expect(err).Should(MatchError(ContainSubstring("spec.components[3].template: Forbidden")))
+				Expect(err).Should(MatchError(ContainSubstring("is not authorized to create deployments")))
+				Expect(reviewed).Should(HaveLen(6))
+			})
+
+			It("Exempt kinds are not reviewed", func() {
+				wh.rbacACSupport.exemptKinds = sets.New(schema.GroupVersionKind{Version: "v1", Kind: "Pod"})
+				aw := toAppWrapper(pod(100), pod(100), deployment(1, 100))
+				_, err := wh.ValidateCreate(reqCtx, aw)
+				Expect(err).Should(MatchError(ContainSubstring("spec.components[2].template: Forbidden")))
+				Expect(reviewed).Should(Equal([]string{"deployments"}))
+			})
+		})
+
 		It("Well-formed AppWrappers are accepted", func() {
 			aw := toAppWrapper(pod(100), deployment(1, 100), namespacedPod("default", 100), rayCluster(1, 100), jobSet(1, 100))
 
@@ -249,6 +459,100 @@ var _ = Describe("AppWrapper Webhook Tests", func() {
 			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
 		})
 
+		Context("Queue names are required when Kueue only manages jobs with queue names", func() {
+			var wh *appWrapperWebhook
+			var reqCtx context.Context
+
+			BeforeEach(func() {
+				conf := config.NewAppWrapperConfig()
+				conf.KueueJobReconciller.ManageJobsWithoutQueueName = false
+				nsSelector, err := metav1.LabelSelectorAsSelector(conf.KueueJobReconciller.ManageJobsNamespaceSelector)
+				Expect(err).NotTo(HaveOccurred())
+				wh = &appWrapperWebhook{
+					client:                       k8sClient,
+					enableKueueIntegrations:      true,
+					manageJobsWithoutQueueName:   false,
+					managedJobsNamespaceSelector: nsSelector,
+				}
+				reqCtx = admission.NewContextWithRequest(ctx, admission.Request{})
+			})
+
+			It("AppWrappers without a queue name are rejected", func() {
+				aw := toAppWrapper(pod(100))
+				_, err := wh.ValidateCreate(reqCtx, aw)
+				Expect(err).Should(MatchError(ContainSubstring(QueueNameLabel)))
+			})
+
+			It("AppWrappers with a queue name are accepted", func() {
+				aw := toAppWrapper(pod(100))
+				aw.Labels = map[string]string{QueueNameLabel: userProvidedQueueName}
+				_, err := wh.ValidateCreate(reqCtx, aw)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("AppWrappers in exempt namespaces do not need a queue name", func() {
+				aw := toAppWrapper(pod(100))
+				aw.Namespace = "kube-system"
+				_, err := wh.ValidateCreate(reqCtx, aw)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		It("AppWrappers referencing a missing PriorityClass are accepted with a warning", func() {
+			warnings := &warningRecorder{}
+			warningCfg := rest.CopyConfig(cfg)
+			warningCfg.WarningHandler = warnings
+			warningClient, err := client.New(warningCfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+
+			aw := toAppWrapper(podForInference(100, func(p *corev1.Pod) { p.Spec.PriorityClassName = "no-such-priority-class" }))
+			Expect(warningClient.Create(ctx, aw)).To(Succeed(), "Missing PriorityClasses should not cause rejection")
+			Expect(warnings.messages()).Should(ContainElement(ContainSubstring(`PriorityClass "no-such-priority-class" not found`)))
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		})
+
+		It("AppWrappers requesting resources not covered by their ClusterQueue are accepted with a warning", func() {
+			warnings := &warningRecorder{}
+			warningCfg := rest.CopyConfig(cfg)
+			warningCfg.WarningHandler = warnings
+			warningClient, err := client.New(warningCfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+			cq := clusterQueue(randName("cq"), corev1.ResourceCPU)
+			Expect(k8sClient.Create(ctx, cq)).To(Succeed())
+			lq := localQueue(randName("lq"), "default", cq.Name)
+			Expect(k8sClient.Create(ctx, lq)).To(Succeed())
+
+			aw := toAppWrapper(pod(100))
+			aw.Labels = map[string]string{QueueNameLabel: lq.Name}
+			Expect(warningClient.Create(ctx, aw)).To(Succeed())
+			Expect(warnings.messages()).ShouldNot(ContainElement(ContainSubstring("not covered by ClusterQueue")))
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+			aw = toAppWrapper(podForInference(100, withGPUs(1)))
+			aw.Labels = map[string]string{QueueNameLabel: lq.Name}
+			Expect(warningClient.Create(ctx, aw)).To(Succeed(), "Uncovered resources should not cause rejection")
+			Expect(warnings.messages()).Should(ContainElement(
+				fmt.Sprintf("resources [nvidia.com/gpu] are not covered by ClusterQueue %v of LocalQueue %v; the AppWrapper will never be admitted", cq.Name, lq.Name)))
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+			Expect(k8sClient.Delete(ctx, lq)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, cq)).To(Succeed())
+		})
+
+		It("AppWrappers containing malformed image references are accepted with a warning", func() {
+			warnings := &warningRecorder{}
+			warningCfg := rest.CopyConfig(cfg)
+			warningCfg.WarningHandler = warnings
+			warningClient, err := client.New(warningCfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+
+			aw := toAppWrapper(podForInference(100, func(p *corev1.Pod) { p.Spec.Containers[0].Image = "quay.io/project-codeflare/BusyBox:1.36" }), pod(100))
+			Expect(warningClient.Create(ctx, aw)).To(Succeed(), "Malformed image references should not cause rejection")
+			Expect(warnings.messages()).Should(ContainElement(ContainSubstring(`spec.components[0] podSet template: container busybox has invalid image reference "quay.io/project-codeflare/BusyBox:1.36"`)))
+			Expect(warnings.messages()).ShouldNot(ContainElement(ContainSubstring("spec.components[1]")))
+			Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+		})
+
 		Context("PodSets are inferred for known GVKs", func() {
 			It("PodSets are inferred for common kinds", func() {
 				aw := toAppWrapper(pod(100), deploymentForInference(1, 100), podForInference(100),
@@ -259,6 +563,52 @@ var _ = Describe("AppWrapper Webhook Tests", func() {
 				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
 			})
 
+			It("DaemonSets must declare the replica count of their PodSet", func() {
+				aw := toAppWrapper(daemonSetForInference(100))
+				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed(), "the replica count of a DaemonSet cannot be inferred")
+
+				component := daemonSetForInference(100)
+				component.DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{{Path: "template.spec.template"}}
+				aw = toAppWrapper(component)
+				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed(), "the declared PodSet must specify replicas")
+
+				component = daemonSetForInference(100)
+				component.DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(int32(3)), Path: "template.spec.template"}}
+				aw = toAppWrapper(component)
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed(), "the declared replicas should be accepted")
+				Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(3)), "quota should be reserved for the declared replicas")
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+			})
+
+			It("Templates must not set a required Pod label to a different value", func() {
+				aw := toAppWrapper(pod(100, func(p *corev1.Pod) { p.Labels = map[string]string{requiredPodLabel: "false"} }))
+				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed(), "a conflicting label value should be rejected")
+
+				aw = toAppWrapper(pod(100, func(p *corev1.Pod) { p.Labels = map[string]string{requiredPodLabel: "true"} }))
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed(), "a matching label value should be accepted")
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+			})
+
+			It("PodSets are inferred for CronJobs", func() {
+				aw := toAppWrapper(cronJobForInference(2, 4, 100), cronJobForInference(8, 4, 100))
+
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed(), "PodSets should be inferred")
+				Expect(aw.Status.ComponentStatus[0].PodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(int32(2)), Path: "template.spec.jobTemplate.spec.template"}}))
+				Expect(aw.Status.ComponentStatus[1].PodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(int32(4)), Path: "template.spec.jobTemplate.spec.template"}}))
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+			})
+
+			It("Declared PodSets of CronJobs must have valid paths", func() {
+				aw := toAppWrapper(cronJobForInference(1, 1, 100))
+				aw.Spec.Components[0].DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(int32(1)), Path: "template.spec.template"}}
+				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed(), "The Job template of a CronJob is nested in its jobTemplate")
+
+				aw = toAppWrapper(cronJobForInference(1, 1, 100))
+				aw.Spec.Components[0].DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(int32(1)), Path: "template.spec.jobTemplate.spec.template"}}
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+			})
+
 			It("PodSets are inferred for PyTorchJobs, RayClusters, and RayJobs", func() {
 				aw := toAppWrapper(pytorchJobForInference(100, 4, 100), rayClusterForInference(7, 100), rayJobForInference(7, 100))
 
@@ -266,7 +616,165 @@ var _ = Describe("AppWrapper Webhook Tests", func() {
 				Expect(aw.Spec.Suspend).Should(BeTrue())
 				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
 			})
+
+			It("PodSets are inferred for the components of InferenceServices", func() {
+				aw := toAppWrapper(inferenceServiceForInference(2, 100))
+
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed(), "PodSets should be inferred")
+				Expect(aw.Spec.Suspend).Should(BeTrue())
+				Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(3)), "Two predictor replicas and one transformer replica")
+				Expect(aw.Status.ComponentStatus[0].PodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{
+					{Replicas: ptr.To(int32(2)), Path: "template.spec.predictor"},
+					{Replicas: ptr.To(int32(1)), Path: "template.spec.transformer"},
+				}))
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+				aw = toAppWrapper(inferenceServiceForInference(1, 100))
+				aw.Spec.Components[0].DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{{Replicas: ptr.To(int32(1)), Path: "template.spec.explainer"}}
+				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed(), "The InferenceService has no explainer")
+			})
+
+			It("The requests of the model framework of an InferenceService predictor are inferred", func() {
+				aw := toAppWrapper(modelInferenceServiceForInference(250))
+
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed(), "PodSets should be inferred")
+				podSets, err := utils.GetPodSets(aw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podSets).Should(HaveLen(1))
+				Expect(podSets[0].Template.Spec.Containers).Should(HaveLen(1))
+				Expect(podSets[0].Template.Spec.Containers[0].Name).Should(Equal("kserve-container"))
+				Expect(podSets[0].Template.Spec.Containers[0].Resources.Requests.Cpu().MilliValue()).Should(Equal(int64(250)))
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+				aw = toAppWrapper(noContainersInferenceService())
+				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed(), "The predictor has neither containers nor a model framework")
+			})
+
+			It("PodSets are inferred for TFJobs and XGBoostJobs", func() {
+				aw := toAppWrapper(tfJobForInference(2, 4, 100), xgboostJobForInference(3, 100))
+
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed(), "PodSets should be inferred")
+				Expect(aw.Spec.Suspend).Should(BeTrue())
+				Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(11)))
+				Expect(aw.Status.ComponentStatus[0].PodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{
+					{Replicas: ptr.To(int32(1)), Path: "template.spec.tfReplicaSpecs.Chief.template"},
+					{Replicas: ptr.To(int32(2)), Path: "template.spec.tfReplicaSpecs.PS.template"},
+					{Replicas: ptr.To(int32(4)), Path: "template.spec.tfReplicaSpecs.Worker.template"},
+				}))
+				Expect(aw.Status.ComponentStatus[1].PodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{
+					{Replicas: ptr.To(int32(1)), Path: "template.spec.xgbReplicaSpecs.Master.template"},
+					{Replicas: ptr.To(int32(3)), Path: "template.spec.xgbReplicaSpecs.Worker.template"},
+				}))
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+			})
+
+			It("PodSets are inferred for LeaderWorkerSets", func() {
+				aw := toAppWrapper(leaderWorkerSet(2, 4, 100))
+
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed(), "PodSets should be inferred")
+				Expect(aw.Spec.Suspend).Should(BeTrue())
+				Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(8)), "Two groups of one leader and three workers")
+				Expect(aw.Status.ComponentStatus[0].PodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{
+					{Replicas: ptr.To(int32(2)), Path: "template.spec.leaderWorkerTemplate.leaderTemplate"},
+					{Replicas: ptr.To(int32(6)), Path: "template.spec.leaderWorkerTemplate.workerTemplate"},
+				}))
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+				aw = toAppWrapper(leaderWorkerSet(2, 4, 100))
+				aw.Spec.Components[0].DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{
+					{Replicas: ptr.To(int32(1)), Path: "template.spec.leaderWorkerTemplate.leaderTemplate"},
+					{Replicas: ptr.To(int32(3)), Path: "template.spec.leaderWorkerTemplate.workerTemplate"},
+				}
+				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed(), "Declared replicas must account for every group")
+
+				By("Leaders are created from the workerTemplate when the leaderTemplate is omitted")
+				aw = toAppWrapper(leaderWorkerSet(2, 4, 100))
+				obj := &unstructured.Unstructured{}
+				Expect(obj.UnmarshalJSON(aw.Spec.Components[0].Template.Raw)).To(Succeed())
+				unstructured.RemoveNestedField(obj.Object, "spec", "leaderWorkerTemplate", "leaderTemplate")
+				raw, err := obj.MarshalJSON()
+				Expect(err).NotTo(HaveOccurred())
+				aw.Spec.Components[0].Template.Raw = raw
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+				Expect(aw.Status.ComponentStatus[0].PodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{
+					{Replicas: ptr.To(int32(8)), Path: "template.spec.leaderWorkerTemplate.workerTemplate"},
+				}))
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+			})
+
+			It("PodSets are inferred for the replicatedJobs of JobSets", func() {
+				comp := jobSet(3, 100)
+				comp.DeclaredPodSets = nil
+				aw := toAppWrapper(comp)
+
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed(), "PodSets should be inferred")
+				Expect(aw.Status.ComponentStatus[0].PodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{
+					{Replicas: ptr.To(int32(1)), Path: "template.spec.replicatedJobs[0].template.spec.template"},
+					{Replicas: ptr.To(int32(3)), Path: "template.spec.replicatedJobs[1].template.spec.template"},
+				}))
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+				By("Each replica of a replicatedJob runs parallelism Pods")
+				comp = jobSet(3, 100)
+				obj := &unstructured.Unstructured{}
+				Expect(obj.UnmarshalJSON(comp.Template.Raw)).To(Succeed())
+				replicatedJobs, _, err := unstructured.NestedSlice(obj.Object, "spec", "replicatedJobs")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unstructured.SetNestedField(replicatedJobs[1].(map[string]interface{}), int64(2), "replicas")).To(Succeed())
+				Expect(unstructured.SetNestedSlice(obj.Object, replicatedJobs, "spec", "replicatedJobs")).To(Succeed())
+				raw, err := obj.MarshalJSON()
+				Expect(err).NotTo(HaveOccurred())
+				comp.Template.Raw = raw
+				aw = toAppWrapper(comp)
+				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed(), "The declared replicas of the workers must account for both of their Jobs")
+
+				comp.DeclaredPodSets[1].Replicas = ptr.To(int32(6))
+				aw = toAppWrapper(comp)
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+			})
+
+			It("PodSets are inferred for RayServices", func() {
+				aw := toAppWrapper(rayServiceForInference(3, 100))
+
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed(), "PodSets should be inferred")
+				Expect(aw.Spec.Suspend).Should(BeTrue())
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+
+				aw = toAppWrapper(rayServiceForInference(3, 100))
+				aw.Spec.Components[0].DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{
+					{Replicas: ptr.To(int32(1)), Path: "template.spec.headGroupSpec.template"},
+					{Replicas: ptr.To(int32(3)), Path: "template.spec.workerGroupSpecs[0].template"},
+				}
+				Expect(k8sClient.Create(ctx, aw)).ShouldNot(Succeed(), "The RayCluster spec of a RayService is nested in its rayClusterConfig")
+
+				aw = toAppWrapper(rayServiceForInference(3, 100))
+				aw.Spec.Components[0].DeclaredPodSets = []workloadv1beta2.AppWrapperPodSet{
+					{Replicas: ptr.To(int32(1)), Path: "template.spec.rayClusterConfig.headGroupSpec.template"},
+					{Replicas: ptr.To(int32(3)), Path: "template.spec.rayClusterConfig.workerGroupSpecs[0].template"},
+				}
+				Expect(k8sClient.Create(ctx, aw)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, aw)).To(Succeed())
+			})
 		})
 	})
 
 })
+
+// warningRecorder collects the warnings returned by the API server
+type warningRecorder struct {
+	sync.Mutex
+	warnings []string
+}
+
+func (r *warningRecorder) HandleWarningHeader(_ int, _ string, text string) {
+	r.Lock()
+	defer r.Unlock()
+	r.warnings = append(r.warnings, text)
+}
+
+func (r *warningRecorder) messages() []string {
+	r.Lock()
+	defer r.Unlock()
+	return append([]string{}, r.warnings...)
+}
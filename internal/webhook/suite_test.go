@@ -64,6 +64,7 @@ const limitedUserName = "limited-user"
 const limitedUserID = "8da0fcfe-6d7f-4f44-b433-d91d22cc1b8c"
 const defaultQueueName = "system-default-queue"
 const userProvidedQueueName = "user-provided-queue"
+const requiredPodLabel = "required-by-operator"
 
 func TestWebhooks(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -78,7 +79,9 @@ var _ = BeforeSuite(func() {
 
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
-		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "config", "crd", "bases"),
+			filepath.Join("..", "..", "dep-crds", "kueue")},
 		ErrorIfCRDPathMissing: false,
 
 		// The BinaryAssetsDirectory is only required if you want to run the tests directly
@@ -161,6 +164,7 @@ var _ = BeforeSuite(func() {
 
 	conf := config.NewAppWrapperConfig()
 	conf.DefaultQueueName = defaultQueueName // add default queue name
+	conf.RequiredPodLabels = map[string]string{requiredPodLabel: "true"}
 	err = SetupAppWrapperWebhook(mgr, conf)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package builder provides a fluent API for constructing AppWrappers from typed Kubernetes objects
+// without hand-marshaling their templates.
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	workloadv1beta2 "github.com/project-codeflare/appwrapper/api/v1beta2"
+	"github.com/project-codeflare/appwrapper/pkg/utils"
+)
+
+// QueueNameLabel is the label that associates an AppWrapper with a Kueue LocalQueue
+const QueueNameLabel = "kueue.x-k8s.io/queue-name"
+
+// AppWrapperBuilder incrementally constructs an AppWrapper.
+// Errors are accumulated and reported by Build.
+type AppWrapperBuilder struct {
+	aw   *workloadv1beta2.AppWrapper
+	errs []error
+}
+
+// New returns a builder for an AppWrapper with the given name and namespace
+func New(name string, namespace string) *AppWrapperBuilder {
+	return &AppWrapperBuilder{
+		aw: &workloadv1beta2.AppWrapper{
+			TypeMeta:   metav1.TypeMeta{APIVersion: workloadv1beta2.GroupVersion.String(), Kind: "AppWrapper"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		},
+	}
+}
+
+// WithComponent adds obj as a component of the AppWrapper.
+// Typed objects of the client-go scheme do not need to set their TypeMeta; other objects
+// (for example *unstructured.Unstructured) must. If podSetPaths are given they are declared as the
+// PodSets of the component, with the replicas inferred for known kinds; otherwise the PodSets of known kinds are inferred.
+// The replicas of a DaemonSet cannot be inferred, so Build reports an error for a DaemonSet component.
+func (b *AppWrapperBuilder) WithComponent(obj runtime.Object, podSetPaths ...string) *AppWrapperBuilder {
+	componentIdx := len(b.aw.Spec.Components)
+	content, err := toUnstructured(obj)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("component %v: %w", componentIdx, err))
+		return b
+	}
+	raw, err := json.Marshal(content.Object)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("component %v: %w", componentIdx, err))
+		return b
+	}
+
+	component := workloadv1beta2.AppWrapperComponent{Template: runtime.RawExtension{Raw: raw}}
+	inferred, err := utils.InferPodSets(content)
+	if len(podSetPaths) > 0 {
+		// declared PodSets take the number of replicas of the inferred PodSet with the same path
+		for _, path := range podSetPaths {
+			podSet := workloadv1beta2.AppWrapperPodSet{Path: path}
+			for _, ps := range inferred {
+				if ps.Path == path {
+					podSet.Replicas = ps.Replicas
+				}
+			}
+			component.DeclaredPodSets = append(component.DeclaredPodSets, podSet)
+		}
+	} else if err == nil {
+		component.DeclaredPodSets = inferred
+	}
+	// errors inferring PodSets are reported by Build
+
+	b.aw.Spec.Components = append(b.aw.Spec.Components, component)
+	return b
+}
+
+// WithQueue sets the name of the Kueue LocalQueue to which the AppWrapper is submitted
+func (b *AppWrapperBuilder) WithQueue(queueName string) *AppWrapperBuilder {
+	return b.WithLabel(QueueNameLabel, queueName)
+}
+
+// WithLabel adds a label to the AppWrapper
+func (b *AppWrapperBuilder) WithLabel(key string, value string) *AppWrapperBuilder {
+	if b.aw.Labels == nil {
+		b.aw.Labels = map[string]string{}
+	}
+	b.aw.Labels[key] = value
+	return b
+}
+
+// WithAnnotation adds an annotation to the AppWrapper (for example one of its fault tolerance annotations)
+func (b *AppWrapperBuilder) WithAnnotation(key string, value string) *AppWrapperBuilder {
+	if b.aw.Annotations == nil {
+		b.aw.Annotations = map[string]string{}
+	}
+	b.aw.Annotations[key] = value
+	return b
+}
+
+// Build returns the constructed AppWrapper after checking the structural invariants that
+// the AppWrapper webhook enforces when the AppWrapper is created
+func (b *AppWrapperBuilder) Build() (*workloadv1beta2.AppWrapper, error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+	if errs := utils.ValidateComponents(b.aw); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return b.aw.DeepCopy(), nil
+}
+
+// toUnstructured converts obj into an Unstructured with its apiVersion and kind set
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		var err error
+		if gvk, err = apiutil.GVKForObject(obj, clientgoscheme.Scheme); err != nil {
+			return nil, err
+		}
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	// A wrapped resource is created by the AppWrapper controller; it has neither a status nor a creationTimestamp
+	delete(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+
+	result := &unstructured.Unstructured{Object: content}
+	result.SetGroupVersionKind(gvk)
+	return result, nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/kueue/pkg/util/limitrange"
+
+	workloadv1beta2 "github.com/project-codeflare/appwrapper/api/v1beta2"
+	"github.com/project-codeflare/appwrapper/pkg/utils"
+)
+
+func TestBuilder(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "AppWrapper Builder Unit Tests")
+}
+
+func podSpec() v1.PodSpec {
+	return v1.PodSpec{
+		RestartPolicy: v1.RestartPolicyNever,
+		Containers: []v1.Container{{
+			Name:    "busybox",
+			Image:   "quay.io/project-codeflare/busybox:1.36",
+			Command: []string{"sh", "-c", "sleep 10"},
+		}},
+	}
+}
+
+func job(name string, parallelism int32) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: batchv1.JobSpec{
+			Parallelism: ptr.To(parallelism),
+			Completions: ptr.To(parallelism),
+			Template:    v1.PodTemplateSpec{Spec: podSpec()},
+		},
+	}
+}
+
+func rayCluster(name string, workers int64) *unstructured.Unstructured {
+	template := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "ray", "image": "rayproject/ray:2.9.0"},
+			},
+		},
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "ray.io/v1",
+		"kind":       "RayCluster",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"headGroupSpec": map[string]interface{}{"template": template},
+			"workerGroupSpecs": []interface{}{
+				map[string]interface{}{"groupName": "workers", "replicas": workers, "template": template},
+			},
+		},
+	}}
+}
+
+var _ = Describe("AppWrapper Builder", func() {
+	It("Builds an AppWrapper containing a Job", func() {
+		aw, err := New("job-aw", "default").WithComponent(job("my-job", 2)).WithQueue("user-queue").Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(aw.Name).Should(Equal("job-aw"))
+		Expect(aw.Namespace).Should(Equal("default"))
+		Expect(aw.Labels).Should(HaveKeyWithValue(QueueNameLabel, "user-queue"))
+		Expect(aw.Spec.Components).Should(HaveLen(1))
+		Expect(aw.Spec.Components[0].DeclaredPodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{
+			{Replicas: ptr.To(int32(2)), Path: "template.spec.template"},
+		}))
+
+		By("Setting the apiVersion and kind of the typed Job in its template")
+		obj := &unstructured.Unstructured{}
+		_, _, err = unstructured.UnstructuredJSONScheme.Decode(aw.Spec.Components[0].Template.Raw, nil, obj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.GetAPIVersion()).Should(Equal("batch/v1"))
+		Expect(obj.GetKind()).Should(Equal("Job"))
+		Expect(obj.GetName()).Should(Equal("my-job"))
+		Expect(obj.Object).ShouldNot(HaveKey("status"))
+		Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(2)))
+	})
+
+	It("Builds an AppWrapper containing a RayCluster", func() {
+		aw, err := New("ray-aw", "default").WithComponent(rayCluster("my-cluster", 3)).Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(aw.Spec.Components).Should(HaveLen(1))
+		Expect(aw.Spec.Components[0].DeclaredPodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{
+			{Replicas: ptr.To(int32(1)), Path: "template.spec.headGroupSpec.template"},
+			{Replicas: ptr.To(int32(3)), Path: "template.spec.workerGroupSpecs[0].template"},
+		}))
+		Expect(utils.ExpectedPodCount(aw)).Should(Equal(int32(4)))
+	})
+
+	It("Declares the given podSet paths", func() {
+		aw, err := New("declared-aw", "default").WithComponent(job("my-job", 1), "template.spec.template").Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(aw.Spec.Components[0].DeclaredPodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{{Path: "template.spec.template", Replicas: ptr.To(int32(1))}}))
+	})
+
+	It("Declares the given podSet paths with the inferred replicas", func() {
+		aw, err := New("declared-aw", "default").WithComponent(job("my-job", 4), "template.spec.template").Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(aw.Spec.Components[0].DeclaredPodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{{Path: "template.spec.template", Replicas: ptr.To(int32(4))}}))
+	})
+
+	It("Reports the structural errors rejected by the webhook", func() {
+		_, err := New("bad-path", "default").WithComponent(job("my-job", 1), "template.spec.missing").Build()
+		Expect(err).Should(MatchError(ContainSubstring("spec.components[0].podSets[0].path")))
+
+		other := job("my-job", 1)
+		other.Namespace = "other"
+		_, err = New("bad-ns", "default").WithComponent(other).Build()
+		Expect(err).Should(MatchError(ContainSubstring("AppWrappers cannot create objects in other namespaces")))
+
+		_, err = New("duplicate", "default").WithComponent(job("my-job", 1)).WithComponent(job("my-job", 1)).Build()
+		Expect(err).Should(MatchError(ContainSubstring("spec.components[1].template.metadata.name: Duplicate value")))
+
+		_, err = New("no-podsets", "default").WithComponent(&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service"}}).Build()
+		Expect(err).Should(MatchError(ContainSubstring("components contains no podspecs")))
+	})
+
+	It("Infers and validates the PodSets of registered custom resources", func() {
+		trainer := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Trainer",
+			"metadata":   map[string]interface{}{"name": "my-trainer"},
+			"spec": map[string]interface{}{
+				"workers":  int64(4),
+				"template": map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "trainer", "image": "busybox"}}}},
+			},
+		}}
+		Expect(utils.RegisterPodSetTemplate(trainer.GroupVersionKind(), "template.spec.template", "template.spec.workers")).To(Succeed())
+		DeferCleanup(utils.UnregisterPodSetTemplates, trainer.GroupVersionKind())
+
+		aw, err := New("custom-aw", "default").WithComponent(trainer).Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(aw.Spec.Components[0].DeclaredPodSets).Should(Equal([]workloadv1beta2.AppWrapperPodSet{
+			{Replicas: ptr.To(int32(4)), Path: "template.spec.template"},
+		}))
+
+		_, err = New("custom-mismatch", "default").WithComponent(trainer, "template.spec.template", "template.spec.template").Build()
+		Expect(err).Should(HaveOccurred())
+
+		Expect(utils.RegisterPodSetTemplate(batchv1.SchemeGroupVersion.WithKind("Job"), "template.spec.template", "")).ShouldNot(Succeed())
+	})
+
+	It("Preserves the init container and sidecar requests of PodSet templates", func() {
+		cpu := func(q string) v1.ResourceRequirements {
+			return v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(q)}}
+		}
+		effectiveCPU := func(pod *v1.Pod) string {
+			aw, err := New("init-aw", "default").WithComponent(pod).Build()
+			Expect(err).NotTo(HaveOccurred())
+			podSets, err := utils.GetPodSets(aw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podSets).Should(HaveLen(1))
+			total := limitrange.TotalRequests(&podSets[0].Template.Spec)[v1.ResourceCPU]
+			return total.String()
+		}
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pod"}, Spec: podSpec()}
+		pod.Spec.Containers[0].Resources = cpu("1")
+		pod.Spec.InitContainers = []v1.Container{{Name: "setup", Image: "busybox", Resources: cpu("2")}}
+		Expect(effectiveCPU(pod)).Should(Equal("2"))
+
+		By("Adding the requests of a sidecar to those of the init containers that follow it and the containers")
+		sidecar := v1.Container{Name: "proxy", Image: "busybox", Resources: cpu("500m"), RestartPolicy: ptr.To(v1.ContainerRestartPolicyAlways)}
+		pod.Spec.InitContainers = append([]v1.Container{sidecar}, pod.Spec.InitContainers...)
+		Expect(effectiveCPU(pod)).Should(Equal("2500m"))
+	})
+
+	It("Reports objects whose kind cannot be determined", func() {
+		_, err := New("unknown", "default").WithComponent(&unstructured.Unstructured{Object: map[string]interface{}{}}).Build()
+		Expect(err).Should(HaveOccurred())
+	})
+})
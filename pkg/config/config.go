@@ -17,12 +17,21 @@ limitations under the License.
 package config
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/yaml"
 )
 
 type OperatorConfig struct {
@@ -33,14 +42,91 @@ type OperatorConfig struct {
 }
 
 type AppWrapperConfig struct {
-	EnableKueueIntegrations bool                       `json:"enableKueueIntegrations,omitempty"`
-	KueueJobReconciller     *KueueJobReconcillerConfig `json:"kueueJobReconciller,omitempty"`
-	Autopilot               *AutopilotConfig           `json:"autopilot,omitempty"`
-	UserRBACAdmissionCheck  bool                       `json:"userRBACAdmissionCheck,omitempty"`
-	FaultTolerance          *FaultToleranceConfig      `json:"faultTolerance,omitempty"`
-	SchedulerName           string                     `json:"schedulerName,omitempty"`
-	DefaultQueueName        string                     `json:"defaultQueueName,omitempty"`
-	SlackQueueName          string                     `json:"slackQueueName,omitempty"`
+	EnableKueueIntegrations      bool                       `json:"enableKueueIntegrations,omitempty"`
+	KueueJobReconciller          *KueueJobReconcillerConfig `json:"kueueJobReconciller,omitempty"`
+	Autopilot                    *AutopilotConfig           `json:"autopilot,omitempty"`
+	UserRBACAdmissionCheck       bool                       `json:"userRBACAdmissionCheck,omitempty"`
+	UserRBACCheckConcurrency     int                        `json:"userRBACCheckConcurrency,omitempty"`
+	UserRBACCheckTimeout         time.Duration              `json:"userRBACCheckTimeout,omitempty"`
+	UserRBACExemptKinds          []RBACExemptKind           `json:"userRBACExemptKinds,omitempty"`
+	FaultTolerance               *FaultToleranceConfig      `json:"faultTolerance,omitempty"`
+	SchedulerName                string                     `json:"schedulerName,omitempty"`
+	PriorityClassName            string                     `json:"priorityClassName,omitempty"`
+	RuntimeClassName             string                     `json:"runtimeClassName,omitempty"`
+	RuntimeClassResources        []string                   `json:"runtimeClassResources,omitempty"`
+	DefaultServiceAccountName    string                     `json:"defaultServiceAccountName,omitempty"`
+	DefaultQueueName             string                     `json:"defaultQueueName,omitempty"`
+	SlackQueueName               string                     `json:"slackQueueName,omitempty"`
+	RequiredPodLabels            map[string]string          `json:"requiredPodLabels,omitempty"`
+	PropagatedMetadataPrefixes   []string                   `json:"propagatedMetadataPrefixes,omitempty"`
+	ObservabilityPodAnnotations  map[string]string          `json:"observabilityPodAnnotations,omitempty"`
+	AdmissionGateName            string                     `json:"admissionGateName,omitempty"`
+	ImagePullSecrets             []string                   `json:"imagePullSecrets,omitempty"`
+	ComponentFailureRules        []ComponentFailureRule     `json:"componentFailureRules,omitempty"`
+	PodSetTemplates              []PodSetTemplate           `json:"podSetTemplates,omitempty"`
+	ParentRefKinds               []ParentRefKind            `json:"parentRefKinds,omitempty"`
+	LabelPrefix                  string                     `json:"labelPrefix,omitempty"`
+	DeletionRequeueInterval      time.Duration              `json:"deletionRequeueInterval,omitempty"`
+	RunningRequeueInterval       time.Duration              `json:"runningRequeueInterval,omitempty"`
+	WarmupRequeueInterval        time.Duration              `json:"warmupRequeueInterval,omitempty"`
+	DeploymentRetryInterval      time.Duration              `json:"deploymentRetryInterval,omitempty"`
+	MaxReconcileDuration         time.Duration              `json:"maxReconcileDuration,omitempty"`
+	ComponentCreationConcurrency int                        `json:"componentCreationConcurrency,omitempty"`
+	ResyncPeriod                 time.Duration              `json:"resyncPeriod,omitempty"`
+	TopologySpread               *TopologySpreadConfig      `json:"topologySpread,omitempty"`
+	CreatePodlessComponentsFirst bool                       `json:"createPodlessComponentsFirst,omitempty"`
+	RecordLastReconcile          bool                       `json:"recordLastReconcile,omitempty"`
+	MissingQueuePolicy           MissingQueuePolicy         `json:"missingQueuePolicy,omitempty"`
+	UserMetricsLabel             bool                       `json:"userMetricsLabel,omitempty"`
+	LenientConfigValidation      bool                       `json:"lenientConfigValidation,omitempty"`
+}
+
+// DefaultLabelPrefix is the prefix of the AppWrapper finalizer and of the label that associates Pods with their AppWrapper
+const DefaultLabelPrefix = "workload.codeflare.dev"
+
+// MissingQueuePolicy determines how the controller treats a Running AppWrapper whose
+// Kueue LocalQueue, or the ClusterQueue of that LocalQueue, has been deleted
+type MissingQueuePolicy string
+
+const (
+	// MissingQueueWarn leaves the AppWrapper running; only its QueueMissing condition and an event report the problem
+	MissingQueueWarn MissingQueuePolicy = "Warn"
+	// MissingQueueSuspend also deactivates the Workload of the AppWrapper so that Kueue suspends it
+	MissingQueueSuspend MissingQueuePolicy = "Suspend"
+)
+
+// ComponentFailureRule marks a wrapped resource of the given APIVersion and Kind as failed
+// when the value found at FailedJSONPath in the resource is equal to FailedValue.
+// Rules are only consulted for resource types that do not have built-in failure detection.
+type ComponentFailureRule struct {
+	APIVersion     string `json:"apiVersion"`
+	Kind           string `json:"kind"`
+	FailedJSONPath string `json:"failedJSONPath"`
+	FailedValue    string `json:"failedValue"`
+}
+
+// PodSetTemplate tells PodSet inference where to find a PodTemplateSpec and its replica count in a wrapped
+// resource of the given APIVersion and Kind. Paths are relative to the component (eg template.spec.template);
+// an empty ReplicasPath means a single replica. Templates can only be added for types without built-in inference.
+type PodSetTemplate struct {
+	APIVersion   string `json:"apiVersion"`
+	Kind         string `json:"kind"`
+	TemplatePath string `json:"templatePath"`
+	ReplicasPath string `json:"replicasPath,omitempty"`
+}
+
+// ParentRefKind is the APIVersion and Kind of a resource that AppWrappers may reference as their ParentRef.
+// The controller watches resources of these kinds and reconciles the AppWrappers that reference a changed resource.
+type ParentRefKind struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// RBACExemptKind is the APIVersion and Kind of a wrapped resource for which the
+// userRBACAdmissionCheck does not perform a SubjectAccessReview.
+type RBACExemptKind struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
 }
 
 type KueueJobReconcillerConfig struct {
@@ -51,20 +137,57 @@ type KueueJobReconcillerConfig struct {
 }
 
 type AutopilotConfig struct {
-	InjectAntiAffinities bool                  `json:"injectAntiAffinities,omitempty"`
-	MonitorNodes         bool                  `json:"monitorNodes,omitempty"`
-	ResourceTaints       map[string][]v1.Taint `json:"resourceTaints,omitempty"`
+	InjectAntiAffinities bool `json:"injectAntiAffinities,omitempty"`
+	IgnoreInitContainers bool `json:"ignoreInitContainers,omitempty"`
+	// MonitorNodes enables the watch of Nodes that tracks NoExecute, NoSchedule, and unreachable Nodes.
+	// Without it, only Pods whose phase is Unknown or that are marked NodeLost are detected as lost.
+	MonitorNodes    bool                  `json:"monitorNodes,omitempty"`
+	ResourceTaints  map[string][]v1.Taint `json:"resourceTaints,omitempty"`
+	NodeHealthRules []NodeHealthRule      `json:"nodeHealthRules,omitempty"`
+	ResyncPeriod    time.Duration         `json:"resyncPeriod,omitempty"`
+}
+
+// NodeHealthRule marks Resource as NoExecute on Nodes whose LabelKey label has one of the UnhealthyValues.
+// NodeHealthRules are in addition to the ResourceTaints: a Node matching either is NoExecute for the
+// resource (and also NoSchedule, as with NoExecute taints).
+type NodeHealthRule struct {
+	LabelKey        string   `json:"labelKey"`
+	UnhealthyValues []string `json:"unhealthyValues"`
+	Resource        string   `json:"resource"`
+}
+
+// TopologySpreadConfig describes the topologySpreadConstraints that are injected into every PodSet
+// when InjectConstraints is true. Each injected constraint spreads the Pods of a single PodSet.
+type TopologySpreadConfig struct {
+	InjectConstraints bool                       `json:"injectConstraints,omitempty"`
+	Constraints       []TopologySpreadConstraint `json:"constraints,omitempty"`
+}
+
+type TopologySpreadConstraint struct {
+	TopologyKey       string                           `json:"topologyKey"`
+	MaxSkew           int32                            `json:"maxSkew"`
+	WhenUnsatisfiable v1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable"`
 }
 
 type FaultToleranceConfig struct {
-	AdmissionGracePeriod        time.Duration `json:"admissionGracePeriod,omitempty"`
-	WarmupGracePeriod           time.Duration `json:"warmupGracePeriod,omitempty"`
-	FailureGracePeriod          time.Duration `json:"failureGracePeriod,omitempty"`
-	RetryPausePeriod            time.Duration `json:"resetPause,omitempty"`
-	RetryLimit                  int32         `json:"retryLimit,omitempty"`
-	ForcefulDeletionGracePeriod time.Duration `json:"deletionGracePeriod,omitempty"`
-	GracePeriodMaximum          time.Duration `json:"gracePeriodCeiling,omitempty"`
-	SuccessTTL                  time.Duration `json:"successTTLCeiling,omitempty"`
+	AdmissionGracePeriod         time.Duration              `json:"admissionGracePeriod,omitempty"`
+	WarmupGracePeriod            time.Duration              `json:"warmupGracePeriod,omitempty"`
+	FailureGracePeriod           time.Duration              `json:"failureGracePeriod,omitempty"`
+	RetryPausePeriod             time.Duration              `json:"resetPause,omitempty"`
+	RetryPauseBackoffFactor      float64                    `json:"retryPauseBackoffFactor,omitempty"`
+	RetryLimit                   int32                      `json:"retryLimit,omitempty"`
+	InfrastructureRetryLimit     int32                      `json:"infrastructureRetryLimit,omitempty"`
+	FailedPodDeletionLimit       int32                      `json:"failedPodDeletionLimit,omitempty"`
+	InfrastructureFailureReasons []string                   `json:"infrastructureFailureReasons,omitempty"`
+	ForcefulDeletionGracePeriod  time.Duration              `json:"deletionGracePeriod,omitempty"`
+	SuccessDeletionGracePeriod   time.Duration              `json:"successDeletionGracePeriod,omitempty"`
+	IgnoreTerminatedPods         bool                       `json:"ignoreTerminatedPods,omitempty"`
+	GracePeriodMaximum           time.Duration              `json:"gracePeriodCeiling,omitempty"`
+	SuccessTTL                   time.Duration              `json:"successTTLCeiling,omitempty"`
+	FailedTTL                    time.Duration              `json:"failedTTL,omitempty"`
+	MigrationDrainPeriod         time.Duration              `json:"migrationDrainPeriod,omitempty"`
+	DeletionPropagationPolicy    metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+	MinimumRunningDuration       time.Duration              `json:"minimumRunningDuration,omitempty"`
 }
 
 type CertManagementConfig struct {
@@ -83,6 +206,7 @@ type ControllerManagerConfig struct {
 	Health         HealthConfiguration  `json:"health,omitempty"`
 	LeaderElection bool                 `json:"leaderElection,omitempty"`
 	EnableHTTP2    bool                 `json:"enableHTTP2,omitempty"`
+	SyncPeriod     time.Duration        `json:"syncPeriod,omitempty"`
 }
 
 type MetricsConfiguration struct {
@@ -96,6 +220,7 @@ type HealthConfiguration struct {
 // NewAppWrapperConfig constructs an AppWrapperConfig and fills in default values
 func NewAppWrapperConfig() *AppWrapperConfig {
 	return &AppWrapperConfig{
+		LabelPrefix:             DefaultLabelPrefix,
 		EnableKueueIntegrations: true,
 		KueueJobReconciller: &KueueJobReconcillerConfig{
 			ManageJobsWithoutQueueName: true,
@@ -121,52 +246,326 @@ func NewAppWrapperConfig() *AppWrapperConfig {
 					{Key: "autopilot.ibm.com/gpuhealth", Value: "EVICT", Effect: v1.TaintEffectNoExecute}},
 			},
 		},
-		UserRBACAdmissionCheck: true,
+		UserRBACAdmissionCheck:   true,
+		UserRBACCheckConcurrency: 8,
+		UserRBACCheckTimeout:     8 * time.Second, // the default webhook timeout is 10 seconds
 		FaultTolerance: &FaultToleranceConfig{
-			AdmissionGracePeriod:        1 * time.Minute,
-			WarmupGracePeriod:           5 * time.Minute,
-			FailureGracePeriod:          1 * time.Minute,
-			RetryPausePeriod:            90 * time.Second,
-			RetryLimit:                  3,
-			ForcefulDeletionGracePeriod: 10 * time.Minute,
-			GracePeriodMaximum:          24 * time.Hour,
-			SuccessTTL:                  7 * 24 * time.Hour,
+			AdmissionGracePeriod:         1 * time.Minute,
+			WarmupGracePeriod:            5 * time.Minute,
+			FailureGracePeriod:           1 * time.Minute,
+			RetryPausePeriod:             90 * time.Second,
+			RetryPauseBackoffFactor:      1.0,
+			RetryLimit:                   3,
+			InfrastructureRetryLimit:     3,
+			InfrastructureFailureReasons: []string{"Evicted", "NodeLost", "Shutdown", "Terminated", "UnexpectedAdmissionError"},
+			ForcefulDeletionGracePeriod:  10 * time.Minute,
+			SuccessDeletionGracePeriod:   10 * time.Minute,
+			GracePeriodMaximum:           24 * time.Hour,
+			SuccessTTL:                   7 * 24 * time.Hour,
+			DeletionPropagationPolicy:    metav1.DeletePropagationBackground,
 		},
+		DeletionRequeueInterval:      5 * time.Second,
+		RunningRequeueInterval:       1 * time.Minute,
+		WarmupRequeueInterval:        5 * time.Second,
+		DeploymentRetryInterval:      1 * time.Second,
+		ComponentCreationConcurrency: 1,
+		CreatePodlessComponentsFirst: true,
+		MissingQueuePolicy:           MissingQueueWarn,
 	}
 }
 
+// LiveAppWrapperConfig publishes the AppWrapperConfig that is currently in effect.
+// A published AppWrapperConfig must not be mutated; updates publish a modified copy instead.
+type LiveAppWrapperConfig struct {
+	current atomic.Pointer[AppWrapperConfig]
+}
+
+func NewLiveAppWrapperConfig(config *AppWrapperConfig) *LiveAppWrapperConfig {
+	live := &LiveAppWrapperConfig{}
+	live.current.Store(config)
+	return live
+}
+
+// Load returns the AppWrapperConfig that is currently in effect
+func (l *LiveAppWrapperConfig) Load() *AppWrapperConfig {
+	return l.current.Load()
+}
+
+// Store publishes config, which replaces the AppWrapperConfig currently in effect
+func (l *LiveAppWrapperConfig) Store(config *AppWrapperConfig) {
+	l.current.Store(config)
+}
+
+// ConfigErrorSeverity classifies the problems found by ValidateAppWrapperConfig
+type ConfigErrorSeverity int
+
+const (
+	// NonCritical problems can be repaired by falling back to the default value of the offending field
+	NonCritical ConfigErrorSeverity = iota
+	// Critical problems cannot be repaired without changing the identity or policies of the operator
+	Critical
+)
+
+// ConfigError is a problem with a field of an AppWrapperConfig
+type ConfigError struct {
+	Severity ConfigErrorSeverity
+	Field    string
+	err      error
+	fallback func()
+}
+
+func (e *ConfigError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.err
+}
+
+func nonCritical(field string, fallback func(), err error) error {
+	return &ConfigError{Severity: NonCritical, Field: field, err: err, fallback: fallback}
+}
+
+func critical(field string, err error) error {
+	return &ConfigError{Severity: Critical, Field: field, err: err}
+}
+
+// ValidateAppWrapperConfig returns the first problem found in config as a *ConfigError
 func ValidateAppWrapperConfig(config *AppWrapperConfig) error {
-	if config.FaultTolerance.ForcefulDeletionGracePeriod > config.FaultTolerance.GracePeriodMaximum {
-		return fmt.Errorf("ForcefulDelectionGracePeriod %v exceeds GracePeriodCeiling %v",
-			config.FaultTolerance.ForcefulDeletionGracePeriod, config.FaultTolerance.GracePeriodMaximum)
+	defaults := NewAppWrapperConfig()
+	ft := config.FaultTolerance
+	if errs := validation.IsQualifiedName(AppWrapperLabel(config)); len(errs) > 0 {
+		return critical("LabelPrefix", fmt.Errorf("LabelPrefix %q is invalid: %v", config.LabelPrefix, strings.Join(errs, "; ")))
+	}
+	if ft.ForcefulDeletionGracePeriod > ft.GracePeriodMaximum {
+		return nonCritical("ForcefulDeletionGracePeriod",
+			func() {
+				ft.ForcefulDeletionGracePeriod = min(defaults.FaultTolerance.ForcefulDeletionGracePeriod, ft.GracePeriodMaximum)
+			},
+			fmt.Errorf("ForcefulDelectionGracePeriod %v exceeds GracePeriodCeiling %v", ft.ForcefulDeletionGracePeriod, ft.GracePeriodMaximum))
+	}
+	if ft.RetryPausePeriod > ft.GracePeriodMaximum {
+		return nonCritical("RetryPausePeriod",
+			func() { ft.RetryPausePeriod = min(defaults.FaultTolerance.RetryPausePeriod, ft.GracePeriodMaximum) },
+			fmt.Errorf("RetryPausePeriod %v exceeds GracePeriodCeiling %v", ft.RetryPausePeriod, ft.GracePeriodMaximum))
+	}
+	if ft.FailureGracePeriod > ft.GracePeriodMaximum {
+		return nonCritical("FailureGracePeriod",
+			func() { ft.FailureGracePeriod = min(defaults.FaultTolerance.FailureGracePeriod, ft.GracePeriodMaximum) },
+			fmt.Errorf("FailureGracePeriod %v exceeds GracePeriodCeiling %v", ft.FailureGracePeriod, ft.GracePeriodMaximum))
+	}
+	if ft.AdmissionGracePeriod > ft.GracePeriodMaximum {
+		return nonCritical("AdmissionGracePeriod",
+			func() {
+				ft.AdmissionGracePeriod = min(defaults.FaultTolerance.AdmissionGracePeriod, ft.GracePeriodMaximum)
+			},
+			fmt.Errorf("AdmissionGracePeriod %v exceeds GracePeriodCeiling %v", ft.AdmissionGracePeriod, ft.GracePeriodMaximum))
+	}
+	if ft.WarmupGracePeriod > ft.GracePeriodMaximum {
+		return nonCritical("WarmupGracePeriod",
+			func() { ft.WarmupGracePeriod = min(defaults.FaultTolerance.WarmupGracePeriod, ft.GracePeriodMaximum) },
+			fmt.Errorf("AdmissionGracePeriod %v exceeds GracePeriodCeiling %v", ft.WarmupGracePeriod, ft.GracePeriodMaximum))
+	}
+	if ft.AdmissionGracePeriod > ft.WarmupGracePeriod {
+		return nonCritical("AdmissionGracePeriod",
+			func() {
+				ft.AdmissionGracePeriod = min(defaults.FaultTolerance.AdmissionGracePeriod, ft.WarmupGracePeriod)
+			},
+			fmt.Errorf("AdmissionGracePeriod %v exceeds AdmissionGracePeriod %v", ft.WarmupGracePeriod, ft.GracePeriodMaximum))
+	}
+	if ft.MigrationDrainPeriod > ft.GracePeriodMaximum {
+		return nonCritical("MigrationDrainPeriod",
+			func() {
+				ft.MigrationDrainPeriod = min(defaults.FaultTolerance.MigrationDrainPeriod, ft.GracePeriodMaximum)
+			},
+			fmt.Errorf("MigrationDrainPeriod %v exceeds GracePeriodCeiling %v", ft.MigrationDrainPeriod, ft.GracePeriodMaximum))
+	}
+	if ft.MinimumRunningDuration > ft.GracePeriodMaximum {
+		return nonCritical("MinimumRunningDuration",
+			func() {
+				ft.MinimumRunningDuration = min(defaults.FaultTolerance.MinimumRunningDuration, ft.GracePeriodMaximum)
+			},
+			fmt.Errorf("MinimumRunningDuration %v exceeds GracePeriodCeiling %v", ft.MinimumRunningDuration, ft.GracePeriodMaximum))
 	}
-	if config.FaultTolerance.RetryPausePeriod > config.FaultTolerance.GracePeriodMaximum {
-		return fmt.Errorf("RetryPausePeriod %v exceeds GracePeriodCeiling %v",
-			config.FaultTolerance.RetryPausePeriod, config.FaultTolerance.GracePeriodMaximum)
+	if ft.SuccessTTL <= 0 {
+		return nonCritical("SuccessTTL", func() { ft.SuccessTTL = defaults.FaultTolerance.SuccessTTL },
+			fmt.Errorf("SuccessTTL %v is not a positive duration", ft.SuccessTTL))
 	}
-	if config.FaultTolerance.FailureGracePeriod > config.FaultTolerance.GracePeriodMaximum {
-		return fmt.Errorf("FailureGracePeriod %v exceeds GracePeriodCeiling %v",
-			config.FaultTolerance.FailureGracePeriod, config.FaultTolerance.GracePeriodMaximum)
+	if ft.FailedTTL < 0 {
+		return nonCritical("FailedTTL", func() { ft.FailedTTL = defaults.FaultTolerance.FailedTTL },
+			fmt.Errorf("FailedTTL %v is negative", ft.FailedTTL))
 	}
-	if config.FaultTolerance.AdmissionGracePeriod > config.FaultTolerance.GracePeriodMaximum {
-		return fmt.Errorf("AdmissionGracePeriod %v exceeds GracePeriodCeiling %v",
-			config.FaultTolerance.AdmissionGracePeriod, config.FaultTolerance.GracePeriodMaximum)
+	if config.Autopilot != nil && config.Autopilot.ResyncPeriod < 0 {
+		return nonCritical("Autopilot.ResyncPeriod", func() { config.Autopilot.ResyncPeriod = defaults.Autopilot.ResyncPeriod },
+			fmt.Errorf("Autopilot ResyncPeriod %v is negative", config.Autopilot.ResyncPeriod))
 	}
-	if config.FaultTolerance.WarmupGracePeriod > config.FaultTolerance.GracePeriodMaximum {
-		return fmt.Errorf("AdmissionGracePeriod %v exceeds GracePeriodCeiling %v",
-			config.FaultTolerance.WarmupGracePeriod, config.FaultTolerance.GracePeriodMaximum)
+	if ft.InfrastructureRetryLimit < 0 {
+		return nonCritical("InfrastructureRetryLimit", func() { ft.InfrastructureRetryLimit = defaults.FaultTolerance.InfrastructureRetryLimit },
+			fmt.Errorf("InfrastructureRetryLimit %v is negative", ft.InfrastructureRetryLimit))
 	}
-	if config.FaultTolerance.AdmissionGracePeriod > config.FaultTolerance.WarmupGracePeriod {
-		return fmt.Errorf("AdmissionGracePeriod %v exceeds AdmissionGracePeriod %v",
-			config.FaultTolerance.WarmupGracePeriod, config.FaultTolerance.GracePeriodMaximum)
+	if ft.FailedPodDeletionLimit < 0 {
+		return nonCritical("FailedPodDeletionLimit", func() { ft.FailedPodDeletionLimit = defaults.FaultTolerance.FailedPodDeletionLimit },
+			fmt.Errorf("FailedPodDeletionLimit %v is negative", ft.FailedPodDeletionLimit))
 	}
-	if config.FaultTolerance.SuccessTTL <= 0 {
-		return fmt.Errorf("SuccessTTL %v is not a positive duration", config.FaultTolerance.SuccessTTL)
+	if ft.RetryPauseBackoffFactor < 1.0 {
+		return nonCritical("RetryPauseBackoffFactor", func() { ft.RetryPauseBackoffFactor = defaults.FaultTolerance.RetryPauseBackoffFactor },
+			fmt.Errorf("RetryPauseBackoffFactor %v is less than 1.0", ft.RetryPauseBackoffFactor))
+	}
+	if ft.DeletionPropagationPolicy != "" && !ValidDeletionPropagation(ft.DeletionPropagationPolicy) {
+		return nonCritical("DeletionPropagationPolicy", func() { ft.DeletionPropagationPolicy = defaults.FaultTolerance.DeletionPropagationPolicy },
+			fmt.Errorf("DeletionPropagationPolicy %q is not one of Background, Foreground, or Orphan", ft.DeletionPropagationPolicy))
+	}
+	if ft.SuccessDeletionGracePeriod > ft.GracePeriodMaximum {
+		return nonCritical("SuccessDeletionGracePeriod",
+			func() {
+				ft.SuccessDeletionGracePeriod = min(defaults.FaultTolerance.SuccessDeletionGracePeriod, ft.GracePeriodMaximum)
+			},
+			fmt.Errorf("SuccessDeletionGracePeriod %v exceeds GracePeriodCeiling %v", ft.SuccessDeletionGracePeriod, ft.GracePeriodMaximum))
+	}
+	if config.DeletionRequeueInterval <= 0 {
+		return nonCritical("DeletionRequeueInterval", func() { config.DeletionRequeueInterval = defaults.DeletionRequeueInterval },
+			fmt.Errorf("DeletionRequeueInterval %v is not a positive duration", config.DeletionRequeueInterval))
+	}
+	if config.RunningRequeueInterval <= 0 {
+		return nonCritical("RunningRequeueInterval", func() { config.RunningRequeueInterval = defaults.RunningRequeueInterval },
+			fmt.Errorf("RunningRequeueInterval %v is not a positive duration", config.RunningRequeueInterval))
+	}
+	if config.WarmupRequeueInterval <= 0 {
+		return nonCritical("WarmupRequeueInterval", func() { config.WarmupRequeueInterval = defaults.WarmupRequeueInterval },
+			fmt.Errorf("WarmupRequeueInterval %v is not a positive duration", config.WarmupRequeueInterval))
+	}
+	if config.DeploymentRetryInterval <= 0 {
+		return nonCritical("DeploymentRetryInterval", func() { config.DeploymentRetryInterval = defaults.DeploymentRetryInterval },
+			fmt.Errorf("DeploymentRetryInterval %v is not a positive duration", config.DeploymentRetryInterval))
+	}
+	if config.UserRBACCheckConcurrency < 1 {
+		return nonCritical("UserRBACCheckConcurrency", func() { config.UserRBACCheckConcurrency = defaults.UserRBACCheckConcurrency },
+			fmt.Errorf("UserRBACCheckConcurrency %v is not positive", config.UserRBACCheckConcurrency))
+	}
+	if config.UserRBACCheckTimeout <= 0 {
+		return nonCritical("UserRBACCheckTimeout", func() { config.UserRBACCheckTimeout = defaults.UserRBACCheckTimeout },
+			fmt.Errorf("UserRBACCheckTimeout %v is not a positive duration", config.UserRBACCheckTimeout))
+	}
+	for _, k := range config.UserRBACExemptKinds {
+		if gv, err := schema.ParseGroupVersion(k.APIVersion); err != nil || gv.Version == "" || k.Kind == "" {
+			return nonCritical("UserRBACExemptKinds", func() { config.UserRBACExemptKinds = defaults.UserRBACExemptKinds },
+				fmt.Errorf("UserRBACExemptKind %q %q is not a valid apiVersion and kind", k.APIVersion, k.Kind))
+		}
+	}
+	if config.MaxReconcileDuration < 0 {
+		return nonCritical("MaxReconcileDuration", func() { config.MaxReconcileDuration = defaults.MaxReconcileDuration },
+			fmt.Errorf("MaxReconcileDuration %v is negative", config.MaxReconcileDuration))
+	}
+	if config.ComponentCreationConcurrency < 1 {
+		return nonCritical("ComponentCreationConcurrency", func() { config.ComponentCreationConcurrency = defaults.ComponentCreationConcurrency },
+			fmt.Errorf("ComponentCreationConcurrency %v is not positive", config.ComponentCreationConcurrency))
+	}
+	if config.ResyncPeriod < 0 {
+		return nonCritical("ResyncPeriod", func() { config.ResyncPeriod = defaults.ResyncPeriod },
+			fmt.Errorf("ResyncPeriod %v is negative", config.ResyncPeriod))
+	}
+	if config.MissingQueuePolicy != "" && config.MissingQueuePolicy != MissingQueueWarn && config.MissingQueuePolicy != MissingQueueSuspend {
+		return nonCritical("MissingQueuePolicy", func() { config.MissingQueuePolicy = defaults.MissingQueuePolicy },
+			fmt.Errorf("MissingQueuePolicy %q is not one of Warn or Suspend", config.MissingQueuePolicy))
+	}
+	for key := range config.ObservabilityPodAnnotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nonCritical("ObservabilityPodAnnotations", func() { config.ObservabilityPodAnnotations = defaults.ObservabilityPodAnnotations },
+				fmt.Errorf("ObservabilityPodAnnotations has invalid key %q: %v", key, strings.Join(errs, "; ")))
+		}
+	}
+	if config.DefaultServiceAccountName != "" {
+		if errs := validation.IsDNS1123Subdomain(config.DefaultServiceAccountName); len(errs) > 0 {
+			return nonCritical("DefaultServiceAccountName", func() { config.DefaultServiceAccountName = defaults.DefaultServiceAccountName },
+				fmt.Errorf("DefaultServiceAccountName %q is invalid: %v", config.DefaultServiceAccountName, strings.Join(errs, "; ")))
+		}
+	}
+	if config.AdmissionGateName != "" {
+		if errs := validation.IsQualifiedName(config.AdmissionGateName); len(errs) > 0 {
+			return nonCritical("AdmissionGateName", func() { config.AdmissionGateName = defaults.AdmissionGateName },
+				fmt.Errorf("AdmissionGateName %q is invalid: %v", config.AdmissionGateName, strings.Join(errs, "; ")))
+		}
+	}
+	if config.TopologySpread != nil {
+		for _, tsc := range config.TopologySpread.Constraints {
+			if errs := validation.IsQualifiedName(tsc.TopologyKey); len(errs) > 0 {
+				return critical("TopologySpread", fmt.Errorf("TopologySpreadConstraint has invalid topologyKey %q: %v", tsc.TopologyKey, strings.Join(errs, "; ")))
+			}
+			if tsc.MaxSkew < 1 {
+				return critical("TopologySpread", fmt.Errorf("TopologySpreadConstraint for %v has maxSkew %v less than 1", tsc.TopologyKey, tsc.MaxSkew))
+			}
+			if tsc.WhenUnsatisfiable != v1.DoNotSchedule && tsc.WhenUnsatisfiable != v1.ScheduleAnyway {
+				return critical("TopologySpread", fmt.Errorf("TopologySpreadConstraint for %v has invalid whenUnsatisfiable %q", tsc.TopologyKey, tsc.WhenUnsatisfiable))
+			}
+		}
+	}
+	for _, rule := range config.ComponentFailureRules {
+		if _, err := schema.ParseGroupVersion(rule.APIVersion); err != nil || rule.APIVersion == "" {
+			return critical("ComponentFailureRules", fmt.Errorf("ComponentFailureRule has invalid apiVersion %q", rule.APIVersion))
+		}
+		if rule.Kind == "" {
+			return critical("ComponentFailureRules", fmt.Errorf("ComponentFailureRule for %v has an empty kind", rule.APIVersion))
+		}
+		if rule.FailedJSONPath == "" || strings.HasPrefix(rule.FailedJSONPath, ".") || strings.HasSuffix(rule.FailedJSONPath, ".") {
+			return critical("ComponentFailureRules", fmt.Errorf("ComponentFailureRule for %v %v has invalid failedJSONPath %q", rule.APIVersion, rule.Kind, rule.FailedJSONPath))
+		}
+	}
+	for _, k := range config.ParentRefKinds {
+		if gv, err := schema.ParseGroupVersion(k.APIVersion); err != nil || gv.Version == "" {
+			return critical("ParentRefKinds", fmt.Errorf("ParentRefKind has invalid apiVersion %q", k.APIVersion))
+		}
+		if k.Kind == "" {
+			return critical("ParentRefKinds", fmt.Errorf("ParentRefKind for %v has an empty kind", k.APIVersion))
+		}
+	}
+	for _, t := range config.PodSetTemplates {
+		if gv, err := schema.ParseGroupVersion(t.APIVersion); err != nil || gv.Version == "" {
+			return critical("PodSetTemplates", fmt.Errorf("PodSetTemplate has invalid apiVersion %q", t.APIVersion))
+		}
+		if t.Kind == "" {
+			return critical("PodSetTemplates", fmt.Errorf("PodSetTemplate for %v has an empty kind", t.APIVersion))
+		}
+		if !templatePathRegex.MatchString(t.TemplatePath) {
+			return critical("PodSetTemplates", fmt.Errorf("PodSetTemplate for %v %v has invalid templatePath %q", t.APIVersion, t.Kind, t.TemplatePath))
+		}
+		if t.ReplicasPath != "" && (!templatePathRegex.MatchString(t.ReplicasPath) || t.ReplicasPath == "template") {
+			return critical("PodSetTemplates", fmt.Errorf("PodSetTemplate for %v %v has invalid replicasPath %q", t.APIVersion, t.Kind, t.ReplicasPath))
+		}
 	}
 
 	return nil
 }
 
+// templatePathRegex matches the paths of PodSetTemplates, which are relative to a component and use the
+// same field and array index syntax as the paths of declared PodSets (eg template.spec.workers[0].template)
+var templatePathRegex = regexp.MustCompile(`^template(\.[A-Za-z0-9_-]+(\[[0-9]+\])*)*$`)
+
+// ValidDeletionPropagation returns true if policy is one of the propagation policies of the Kubernetes garbage collector
+func ValidDeletionPropagation(policy metav1.DeletionPropagation) bool {
+	return policy == metav1.DeletePropagationBackground || policy == metav1.DeletePropagationForeground || policy == metav1.DeletePropagationOrphan
+}
+
+// SanitizeAppWrapperConfig repairs the NonCritical problems of config by falling back to the default values
+// of the offending fields. It returns the repaired problems and the first Critical problem (if any).
+func SanitizeAppWrapperConfig(config *AppWrapperConfig) ([]error, error) {
+	repaired := []error{}
+	for {
+		err := ValidateAppWrapperConfig(config)
+		if err == nil {
+			return repaired, nil
+		}
+		var cerr *ConfigError
+		if !errors.As(err, &cerr) || cerr.Severity == Critical || cerr.fallback == nil || len(repaired) > maxConfigRepairs {
+			return repaired, err
+		}
+		cerr.fallback()
+		repaired = append(repaired, err)
+	}
+}
+
+// maxConfigRepairs bounds the number of fallbacks applied by SanitizeAppWrapperConfig
+const maxConfigRepairs = 32
+
 // NewCertManagermentConfig constructs a CertManagementConfig and fills in default values
 func NewCertManagementConfig(namespace string) *CertManagementConfig {
 	return &CertManagementConfig{
@@ -192,5 +591,61 @@ func NewControllerManagerConfig() *ControllerManagerConfig {
 		},
 		LeaderElection: false,
 		EnableHTTP2:    false,
+		SyncPeriod:     10 * time.Hour,
+	}
+}
+
+func labelPrefix(config *AppWrapperConfig) string {
+	if config.LabelPrefix == "" {
+		return DefaultLabelPrefix
+	}
+	return config.LabelPrefix
+}
+
+// AppWrapperLabel returns the key of the label that associates wrapped resources and their Pods with their AppWrapper
+func AppWrapperLabel(config *AppWrapperConfig) string {
+	return labelPrefix(config) + "/appwrapper"
+}
+
+// AppWrapperFinalizer returns the name of the finalizer that the controller adds to the AppWrappers it deploys
+func AppWrapperFinalizer(config *AppWrapperConfig) string {
+	return labelPrefix(config) + "/finalizer"
+}
+
+// ValidateControllerManagerConfig checks that the controller manager configuration is well formed
+func ValidateControllerManagerConfig(config *ControllerManagerConfig) error {
+	if config.SyncPeriod <= 0 {
+		return fmt.Errorf("SyncPeriod %v is not positive", config.SyncPeriod)
+	}
+	return nil
+}
+
+// NewCacheOptions returns the manager cache options derived from the controller manager configuration
+func NewCacheOptions(config *ControllerManagerConfig) cache.Options {
+	return cache.Options{
+		SyncPeriod: ptr.To(config.SyncPeriod),
 	}
 }
+
+// NewOperatorConfig constructs an OperatorConfig and fills in default values
+func NewOperatorConfig(namespace string) *OperatorConfig {
+	return &OperatorConfig{
+		AppWrapper:        NewAppWrapperConfig(),
+		CertManagement:    NewCertManagementConfig(namespace),
+		ControllerManager: NewControllerManagerConfig(),
+		WebhooksEnabled:   ptr.To(true),
+	}
+}
+
+// UnmarshalConfigMap overlays the configuration contained in configMap onto cfg
+func UnmarshalConfigMap(configMap *v1.ConfigMap, cfg *OperatorConfig) error {
+	if len(configMap.Data) != 1 {
+		return fmt.Errorf("cannot resolve config from ConfigMap %s/%s", configMap.Namespace, configMap.Name)
+	}
+
+	for _, data := range configMap.Data {
+		return yaml.Unmarshal([]byte(data), cfg)
+	}
+
+	return nil
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package config
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestConfig(t *testing.T) {
@@ -61,5 +64,262 @@ var _ = Describe("AppWrapper Config", func() {
 
 		bad = &FaultToleranceConfig{SuccessTTL: -1 * time.Second}
 		Expect(ValidateAppWrapperConfig(&AppWrapperConfig{FaultTolerance: bad})).ShouldNot(Succeed())
+
+		bad = &FaultToleranceConfig{SuccessTTL: 1 * time.Second, FailedTTL: -1 * time.Second}
+		Expect(ValidateAppWrapperConfig(&AppWrapperConfig{FaultTolerance: bad})).ShouldNot(Succeed())
+
+		bad = &FaultToleranceConfig{SuccessTTL: 1 * time.Second, InfrastructureRetryLimit: -1}
+		Expect(ValidateAppWrapperConfig(&AppWrapperConfig{FaultTolerance: bad})).ShouldNot(Succeed())
+
+		bad = &FaultToleranceConfig{SuccessTTL: 1 * time.Second, FailedPodDeletionLimit: -1}
+		Expect(ValidateAppWrapperConfig(&AppWrapperConfig{FaultTolerance: bad})).ShouldNot(Succeed())
+
+		bad = &FaultToleranceConfig{SuccessTTL: 1 * time.Second, RetryPauseBackoffFactor: 0.5}
+		Expect(ValidateAppWrapperConfig(&AppWrapperConfig{FaultTolerance: bad})).ShouldNot(Succeed())
+
+		bad = &FaultToleranceConfig{SuccessTTL: 1 * time.Second, RetryPauseBackoffFactor: 1.0, SuccessDeletionGracePeriod: 10 * time.Second, GracePeriodMaximum: 1 * time.Second}
+		Expect(ValidateAppWrapperConfig(&AppWrapperConfig{FaultTolerance: bad})).ShouldNot(Succeed())
+
+		bad = &FaultToleranceConfig{SuccessTTL: 1 * time.Second, RetryPauseBackoffFactor: 1.0, MigrationDrainPeriod: 10 * time.Second, GracePeriodMaximum: 1 * time.Second}
+		Expect(ValidateAppWrapperConfig(&AppWrapperConfig{FaultTolerance: bad})).ShouldNot(Succeed())
+
+		bad = &FaultToleranceConfig{SuccessTTL: 1 * time.Second, RetryPauseBackoffFactor: 1.0, MinimumRunningDuration: 10 * time.Second, GracePeriodMaximum: 1 * time.Second}
+		Expect(ValidateAppWrapperConfig(&AppWrapperConfig{FaultTolerance: bad})).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.FaultTolerance.DeletionPropagationPolicy = metav1.DeletePropagationForeground
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.FaultTolerance.DeletionPropagationPolicy = "Eventually"
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.Autopilot.ResyncPeriod = -1 * time.Minute
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.ComponentFailureRules = []ComponentFailureRule{{APIVersion: "sparkoperator.k8s.io/v1beta2", Kind: "SparkApplication", FailedJSONPath: "status.applicationState.state", FailedValue: "FAILED"}}
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.ComponentFailureRules = []ComponentFailureRule{{APIVersion: "sparkoperator.k8s.io/v1beta2", FailedJSONPath: "status.applicationState.state", FailedValue: "FAILED"}}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc.ComponentFailureRules = []ComponentFailureRule{{APIVersion: "a/b/c", Kind: "SparkApplication", FailedJSONPath: "status.applicationState.state", FailedValue: "FAILED"}}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc.ComponentFailureRules = []ComponentFailureRule{{APIVersion: "sparkoperator.k8s.io/v1beta2", Kind: "SparkApplication", FailedJSONPath: ".status", FailedValue: "FAILED"}}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.UserRBACExemptKinds = []RBACExemptKind{{APIVersion: "v1", Kind: "Pod"}, {APIVersion: "batch/v1", Kind: "Job"}}
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.UserRBACExemptKinds = []RBACExemptKind{{APIVersion: "batch/v1"}}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.ParentRefKinds = []ParentRefKind{{APIVersion: "tekton.dev/v1", Kind: "PipelineRun"}}
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.ParentRefKinds = []ParentRefKind{{APIVersion: "tekton.dev/v1"}}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.PodSetTemplates = []PodSetTemplate{{APIVersion: "example.com/v1", Kind: "Trainer", TemplatePath: "template.spec.workers[0].template", ReplicasPath: "template.spec.workers[0].replicas"}}
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.PodSetTemplates = []PodSetTemplate{{APIVersion: "example.com/v1", Kind: "Trainer", TemplatePath: "spec.template"}}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc.PodSetTemplates = []PodSetTemplate{{APIVersion: "example.com/v1", Kind: "Trainer", TemplatePath: "template.spec.workers[x].template"}}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc.PodSetTemplates = []PodSetTemplate{{APIVersion: "example.com/v1", Kind: "Trainer", TemplatePath: "template.spec.template", ReplicasPath: "template.spec."}}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc.PodSetTemplates = []PodSetTemplate{{APIVersion: "example.com/v1", TemplatePath: "template.spec.template"}}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.DeletionRequeueInterval = 0
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.RunningRequeueInterval = -1 * time.Minute
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.WarmupRequeueInterval = 0
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.DeploymentRetryInterval = 0
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.UserRBACCheckConcurrency = 0
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.UserRBACCheckTimeout = 0
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.MaxReconcileDuration = -1 * time.Second
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.ComponentCreationConcurrency = 0
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.ResyncPeriod = -1 * time.Minute
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.ObservabilityPodAnnotations = map[string]string{"profiler.example.com/enabled": "true"}
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.ObservabilityPodAnnotations = map[string]string{"not a key": "true"}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.DefaultServiceAccountName = "workload-identity"
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.DefaultServiceAccountName = "Not_A_Name"
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.MissingQueuePolicy = MissingQueueSuspend
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.MissingQueuePolicy = "Ignore"
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.AdmissionGateName = "example.com/admission"
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.AdmissionGateName = "not a gate"
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc = NewAppWrapperConfig()
+		awc.TopologySpread = &TopologySpreadConfig{InjectConstraints: true, Constraints: []TopologySpreadConstraint{{TopologyKey: "example.com/rack", MaxSkew: 1, WhenUnsatisfiable: v1.DoNotSchedule}}}
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+
+		awc.TopologySpread.Constraints[0].MaxSkew = 0
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc.TopologySpread.Constraints[0] = TopologySpreadConstraint{TopologyKey: "not a key", MaxSkew: 1, WhenUnsatisfiable: v1.DoNotSchedule}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+
+		awc.TopologySpread.Constraints[0] = TopologySpreadConstraint{TopologyKey: "example.com/rack", MaxSkew: 1, WhenUnsatisfiable: "Sometimes"}
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+	})
+
+	It("Validation problems are classified by severity", func() {
+		awc := NewAppWrapperConfig()
+		awc.RunningRequeueInterval = 0
+		var cerr *ConfigError
+		Expect(errors.As(ValidateAppWrapperConfig(awc), &cerr)).Should(BeTrue())
+		Expect(cerr.Severity).Should(Equal(NonCritical))
+		Expect(cerr.Field).Should(Equal("RunningRequeueInterval"))
+
+		awc = NewAppWrapperConfig()
+		awc.LabelPrefix = "not a prefix"
+		Expect(errors.As(ValidateAppWrapperConfig(awc), &cerr)).Should(BeTrue())
+		Expect(cerr.Severity).Should(Equal(Critical))
+		Expect(cerr.Field).Should(Equal("LabelPrefix"))
+	})
+
+	It("Sanitizing falls back to defaults for non-critical problems", func() {
+		defaults := NewAppWrapperConfig()
+		awc := NewAppWrapperConfig()
+		awc.FaultTolerance.GracePeriodMaximum = 2 * time.Minute
+		awc.FaultTolerance.ForcefulDeletionGracePeriod = 1 * time.Hour
+		awc.FaultTolerance.RetryPauseBackoffFactor = 0.5
+		awc.FaultTolerance.SuccessTTL = 0
+		awc.FaultTolerance.InfrastructureRetryLimit = -1
+		awc.FaultTolerance.FailedPodDeletionLimit = -1
+		awc.DeletionRequeueInterval = -1 * time.Second
+		awc.MaxReconcileDuration = -1 * time.Second
+		awc.ComponentCreationConcurrency = 0
+		awc.Autopilot.ResyncPeriod = -1 * time.Minute
+
+		repaired, err := SanitizeAppWrapperConfig(awc)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(repaired).Should(HaveLen(11))
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+		Expect(awc.FaultTolerance.GracePeriodMaximum).Should(Equal(2 * time.Minute))
+		Expect(awc.FaultTolerance.ForcefulDeletionGracePeriod).Should(Equal(2 * time.Minute))
+		Expect(awc.FaultTolerance.SuccessDeletionGracePeriod).Should(Equal(2 * time.Minute))
+		Expect(awc.FaultTolerance.WarmupGracePeriod).Should(Equal(2 * time.Minute))
+		Expect(awc.FaultTolerance.RetryPauseBackoffFactor).Should(Equal(defaults.FaultTolerance.RetryPauseBackoffFactor))
+		Expect(awc.FaultTolerance.SuccessTTL).Should(Equal(defaults.FaultTolerance.SuccessTTL))
+		Expect(awc.FaultTolerance.InfrastructureRetryLimit).Should(Equal(defaults.FaultTolerance.InfrastructureRetryLimit))
+		Expect(awc.FaultTolerance.FailedPodDeletionLimit).Should(Equal(defaults.FaultTolerance.FailedPodDeletionLimit))
+		Expect(awc.DeletionRequeueInterval).Should(Equal(defaults.DeletionRequeueInterval))
+		Expect(awc.MaxReconcileDuration).Should(Equal(defaults.MaxReconcileDuration))
+		Expect(awc.ComponentCreationConcurrency).Should(Equal(defaults.ComponentCreationConcurrency))
+		Expect(awc.Autopilot.ResyncPeriod).Should(Equal(defaults.Autopilot.ResyncPeriod))
+
+		By("A valid config needs no repairs")
+		repaired, err = SanitizeAppWrapperConfig(NewAppWrapperConfig())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(repaired).Should(BeEmpty())
+	})
+
+	It("Sanitizing still rejects critical problems", func() {
+		awc := NewAppWrapperConfig()
+		awc.RunningRequeueInterval = 0
+		awc.ComponentFailureRules = []ComponentFailureRule{{APIVersion: "sparkoperator.k8s.io/v1beta2", FailedJSONPath: "status.applicationState.state", FailedValue: "FAILED"}}
+		repaired, err := SanitizeAppWrapperConfig(awc)
+		Expect(err).Should(HaveOccurred())
+		Expect(repaired).Should(HaveLen(1))
+
+		awc = NewAppWrapperConfig()
+		awc.TopologySpread = &TopologySpreadConfig{Constraints: []TopologySpreadConstraint{{TopologyKey: "example.com/rack", MaxSkew: 0, WhenUnsatisfiable: v1.DoNotSchedule}}}
+		_, err = SanitizeAppWrapperConfig(awc)
+		Expect(err).Should(HaveOccurred())
+
+		awc = NewAppWrapperConfig()
+		awc.LabelPrefix = "not a prefix"
+		_, err = SanitizeAppWrapperConfig(awc)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Label Prefix", func() {
+		awc := NewAppWrapperConfig()
+		Expect(AppWrapperLabel(awc)).Should(Equal("workload.codeflare.dev/appwrapper"))
+		Expect(AppWrapperFinalizer(awc)).Should(Equal("workload.codeflare.dev/finalizer"))
+		Expect(AppWrapperLabel(&AppWrapperConfig{})).Should(Equal(AppWrapperLabel(awc)))
+
+		awc.LabelPrefix = "tenant.example.com"
+		Expect(ValidateAppWrapperConfig(awc)).Should(Succeed())
+		Expect(AppWrapperLabel(awc)).Should(Equal("tenant.example.com/appwrapper"))
+		Expect(AppWrapperFinalizer(awc)).Should(Equal("tenant.example.com/finalizer"))
+
+		awc.LabelPrefix = "Not A Prefix!"
+		Expect(ValidateAppWrapperConfig(awc)).ShouldNot(Succeed())
+	})
+
+	It("Controller Manager Config Validation", func() {
+		cmc := NewControllerManagerConfig()
+		Expect(ValidateControllerManagerConfig(cmc)).Should(Succeed())
+
+		cmc.SyncPeriod = 0
+		Expect(ValidateControllerManagerConfig(cmc)).ShouldNot(Succeed())
+
+		cmc.SyncPeriod = -1 * time.Minute
+		Expect(ValidateControllerManagerConfig(cmc)).ShouldNot(Succeed())
+	})
+
+	It("Cache options reflect the configured SyncPeriod", func() {
+		cmc := NewControllerManagerConfig()
+		Expect(*NewCacheOptions(cmc).SyncPeriod).Should(Equal(10 * time.Hour))
+
+		cmc.SyncPeriod = 5 * time.Minute
+		Expect(*NewCacheOptions(cmc).SyncPeriod).Should(Equal(5 * time.Minute))
 	})
 })
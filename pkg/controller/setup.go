@@ -22,8 +22,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
@@ -33,12 +35,33 @@ import (
 	"github.com/project-codeflare/appwrapper/internal/controller/workload"
 	"github.com/project-codeflare/appwrapper/internal/webhook"
 	"github.com/project-codeflare/appwrapper/pkg/config"
+	"github.com/project-codeflare/appwrapper/pkg/utils"
 
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 )
 
-// SetupControllers creates and configures all components of the AppWrapper controller
-func SetupControllers(mgr ctrl.Manager, awConfig *config.AppWrapperConfig) error {
+// ValidateLabelPrefix reports existing AppWrappers whose finalizers may be stranded by the configured LabelPrefix
+func ValidateLabelPrefix(ctx context.Context, c client.Reader, awConfig *config.AppWrapperConfig) error {
+	return appwrapper.ValidateFinalizerPrefix(ctx, c, awConfig)
+}
+
+// RegisterPodSetTemplates extends PodSet inference with the PodSetTemplates of awConfig
+func RegisterPodSetTemplates(awConfig *config.AppWrapperConfig) error {
+	for _, t := range awConfig.PodSetTemplates {
+		gv, err := schema.ParseGroupVersion(t.APIVersion)
+		if err != nil {
+			return fmt.Errorf("podSetTemplate: %w", err)
+		}
+		if err := utils.RegisterPodSetTemplate(gv.WithKind(t.Kind), t.TemplatePath, t.ReplicasPath); err != nil {
+			return fmt.Errorf("podSetTemplate: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetupControllers creates and configures all components of the AppWrapper controller.
+// awConfig is the configuration the operator was started with; live publishes the changes applied at runtime.
+func SetupControllers(mgr ctrl.Manager, awConfig *config.AppWrapperConfig, live *config.LiveAppWrapperConfig) error {
 	if awConfig.EnableKueueIntegrations {
 		if err := workload.WorkloadReconciler(
 			mgr.GetClient(),
@@ -56,6 +79,7 @@ func SetupControllers(mgr ctrl.Manager, awConfig *config.AppWrapperConfig) error
 		if err := (&appwrapper.NodeHealthMonitor{
 			Client: mgr.GetClient(),
 			Config: awConfig,
+			Live:   live,
 			Events: conduit,
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("node health monitor: %w", err)
@@ -76,6 +100,7 @@ func SetupControllers(mgr ctrl.Manager, awConfig *config.AppWrapperConfig) error
 		Recorder: mgr.GetEventRecorderFor("appwrappers"),
 		Scheme:   mgr.GetScheme(),
 		Config:   awConfig,
+		Live:     live,
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("appwrapper controller: %w", err)
 	}
@@ -83,6 +108,18 @@ func SetupControllers(mgr ctrl.Manager, awConfig *config.AppWrapperConfig) error
 	return nil
 }
 
+// SetupConfigMonitor creates and configures the controller that publishes changes to the operator's ConfigMap to live
+func SetupConfigMonitor(mgr ctrl.Manager, cfg *config.OperatorConfig, live *config.LiveAppWrapperConfig, cmName types.NamespacedName) error {
+	if err := (&appwrapper.ConfigMapMonitor{
+		Config:        cfg,
+		Live:          live,
+		ConfigMapName: cmName,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("config monitor: %w", err)
+	}
+	return nil
+}
+
 // SetupWebhooks creates and configures the AppWrapper controller's Webhooks
 func SetupWebhooks(mgr ctrl.Manager, awConfig *config.AppWrapperConfig) error {
 	if err := webhook.SetupAppWrapperWebhook(mgr, awConfig); err != nil {
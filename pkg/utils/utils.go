@@ -19,8 +19,10 @@ package utils
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	//  Import the crypto sha256 algorithm for the docker image parser to work
 	_ "crypto/sha256"
@@ -30,9 +32,13 @@ import (
 	dockerref "github.com/distribution/reference"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -43,23 +49,65 @@ import (
 
 const templateString = "template"
 
+// GVKs whose pod templates inline the fields of a v1.PodSpec alongside top-level labels and annotations
+// instead of embedding a v1.PodTemplateSpec
+var inlinedPodSpecGVKs = []schema.GroupVersionKind{
+	{Group: "serving.kserve.io", Version: "v1beta1", Kind: "InferenceService"},
+}
+
+// Fields of the components of an InferenceService that specify a model framework instead of containers.
+// KServe turns the v1.Container inlined in a framework field into the kserve-container of the component's Pods.
+var kserveFrameworkFields = []string{
+	"model", "sklearn", "xgboost", "tensorflow", "pytorch", "triton", "onnx", "pmml", "lightgbm", "paddle", "huggingface", // predictor
+	"alibi", "art", // explainer
+}
+
+// PodTemplateParts returns the maps holding the metadata and the spec of the pod template p of a resource of the given GVK.
+// For most GVKs p is a v1.PodTemplateSpec (or a Pod) and the returned metadata is nil if p does not have any.
+// For GVKs that inline the fields of a v1.PodSpec, p is both its own metadata and its own spec.
+func PodTemplateParts(gvk schema.GroupVersionKind, p map[string]interface{}) (map[string]interface{}, map[string]interface{}, error) {
+	if slices.Contains(inlinedPodSpecGVKs, gvk) {
+		return p, p, nil
+	}
+	spec, ok := p["spec"].(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("does not contain a spec")
+	}
+	metadata, _ := p["metadata"].(map[string]interface{})
+	return metadata, spec, nil
+}
+
 // GetPodTemplateSpec extracts a Kueue-compatible PodTemplateSpec at the given path within obj
 func GetPodTemplateSpec(obj *unstructured.Unstructured, path string) (*v1.PodTemplateSpec, error) {
 	candidatePTS, err := GetRawTemplate(obj.UnstructuredContent(), path)
 	if err != nil {
 		return nil, err
 	}
+	metadata, spec, err := PodTemplateParts(obj.GroupVersionKind(), candidatePTS)
+	if err != nil {
+		return nil, fmt.Errorf("content at %v %w", path, err)
+	}
 
-	// Convert candidatePTS.spec to a natively-typed PodSpec
+	// Convert spec to a natively-typed PodSpec
 	// NOTE: candidatePTS _may_ be a Pod, not a PodSpecTemplate so only parse the Spec.
+	// An inlined PodSpec is accompanied by other fields of its resource, so unknown fields are tolerated.
 	src := &v1.PodSpec{}
-	spec, ok := candidatePTS["spec"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("content at %v does not contain a spec", path)
-	}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(spec, src, true); err != nil {
+	strict := !slices.Contains(inlinedPodSpecGVKs, obj.GroupVersionKind())
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(spec, src, strict); err != nil {
 		return nil, fmt.Errorf("content at %v.spec not parseable as a v1.PodSpec: %w", path, err)
 	}
+	if obj.GroupVersionKind() == (schema.GroupVersionKind{Group: "serving.kserve.io", Version: "v1beta1", Kind: "InferenceService"}) {
+		frameworkContainer, err := kserveFrameworkContainer(spec)
+		if err != nil {
+			return nil, fmt.Errorf("content at %v %w", path, err)
+		}
+		if frameworkContainer != nil {
+			src.Containers = append([]v1.Container{*frameworkContainer}, src.Containers...)
+		}
+		if len(src.Containers) == 0 {
+			return nil, fmt.Errorf("content at %v specifies neither containers nor a model framework", path)
+		}
+	}
 
 	// Now, copy just the subset of src that is relevant to Kueue.
 	// We must deeply ensure that any fields with non-zero default values
@@ -68,7 +116,7 @@ func GetPodTemplateSpec(obj *unstructured.Unstructured, path string) (*v1.PodTem
 
 	// Metadata
 	dst := &v1.PodTemplateSpec{}
-	if metadata, ok := candidatePTS["metadata"].(map[string]interface{}); ok {
+	if metadata != nil {
 		if labels, ok := metadata["labels"].(map[string]string); ok {
 			dst.Labels = labels
 		}
@@ -123,6 +171,27 @@ func GetPodTemplateSpec(obj *unstructured.Unstructured, path string) (*v1.PodTem
 	return dst, nil
 }
 
+// kserveFrameworkContainer returns the container KServe generates from the model framework field of spec,
+// the inlined PodSpec of a component of an InferenceService, or nil if spec does not have a framework field.
+// Only the resources the user specifies are known; defaults from the ServingRuntime are not accounted for.
+func kserveFrameworkContainer(spec map[string]interface{}) (*v1.Container, error) {
+	for _, field := range kserveFrameworkFields {
+		framework, ok := spec[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		container := &v1.Container{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(framework, container, false); err != nil {
+			return nil, fmt.Errorf("field %v not parseable as a v1.Container: %w", field, err)
+		}
+		if container.Name == "" {
+			container.Name = "kserve-container"
+		}
+		return container, nil
+	}
+	return nil, nil
+}
+
 func copyContainers(src []v1.Container) []v1.Container {
 	dst := make([]v1.Container, len(src))
 	for i := range src {
@@ -132,6 +201,7 @@ func copyContainers(src []v1.Container) []v1.Container {
 		dst[i].Args = src[i].Args
 		dst[i].Resources.Requests = defaultResourceList(src[i].Resources.Requests)
 		dst[i].Resources.Limits = defaultResourceList(src[i].Resources.Limits)
+		dst[i].RestartPolicy = src[i].RestartPolicy // distinguishes sidecars, whose requests Kueue adds to those of the containers
 		if src[i].TerminationMessagePath == "" {
 			dst[i].TerminationMessagePath = v1.TerminationMessagePathDefault
 		} else {
@@ -166,6 +236,31 @@ func defaultResourceList(rl v1.ResourceList) v1.ResourceList {
 	return rl
 }
 
+// ParseSharedScratch parses the value of a SharedScratchAnnotation of the form [sizeLimit:]mountPath.
+// The returned sizeLimit is nil if the value does not specify one.
+func ParseSharedScratch(value string) (*resource.Quantity, string, error) {
+	var sizeLimit *resource.Quantity
+	mountPath := value
+	if size, path, found := strings.Cut(value, ":"); found {
+		quantity, err := resource.ParseQuantity(size)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid sizeLimit %q: %w", size, err)
+		}
+		sizeLimit = &quantity
+		mountPath = path
+	}
+	if !strings.HasPrefix(mountPath, "/") {
+		return nil, "", fmt.Errorf("mountPath %q is not an absolute path", mountPath)
+	}
+	return sizeLimit, mountPath, nil
+}
+
+// ValidateImageReference returns an error if image cannot be parsed as a container image reference
+func ValidateImageReference(image string) error {
+	_, err := dockerref.ParseNormalizedNamed(image)
+	return err
+}
+
 // getImageTag parses a docker image string and returns the tag.
 // If both tag and digest are empty,"latest" will be returned.
 func getImageTag(image string) string {
@@ -227,8 +322,17 @@ func getValueAtPath(obj map[string]interface{}, path string) (interface{}, error
 		return nil, fmt.Errorf("first element of the path must be 'template'")
 	}
 	remaining := strings.TrimPrefix(path, processed)
-	var cursor interface{} = obj
+	return navigatePath(obj, processed, remaining)
+}
+
+// GetFieldValue returns the value found at the given path relative to the root of obj
+// (for example "status.applicationState.state") or an error if the path is invalid
+func GetFieldValue(obj map[string]interface{}, path string) (interface{}, error) {
+	return navigatePath(obj, "", "."+path)
+}
 
+// navigatePath follows the remaining path elements starting from cursor
+func navigatePath(cursor interface{}, processed string, remaining string) (interface{}, error) {
 	for remaining != "" {
 		if strings.HasPrefix(remaining, "[") {
 			// Array index expression
@@ -294,13 +398,120 @@ func ExpectedPodCount(aw *workloadv1beta2.AppWrapper) (int32, error) {
 	}
 	var expected int32
 	for _, c := range aw.Status.ComponentStatus {
-		for _, s := range c.PodSets {
-			expected += Replicas(s)
+		expected += componentPodCount(c)
+	}
+	return expected, nil
+}
+
+// ExpectedServicePodCount returns the number of expected Pods that belong to service components
+func ExpectedServicePodCount(aw *workloadv1beta2.AppWrapper) (int32, error) {
+	if err := EnsureComponentStatusInitialized(aw); err != nil {
+		return 0, err
+	}
+	var expected int32
+	for _, c := range aw.Status.ComponentStatus {
+		if IsServiceComponent(c.APIVersion, c.Kind) {
+			expected += componentPodCount(c)
+		}
+	}
+	return expected, nil
+}
+
+// ExpectedSucceededPodCount returns the number of Pods of non-service components that must succeed for the AppWrapper to complete.
+// It differs from ExpectedPodCount, which counts the concurrently running Pods that consume quota,
+// for Indexed Jobs whose completions exceed their parallelism.
+func ExpectedSucceededPodCount(aw *workloadv1beta2.AppWrapper) (int32, error) {
+	if err := EnsureComponentStatusInitialized(aw); err != nil {
+		return 0, err
+	}
+	var expected int32
+	for idx, c := range aw.Status.ComponentStatus {
+		if IsServiceComponent(c.APIVersion, c.Kind) {
+			continue
+		}
+		completions, err := indexedJobCompletions(aw.Spec.Components[idx])
+		if err != nil {
+			return 0, err
+		}
+		if completions > 0 {
+			expected += completions
+		} else {
+			expected += componentPodCount(c)
 		}
 	}
 	return expected, nil
 }
 
+// indexedJobCompletions returns the completions of a component that is a batch/v1 Job with completionMode Indexed
+// and zero for all other components
+func indexedJobCompletions(component workloadv1beta2.AppWrapperComponent) (int32, error) {
+	obj := &unstructured.Unstructured{}
+	if _, _, err := unstructured.UnstructuredJSONScheme.Decode(component.Template.Raw, nil, obj); err != nil {
+		return 0, err
+	}
+	if obj.GroupVersionKind() != (schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}) {
+		return 0, nil
+	}
+	if mode, err := getValueAtPath(obj.UnstructuredContent(), "template.spec.completionMode"); err != nil || mode != "Indexed" {
+		return 0, nil
+	}
+	if completions, err := GetReplicas(obj, "template.spec.completions"); err == nil {
+		return completions, nil
+	}
+	return 0, nil
+}
+
+func componentPodCount(c workloadv1beta2.AppWrapperComponentStatus) int32 {
+	if c.DesiredPods != nil {
+		// Pod count determined at runtime by the Component's controller (eg the number of Nodes for a DaemonSet, which may be zero)
+		return *c.DesiredPods
+	}
+	var expected int32
+	for _, s := range c.PodSets {
+		expected += Replicas(s)
+	}
+	return expected
+}
+
+// PodSetLabelValue returns the value of the PodSetLabel for the given PodSet of the given component
+func PodSetLabelValue(componentIdx int, podSetIdx int) string {
+	return fmt.Sprintf("%v-%v", componentIdx, podSetIdx)
+}
+
+// ParsePodSetLabelValue returns the indices of the component and PodSet identified by the value of a PodSetLabel
+func ParsePodSetLabelValue(labelValue string) (int, int, error) {
+	var componentIdx, podSetIdx int
+	if _, err := fmt.Sscanf(labelValue, "%d-%d", &componentIdx, &podSetIdx); err != nil {
+		return 0, 0, fmt.Errorf("malformed podSet label value '%v'", labelValue)
+	}
+	return componentIdx, podSetIdx, nil
+}
+
+// PodSetPath returns a description of the PodSet identified by the value of a PodSetLabel
+func PodSetPath(aw *workloadv1beta2.AppWrapper, labelValue string) (string, error) {
+	componentIdx, podSetIdx, err := ParsePodSetLabelValue(labelValue)
+	if err != nil {
+		return "", err
+	}
+	if componentIdx < 0 || componentIdx >= len(aw.Status.ComponentStatus) ||
+		podSetIdx < 0 || podSetIdx >= len(aw.Status.ComponentStatus[componentIdx].PodSets) {
+		return "", fmt.Errorf("podSet label value '%v' out of range", labelValue)
+	}
+	return fmt.Sprintf("components[%v].%v", componentIdx, aw.Status.ComponentStatus[componentIdx].PodSets[podSetIdx].Path), nil
+}
+
+// IsServiceComponent returns true for resource types whose Pods are long-running services that never complete.
+// Service components do not contribute to the completion of an AppWrapper; they only need to be ready.
+func IsServiceComponent(apiVersion string, kind string) bool {
+	switch apiVersion + ":" + kind {
+	case "apps/v1:Deployment", "apps/v1:StatefulSet", "apps/v1:DaemonSet", "serving.kserve.io/v1beta1:InferenceService",
+		"leaderworkerset.x-k8s.io/v1:LeaderWorkerSet":
+		return true
+	default:
+		return false
+	}
+}
+
 // EnsureComponentStatusInitialized initializes aw.Status.ComponenetStatus, including performing PodSet inference for known GVKs
 func EnsureComponentStatusInitialized(aw *workloadv1beta2.AppWrapper) error {
 	if len(aw.Status.ComponentStatus) == len(aw.Spec.Components) {
@@ -440,9 +651,122 @@ var templatesForGVK = map[schema.GroupVersionKind][]resourceTemplate{
 	{Group: "", Version: "v1", Kind: "Pod"}:             {{path: "template"}},
 	{Group: "apps", Version: "v1", Kind: "Deployment"}:  {{path: "template.spec.template", replicas: "template.spec.replicas"}},
 	{Group: "apps", Version: "v1", Kind: "StatefulSet"}: {{path: "template.spec.template", replicas: "template.spec.replicas"}},
+	{Group: "serving.kserve.io", Version: "v1beta1", Kind: "InferenceService"}: {
+		{path: "template.spec.predictor", replicas: "template.spec.predictor.minReplicas"},
+		{path: "template.spec.transformer", replicas: "template.spec.transformer.minReplicas"},
+		{path: "template.spec.explainer", replicas: "template.spec.explainer.minReplicas"},
+	},
 }
 
-// inferPodSets infers PodSets for RayJobs and RayClusters
+// resource templates registered for GVKs without built-in inference
+var (
+	customTemplatesForGVK      = map[schema.GroupVersionKind][]resourceTemplate{}
+	customTemplatesForGVKMutex sync.RWMutex
+)
+
+// RegisterPodSetTemplate adds a PodSet to the PodSets inferred for resources of the given GVK. Its PodTemplateSpec
+// is found at templatePath and its replica count at replicasPath (one replica if empty). It returns an error for
+// GVKs that have built-in inference.
+func RegisterPodSetTemplate(gvk schema.GroupVersionKind, templatePath string, replicasPath string) error {
+	if _, ok := templatesForGVK[gvk]; ok || slices.Contains(specialInferenceGVKs, gvk) {
+		return fmt.Errorf("PodSets of %v are inferred by built-in rules", gvk)
+	}
+	customTemplatesForGVKMutex.Lock() // BEGIN CRITICAL SECTION
+	defer customTemplatesForGVKMutex.Unlock()
+	customTemplatesForGVK[gvk] = append(customTemplatesForGVK[gvk], resourceTemplate{path: templatePath, replicas: replicasPath})
+	return nil
+}
+
+// UnregisterPodSetTemplates removes all PodSets registered by RegisterPodSetTemplate for the given GVK
+func UnregisterPodSetTemplates(gvk schema.GroupVersionKind) {
+	customTemplatesForGVKMutex.Lock() // BEGIN CRITICAL SECTION
+	defer customTemplatesForGVKMutex.Unlock()
+	delete(customTemplatesForGVK, gvk)
+}
+
+// resourceTemplatesFor returns the built-in or registered resource templates of gvk
+func resourceTemplatesFor(gvk schema.GroupVersionKind) []resourceTemplate {
+	if templates, ok := templatesForGVK[gvk]; ok {
+		return templates
+	}
+	customTemplatesForGVKMutex.RLock() // BEGIN CRITICAL SECTION
+	defer customTemplatesForGVKMutex.RUnlock()
+	return customTemplatesForGVK[gvk]
+}
+
+// GVKs other than those in templatesForGVK for which InferPodSets has special handling
+var specialInferenceGVKs = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	{Group: "batch", Version: "v1", Kind: "Job"},
+	{Group: "batch", Version: "v1", Kind: "CronJob"},
+	{Group: "jobset.x-k8s.io", Version: "v1alpha2", Kind: "JobSet"},
+	{Group: "kubeflow.org", Version: "v1", Kind: "PyTorchJob"},
+	{Group: "kubeflow.org", Version: "v1", Kind: "TFJob"},
+	{Group: "kubeflow.org", Version: "v1", Kind: "XGBoostJob"},
+	{Group: "ray.io", Version: "v1", Kind: "RayCluster"},
+	{Group: "ray.io", Version: "v1", Kind: "RayJob"},
+	{Group: "ray.io", Version: "v1", Kind: "RayService"},
+	{Group: "leaderworkerset.x-k8s.io", Version: "v1", Kind: "LeaderWorkerSet"},
+}
+
+// ValidateAPIVersion checks that apiVersion is well-formed and rejects near misses of the GVKs known to InferPodSets
+// (differences in case or an omitted group), which would otherwise silently bypass PodSet inference.
+func ValidateAPIVersion(apiVersion string, kind string) error {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil || gv.Version == "" {
+		return fmt.Errorf("apiVersion %q is not of the form 'group/version' or 'version'", apiVersion)
+	}
+	gvk := gv.WithKind(kind)
+	known := append([]schema.GroupVersionKind{}, specialInferenceGVKs...)
+	for k := range templatesForGVK {
+		known = append(known, k)
+	}
+	customTemplatesForGVKMutex.RLock() // BEGIN CRITICAL SECTION
+	for k := range customTemplatesForGVK {
+		known = append(known, k)
+	}
+	customTemplatesForGVKMutex.RUnlock() // END CRITICAL SECTION
+	for _, k := range known {
+		if k == gvk || !strings.EqualFold(k.Kind, gvk.Kind) || !strings.EqualFold(k.Version, gvk.Version) {
+			continue
+		}
+		if strings.EqualFold(k.Group, gvk.Group) {
+			return fmt.Errorf("apiVersion %q and kind %q differ only in case from %q and %q", apiVersion, kind, k.GroupVersion().String(), k.Kind)
+		}
+		if gvk.Group == "" {
+			return fmt.Errorf("apiVersion %q omits the group; did you mean %q?", apiVersion, k.GroupVersion().String())
+		}
+	}
+	if errs := validation.IsDNS1123Label(gv.Version); len(errs) > 0 {
+		return fmt.Errorf("version %q of apiVersion %q is invalid: %v", gv.Version, apiVersion, strings.Join(errs, "; "))
+	}
+	if gv.Group != "" {
+		if errs := validation.IsDNS1123Subdomain(gv.Group); len(errs) > 0 {
+			return fmt.Errorf("group %q of apiVersion %q is invalid: %v", gv.Group, apiVersion, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// inferKubeflowPodSets infers PodSets for Kubeflow Training Operator jobs whose replicaSpecsField maps roles to ReplicaSpecs
+func inferKubeflowPodSets(obj *unstructured.Unstructured, replicaSpecsField string, roles []string) ([]workloadv1beta2.AppWrapperPodSet, error) {
+	podSets := []workloadv1beta2.AppWrapperPodSet{}
+	for _, replicaType := range roles {
+		prefix := "template.spec." + replicaSpecsField + "." + replicaType + "."
+		// validate path to replica template
+		if _, err := getValueAtPath(obj.UnstructuredContent(), prefix+templateString); err == nil {
+			// infer replica count
+			replicas, err := inferReplicas(obj.UnstructuredContent(), prefix+"replicas")
+			if err != nil {
+				return nil, err
+			}
+			podSets = append(podSets, workloadv1beta2.AppWrapperPodSet{Replicas: ptr.To(replicas), Path: prefix + templateString})
+		}
+	}
+	return podSets, nil
+}
+
+// inferPodSets infers PodSets for RayJobs, RayClusters, and RayServices
 func inferRayPodSets(obj *unstructured.Unstructured, clusterSpecPrefix string) ([]workloadv1beta2.AppWrapperPodSet, error) {
 	podSets := []workloadv1beta2.AppWrapperPodSet{}
 
@@ -466,35 +790,119 @@ func inferRayPodSets(obj *unstructured.Unstructured, clusterSpecPrefix string) (
 	return podSets, nil
 }
 
+// jobPodCount returns the number of concurrently running Pods of the batch/v1 JobSpec at jobSpecPrefix,
+// which is its parallelism (default 1) bounded by its completions
+func jobPodCount(obj *unstructured.Unstructured, jobSpecPrefix string) int32 {
+	var replicas int32 = 1
+	if parallelism, err := GetReplicas(obj, jobSpecPrefix+"parallelism"); err == nil {
+		replicas = parallelism
+	}
+	if completions, err := GetReplicas(obj, jobSpecPrefix+"completions"); err == nil && completions < replicas {
+		replicas = completions
+	}
+	return replicas
+}
+
+// inferJobSetPodSets infers PodSets for JobSets; each replicatedJob runs replicas (default 1) copies of its Job
+func inferJobSetPodSets(obj *unstructured.Unstructured) ([]workloadv1beta2.AppWrapperPodSet, error) {
+	podSets := []workloadv1beta2.AppWrapperPodSet{}
+	if replicatedJobs, err := getValueAtPath(obj.UnstructuredContent(), "template.spec.replicatedJobs"); err == nil {
+		if replicatedJobs, ok := replicatedJobs.([]interface{}); ok {
+			for i := range replicatedJobs {
+				replicatedJobPrefix := fmt.Sprintf("template.spec.replicatedJobs[%v].", i)
+				// validate path to replica template
+				if _, err := getValueAtPath(obj.UnstructuredContent(), replicatedJobPrefix+"template.spec."+templateString); err == nil {
+					// infer replica count
+					replicas, err := inferReplicas(obj.UnstructuredContent(), replicatedJobPrefix+"replicas")
+					if err != nil {
+						return nil, err
+					}
+					replicas *= jobPodCount(obj, replicatedJobPrefix+"template.spec.")
+					podSets = append(podSets, workloadv1beta2.AppWrapperPodSet{Replicas: ptr.To(replicas), Path: replicatedJobPrefix + "template.spec." + templateString})
+				}
+			}
+		}
+	}
+	return podSets, nil
+}
+
+// leaderWorkerSetPodCounts returns the number of leader and worker Pods of a LeaderWorkerSet
+// with the given number of replicas (groups) that each consist of size Pods (one leader and size-1 workers)
+func leaderWorkerSetPodCounts(replicas int32, size int32) (leaders int32, workers int32) {
+	return replicas, replicas * (size - 1)
+}
+
+// inferLeaderWorkerSetPodSets infers PodSets for LeaderWorkerSets.
+// The leaderTemplate is optional; if it is omitted the leaders are created from the workerTemplate.
+func inferLeaderWorkerSetPodSets(obj *unstructured.Unstructured) ([]workloadv1beta2.AppWrapperPodSet, error) {
+	const lwtPrefix = "template.spec.leaderWorkerTemplate."
+	podSets := []workloadv1beta2.AppWrapperPodSet{}
+
+	replicas, err := inferReplicas(obj.UnstructuredContent(), "template.spec.replicas")
+	if err != nil {
+		return nil, err
+	}
+	size, err := inferReplicas(obj.UnstructuredContent(), lwtPrefix+"size")
+	if err != nil {
+		return nil, err
+	}
+	if size < 1 {
+		return nil, fmt.Errorf("at path position '%vsize' value %v is less than 1", lwtPrefix, size)
+	}
+	leaders, workers := leaderWorkerSetPodCounts(replicas, size)
+
+	if _, err := getValueAtPath(obj.UnstructuredContent(), lwtPrefix+"leaderTemplate"); err == nil {
+		podSets = append(podSets, workloadv1beta2.AppWrapperPodSet{Replicas: ptr.To(leaders), Path: lwtPrefix + "leaderTemplate"})
+	} else {
+		workers += leaders
+	}
+	podSets = append(podSets, workloadv1beta2.AppWrapperPodSet{Replicas: ptr.To(workers), Path: lwtPrefix + "workerTemplate"})
+	return podSets, nil
+}
+
 // InferPodSets infers PodSets for known GVKs
 func InferPodSets(obj *unstructured.Unstructured) ([]workloadv1beta2.AppWrapperPodSet, error) {
 	gvk := obj.GroupVersionKind()
 	podSets := []workloadv1beta2.AppWrapperPodSet{}
 
 	switch gvk {
+	case schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"}:
+		// The Pod count is determined at runtime by the number of Nodes; the replicas that Kueue reserves quota for must be declared
+		podSets = append(podSets, workloadv1beta2.AppWrapperPodSet{Path: "template.spec.template"})
+
 	case schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}:
-		var replicas int32 = 1
-		if parallelism, err := GetReplicas(obj, "template.spec.parallelism"); err == nil {
-			replicas = parallelism
-		}
-		if completions, err := GetReplicas(obj, "template.spec.completions"); err == nil && completions < replicas {
-			replicas = completions
+		podSets = append(podSets, workloadv1beta2.AppWrapperPodSet{Replicas: ptr.To(jobPodCount(obj, "template.spec.")), Path: "template.spec.template"})
+
+	case schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"}:
+		podSets = append(podSets, workloadv1beta2.AppWrapperPodSet{Replicas: ptr.To(jobPodCount(obj, "template.spec.jobTemplate.spec.")), Path: "template.spec.jobTemplate.spec.template"})
+
+	case schema.GroupVersionKind{Group: "jobset.x-k8s.io", Version: "v1alpha2", Kind: "JobSet"}:
+		jobSetPodSets, err := inferJobSetPodSets(obj)
+		if err != nil {
+			return nil, err
 		}
-		podSets = append(podSets, workloadv1beta2.AppWrapperPodSet{Replicas: ptr.To(replicas), Path: "template.spec.template"})
+		podSets = append(podSets, jobSetPodSets...)
 
 	case schema.GroupVersionKind{Group: "kubeflow.org", Version: "v1", Kind: "PyTorchJob"}:
-		for _, replicaType := range []string{"Master", "Worker"} {
-			prefix := "template.spec.pytorchReplicaSpecs." + replicaType + "."
-			// validate path to replica template
-			if _, err := getValueAtPath(obj.UnstructuredContent(), prefix+templateString); err == nil {
-				// infer replica count
-				replicas, err := inferReplicas(obj.UnstructuredContent(), prefix+"replicas")
-				if err != nil {
-					return nil, err
-				}
-				podSets = append(podSets, workloadv1beta2.AppWrapperPodSet{Replicas: ptr.To(replicas), Path: prefix + templateString})
-			}
+		kubeflowPodSets, err := inferKubeflowPodSets(obj, "pytorchReplicaSpecs", []string{"Master", "Worker"})
+		if err != nil {
+			return nil, err
 		}
+		podSets = append(podSets, kubeflowPodSets...)
+
+	case schema.GroupVersionKind{Group: "kubeflow.org", Version: "v1", Kind: "TFJob"}:
+		kubeflowPodSets, err := inferKubeflowPodSets(obj, "tfReplicaSpecs", []string{"Chief", "PS", "Worker", "Evaluator"})
+		if err != nil {
+			return nil, err
+		}
+		podSets = append(podSets, kubeflowPodSets...)
+
+	case schema.GroupVersionKind{Group: "kubeflow.org", Version: "v1", Kind: "XGBoostJob"}:
+		kubeflowPodSets, err := inferKubeflowPodSets(obj, "xgbReplicaSpecs", []string{"Master", "Worker"})
+		if err != nil {
+			return nil, err
+		}
+		podSets = append(podSets, kubeflowPodSets...)
 
 	case schema.GroupVersionKind{Group: "ray.io", Version: "v1", Kind: "RayCluster"}:
 		rayPodSets, err := inferRayPodSets(obj, "template.spec.")
@@ -510,8 +918,22 @@ func InferPodSets(obj *unstructured.Unstructured) ([]workloadv1beta2.AppWrapperP
 		}
 		podSets = append(podSets, rayPodSets...)
 
+	case schema.GroupVersionKind{Group: "ray.io", Version: "v1", Kind: "RayService"}:
+		rayPodSets, err := inferRayPodSets(obj, "template.spec.rayClusterConfig.")
+		if err != nil {
+			return nil, err
+		}
+		podSets = append(podSets, rayPodSets...)
+
+	case schema.GroupVersionKind{Group: "leaderworkerset.x-k8s.io", Version: "v1", Kind: "LeaderWorkerSet"}:
+		lwsPodSets, err := inferLeaderWorkerSetPodSets(obj)
+		if err != nil {
+			return nil, err
+		}
+		podSets = append(podSets, lwsPodSets...)
+
 	default:
-		for _, template := range templatesForGVK[gvk] {
+		for _, template := range resourceTemplatesFor(gvk) {
 			// validate path to template
 			if _, err := getValueAtPath(obj.UnstructuredContent(), template.path); err == nil {
 				replicas, err := inferReplicas(obj.UnstructuredContent(), template.replicas)
@@ -533,9 +955,15 @@ func InferPodSets(obj *unstructured.Unstructured) ([]workloadv1beta2.AppWrapperP
 	return podSets, nil
 }
 
-// ValidatePodSets validates the declared and inferred PodSets
+// ValidatePodSets validates the declared and inferred PodSets.
+// An inferred PodSet without a replica count (eg the PodSet of a DaemonSet) must be declared with an explicit replica count.
 func ValidatePodSets(declared []workloadv1beta2.AppWrapperPodSet, inferred []workloadv1beta2.AppWrapperPodSet) error {
 	if len(declared) == 0 {
+		for _, ips := range inferred {
+			if ips.Replicas == nil {
+				return fmt.Errorf("the replica count of the PodSet at path position '%v' cannot be inferred and must be declared", ips.Path)
+			}
+		}
 		return nil
 	}
 
@@ -561,7 +989,13 @@ func ValidatePodSets(declared []workloadv1beta2.AppWrapperPodSet, inferred []wor
 				return fmt.Errorf("PodSet with path '%v' is missing", ips.Path)
 			}
 
-			ipr := ptr.Deref(ips.Replicas, 1)
+			if ips.Replicas == nil {
+				if dps.Replicas == nil {
+					return fmt.Errorf("the replica count of the PodSet at path position '%v' cannot be inferred and must be declared", ips.Path)
+				}
+				continue
+			}
+			ipr := *ips.Replicas
 			dpr := ptr.Deref(dps.Replicas, 1)
 			if ipr != dpr {
 				return fmt.Errorf("replica count %v differs from inferred count %v for PodSet at path position '%v'", dpr, ipr, ips.Path)
@@ -572,6 +1006,112 @@ func ValidatePodSets(declared []workloadv1beta2.AppWrapperPodSet, inferred []wor
 	return nil
 }
 
+// ValidateComponents checks the structural invariants of the components of an AppWrapper
+// that do not depend on the requesting user or on the state of the cluster:
+//  1. Every component must have a well-formed apiVersion
+//  2. AppWrappers must not contain other AppWrappers
+//  3. AppWrappers must only contain resources intended for their own namespace
+//  4. Explicitly named components must be unique per apiVersion and kind
+//  5. Every PodSet must be well-formed: the Path must exist and must be parseable as a PodSpecTemplate,
+//     the replicas must not be negative, and declared PodSets must match the inferred PodSets of known GVKs
+//  6. An EndpointPath must refer to a field of the status of the component
+//  7. A requested shared scratch volume must be well-formed
+//  8. AppWrappers must contain between 1 and 8 PodSets (Kueue invariant)
+func ValidateComponents(aw *workloadv1beta2.AppWrapper) field.ErrorList {
+	allErrors := field.ErrorList{}
+	components := aw.Spec.Components
+	componentsPath := field.NewPath("spec").Child("components")
+	podSpecCount := 0
+	componentNames := sets.New[string]()
+
+	for idx, component := range components {
+		compPath := componentsPath.Index(idx)
+		unstruct := &unstructured.Unstructured{}
+		_, gvk, err := unstructured.UnstructuredJSONScheme.Decode(component.Template.Raw, nil, unstruct)
+		if err != nil {
+			allErrors = append(allErrors, field.Invalid(compPath.Child("template"), component.Template, "failed to decode as JSON"))
+			continue
+		}
+
+		// 1. Reject malformed apiVersions; the GVK used for validation and inference must be the GVK that will be created
+		if err := ValidateAPIVersion(unstruct.GetAPIVersion(), unstruct.GetKind()); err != nil {
+			allErrors = append(allErrors, field.Invalid(compPath.Child("template").Child("apiVersion"), unstruct.GetAPIVersion(), err.Error()))
+			continue
+		}
+
+		// 2. Deny nested AppWrappers
+		if *gvk == workloadv1beta2.GroupVersion.WithKind("AppWrapper") {
+			allErrors = append(allErrors, field.Forbidden(compPath.Child("template"), "Nested AppWrappers are forbidden"))
+		}
+
+		// 3. Forbid creation of resources in other namespaces
+		if unstruct.GetNamespace() != "" && unstruct.GetNamespace() != aw.Namespace {
+			allErrors = append(allErrors, field.Forbidden(compPath.Child("template").Child("metadata").Child("namespace"),
+				"AppWrappers cannot create objects in other namespaces"))
+		}
+
+		// 4. Explicit names must be unique per apiVersion and kind; generated names cannot collide
+		if name := unstruct.GetName(); name != "" {
+			key := unstruct.GetAPIVersion() + ":" + unstruct.GetKind() + ":" + name
+			if componentNames.Has(key) {
+				allErrors = append(allErrors, field.Duplicate(compPath.Child("template").Child("metadata").Child("name"), name))
+			}
+			componentNames.Insert(key)
+		}
+
+		// 5. Every DeclaredPodSet must specify a path within Template to a v1.PodSpecTemplate and a non-negative number of replicas
+		podSetsPath := compPath.Child("podSets")
+		for psIdx, ps := range component.DeclaredPodSets {
+			podSetPath := podSetsPath.Index(psIdx)
+			if ps.Path == "" {
+				allErrors = append(allErrors, field.Required(podSetPath.Child("path"), "podspec must specify path"))
+			}
+			if _, err := GetPodTemplateSpec(unstruct, ps.Path); err != nil {
+				allErrors = append(allErrors, field.Invalid(podSetPath.Child("path"), ps.Path, fmt.Sprintf("path does not refer to a v1.PodSpecTemplate: %v", err)))
+			}
+			if ps.Replicas != nil && *ps.Replicas < 0 {
+				allErrors = append(allErrors, field.Invalid(podSetPath.Child("replicas"), *ps.Replicas, "replicas must not be negative"))
+			}
+		}
+
+		// 6. An EndpointPath must refer to a field of the status of the Component
+		if path := component.EndpointPath; path != "" && (!strings.HasPrefix(path, "status.") || strings.HasSuffix(path, ".")) {
+			allErrors = append(allErrors, field.Invalid(compPath.Child("endpointPath"), path, "path must refer to a field within status"))
+		}
+
+		// 7. A requested shared scratch volume must be well-formed
+		if value, ok := component.Annotations[workloadv1beta2.SharedScratchAnnotation]; ok {
+			if _, _, err := ParseSharedScratch(value); err != nil {
+				allErrors = append(allErrors, field.Invalid(compPath.Child("annotations").Key(workloadv1beta2.SharedScratchAnnotation), value, err.Error()))
+			}
+		}
+
+		// Validate PodSets for known GVKs
+		if inferred, err := InferPodSets(unstruct); err != nil {
+			allErrors = append(allErrors, field.Invalid(compPath.Child("template"), component.Template, fmt.Sprintf("error inferring PodSets: %v", err)))
+		} else {
+			if len(component.DeclaredPodSets) > len(inferred) {
+				podSpecCount += len(component.DeclaredPodSets)
+			} else {
+				podSpecCount += len(inferred)
+			}
+			if err := ValidatePodSets(component.DeclaredPodSets, inferred); err != nil {
+				allErrors = append(allErrors, field.Invalid(podSetsPath, component.DeclaredPodSets, err.Error()))
+			}
+		}
+	}
+
+	// 8. Enforce Kueue limitation that 0 < podSpecCount <= 8
+	if podSpecCount == 0 {
+		allErrors = append(allErrors, field.Invalid(componentsPath, components, "components contains no podspecs"))
+	}
+	if podSpecCount > 8 {
+		allErrors = append(allErrors, field.Invalid(componentsPath, components, fmt.Sprintf("components contains %v podspecs; at most 8 are allowed", podSpecCount)))
+	}
+
+	return allErrors
+}
+
 var labelRegex = regexp.MustCompile(`[^-_.\w]`)
 
 // SanitizeLabel sanitizes a string for use as a label
@@ -320,17 +320,16 @@ var _ = Describe("AppWrapper E2E Test", func() {
 		It("A running job is migrated away from an unhealthy node", func() {
 			aw := createAppWrapper(ctx, autopilotjob(200, 1))
 			appwrappers = append(appwrappers, aw)
-			awName := types.NamespacedName{Name: aw.Name, Namespace: aw.Namespace}
 			By("workload is running")
 			Expect(waitAWPodsReady(ctx, aw)).Should(Succeed())
+			Eventually(AppWrapperNodes(ctx, aw), 60*time.Second).Should(HaveLen(1))
 			By("node is labeled by autopilot")
-			nodeName, err := getNodeForAppwrapper(ctx, awName)
-			Expect(err).ShouldNot(HaveOccurred())
+			nodeName := AppWrapperNodes(ctx, aw)(Default)[0]
 			DeferCleanup(func() {
 				err := updateNode(ctx, nodeName, func(n *v1.Node) { delete(n.Labels, "autopilot.ibm.com/gpuhealth") })
 				Expect(err).ShouldNot(HaveOccurred())
 			})
-			err = updateNode(ctx, nodeName, func(n *v1.Node) { n.Labels["autopilot.ibm.com/gpuhealth"] = "EVICT" })
+			err := updateNode(ctx, nodeName, func(n *v1.Node) { n.Labels["autopilot.ibm.com/gpuhealth"] = "EVICT" })
 			Expect(err).ShouldNot(HaveOccurred())
 			By("workload is reset")
 			Eventually(AppWrapperPhase(ctx, aw), 120*time.Second).Should(Equal(workloadv1beta2.AppWrapperResetting))
@@ -18,7 +18,6 @@ package e2e
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	// . "github.com/onsi/ginkgo/v2"
@@ -208,20 +207,6 @@ func getAppWrapper(ctx context.Context, awName types.NamespacedName) *workloadv1
 	return aw
 }
 
-func getNodeForAppwrapper(ctx context.Context, awName types.NamespacedName) (string, error) {
-	podList := &v1.PodList{}
-	err := getClient(ctx).List(ctx, podList, &client.ListOptions{Namespace: awName.Namespace})
-	if err != nil {
-		return "", err
-	}
-	for _, pod := range podList.Items {
-		if awn, found := pod.Labels[workloadv1beta2.AppWrapperLabel]; found && awn == awName.Name {
-			return pod.Spec.NodeName, nil
-		}
-	}
-	return "", fmt.Errorf("No pods found for %v", awName)
-}
-
 func updateNode(ctx context.Context, nodeName string, update func(*v1.Node)) error {
 	for {
 		node := &v1.Node{}
@@ -309,6 +294,20 @@ func checkAppWrapperRunning(ctx context.Context, aw *workloadv1beta2.AppWrapper)
 	return aw2.Status.Phase == workloadv1beta2.AppWrapperRunning
 }
 
+func AppWrapperNodes(ctx context.Context, aw *workloadv1beta2.AppWrapper) func(g Gomega) []string {
+	name := aw.Name
+	namespace := aw.Namespace
+	return func(g Gomega) []string {
+		aw := &workloadv1beta2.AppWrapper{}
+		err := getClient(ctx).Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, aw)
+		g.Expect(err).NotTo(HaveOccurred())
+		if aw.Status.PodStatus == nil {
+			return nil
+		}
+		return aw.Status.PodStatus.Nodes
+	}
+}
+
 func AppWrapperPhase(ctx context.Context, aw *workloadv1beta2.AppWrapper) func(g Gomega) workloadv1beta2.AppWrapperPhase {
 	name := aw.Name
 	namespace := aw.Namespace